@@ -1,17 +1,22 @@
 package main
 
 import (
+	"os"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 
+	"github.com/dale-tomson/declutter/internal/cli"
 	"github.com/dale-tomson/declutter/internal/icon"
-	"github.com/dale-tomson/declutter/internal/theme"
 	"github.com/dale-tomson/declutter/internal/ui"
 )
 
 func main() {
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+	}
+
 	a := app.NewWithID("com.github.dale-tomson.declutter")
-	a.Settings().SetTheme(theme.New())
 	a.SetIcon(icon.Resource())
 
 	w := a.NewWindow("Declutter")
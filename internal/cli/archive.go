@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dale-tomson/declutter/internal/organizer"
+	"github.com/dale-tomson/declutter/internal/trash"
+)
+
+func runArchive(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("archive", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: declutter archive [flags] <organized folder>")
+		flags.PrintDefaults()
+	}
+
+	olderThan := flags.Int("older-than", 2, "archive months that ended more than this many `years` ago")
+	dryRun := flags.Bool("dry-run", false, "list the months without archiving them")
+	quiet := flags.Bool("quiet", false, "only print the summary")
+
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if flags.NArg() != 1 || *olderThan < 0 {
+		flags.Usage()
+		return 2
+	}
+
+	// The archive replaces the month folders, so they are deleted rather
+	// than trashed.
+	org := organizer.New(flags.Arg(0), logger(stdout, *quiet))
+	months, err := org.ArchiveCandidates(organizer.ArchiveCutoff(time.Now(), *olderThan))
+	if err != nil {
+		fmt.Fprintf(stderr, "declutter: %v\n", err)
+		return 1
+	}
+
+	if *dryRun {
+		for _, m := range months {
+			if !*quiet {
+				fmt.Fprintf(stdout, "Would archive: %s\n", m.Rel)
+			}
+		}
+		fmt.Fprintf(stdout, "Dry run: %d months to archive\n", len(months))
+		return 0
+	}
+
+	archived, err := org.Archive(months)
+	if err != nil {
+		fmt.Fprintf(stderr, "declutter: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "Done: %d archived, %d failed\n", archived, len(months)-archived)
+	if archived < len(months) {
+		return 1
+	}
+	return 0
+}
+
+func runRestore(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: declutter restore [flags] <archive.zip>...")
+		flags.PrintDefaults()
+	}
+
+	quiet := flags.Bool("quiet", false, "only print the summary")
+	useTrash := flags.Bool("trash", true, "move restored archives to the trash instead of deleting them")
+
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	code := 0
+	var restored, skipped int
+	for _, path := range flags.Args() {
+		org := organizer.NewWithOptions(".", trashOptions(*useTrash), logger(stdout, *quiet))
+		r, s, err := org.RestoreArchive(path)
+		restored += r
+		skipped += s
+		if err != nil {
+			fmt.Fprintf(stderr, "declutter: %s: %v\n", path, err)
+			code = 1
+		}
+	}
+	fmt.Fprintf(stdout, "Done: %d restored, %d kept\n", restored, skipped)
+	return code
+}
+
+func trashOptions(useTrash bool) organizer.Options {
+	var options organizer.Options
+	if useTrash {
+		if t, err := trash.New(); err == nil {
+			options.Trash = t
+		}
+	}
+	return options
+}
+
+func logger(stdout io.Writer, quiet bool) func(string) {
+	return func(msg string) {
+		if !quiet {
+			fmt.Fprintln(stdout, msg)
+		}
+	}
+}
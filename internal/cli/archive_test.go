@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRunArchiveAndRestore verifies old months are archived and restored
+// from the command line
+func TestRunArchiveAndRestore(t *testing.T) {
+	tmpDir := t.TempDir()
+	month := filepath.Join(tmpDir, "2019", "03-March")
+	if err := os.MkdirAll(month, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFileAt(t, filepath.Join(month, "a.txt"), time.Date(2019, 3, 15, 12, 0, 0, 0, time.UTC))
+
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"archive", "-dry-run", tmpDir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Run returned %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Would archive: 2019/03-March") {
+		t.Errorf("Unexpected output %q", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := Run([]string{"archive", "-quiet", tmpDir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Run returned %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Done: 1 archived, 0 failed") {
+		t.Errorf("Unexpected output %q", stdout.String())
+	}
+	if _, err := os.Stat(month); !os.IsNotExist(err) {
+		t.Errorf("Expected the month folder to be removed, got %v", err)
+	}
+
+	stdout.Reset()
+	if code := Run([]string{"restore", "-quiet", "-trash=false", month + ".zip"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Run returned %d: %s", code, stderr.String())
+	}
+	if _, err := os.Stat(filepath.Join(month, "a.txt")); err != nil {
+		t.Errorf("Expected a.txt back: %v", err)
+	}
+}
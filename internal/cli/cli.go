@@ -0,0 +1,340 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dale-tomson/declutter/internal/i18n"
+	"github.com/dale-tomson/declutter/internal/metadata"
+	"github.com/dale-tomson/declutter/internal/organizer"
+	"github.com/dale-tomson/declutter/internal/report"
+	"github.com/dale-tomson/declutter/internal/trash"
+)
+
+const usage = `Usage: declutter <command> [flags]
+
+Commands:
+  organize   sort a folder's files into Year/Month folders
+  archive    pack old month folders into verified .zip archives
+  restore    unpack month archives back into their folders
+  verify     check organized files against their SHA256SUMS
+  unlock     remove the lock of a run that is gone
+
+Run "declutter <command> -h" for the command's flags.
+Without a command, the graphical interface starts.
+`
+
+var commands = map[string]func(args []string, stdout, stderr io.Writer) int{
+	"organize": runOrganize,
+	"archive":  runArchive,
+	"restore":  runRestore,
+	"verify":   runVerify,
+	"unlock":   runUnlock,
+}
+
+// IsCommand reports whether arg selects the headless mode rather than the
+// GUI.
+func IsCommand(arg string) bool {
+	switch arg {
+	case "help", "-h", "-help", "--help":
+		return true
+	}
+	_, ok := commands[arg]
+	return ok
+}
+
+// Run executes a command and returns the process exit code: 0 on success,
+// 1 when the run failed or some files could not be moved, and 2 for usage
+// errors.
+func Run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		if IsCommand(args[0]) {
+			fmt.Fprint(stdout, usage)
+			return 0
+		}
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+	return command(args[1:], stdout, stderr)
+}
+
+// stringList collects a flag that may be given more than once.
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+func runOrganize(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("organize", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: declutter organize [flags] <folder>")
+		flags.PrintDefaults()
+	}
+
+	fixExtensions := flags.Bool("fix-extensions", false, "rename files whose extension is missing or does not match their content")
+	metadataDates := flags.Bool("metadata-dates", false, "use dates from media and document metadata; the same as -media-dates -document-dates")
+	mediaDates := flags.Bool("media-dates", false, "use dates from video and audio metadata")
+	documentDates := flags.Bool("document-dates", false, "use dates from PDF and office document metadata")
+	filenameDates := flags.Bool("filename-dates", false, "use dates found in file names")
+	mediaLocal := flags.Bool("media-local", false, "read MP4 and Matroska recording times as local time, for cameras that write them that way")
+	monthNames := flags.String("month-names", string(organizer.MonthStyleEnglish), "month folder names: english, localized, abbreviated or numeric")
+	language := flags.String("language", string(i18n.English), "language for localized month names")
+	timeZone := flags.String("time-zone", "local", "time zone policy: local, utc, metadata, an offset or a zone name")
+	dryRun := flags.Bool("dry-run", false, "plan the moves without touching any files")
+	quiet := flags.Bool("quiet", false, "only print the summary")
+	useTrash := flags.Bool("trash", true, "move what -cleanup removes to the trash instead of deleting it")
+	cleanup := flags.Bool("cleanup", false, "remove empty folders, empty files and junk after organizing; same as the three -cleanup- flags")
+	cleanupDirs := flags.Bool("cleanup-dirs", false, "remove empty folders after organizing")
+	cleanupFiles := flags.Bool("cleanup-files", false, "remove empty files other than dotfiles after organizing")
+	cleanupJunk := flags.Bool("cleanup-junk", false, "remove junk files after organizing")
+	resume := flags.Bool("resume", false, "finish a run that was interrupted, or start a new one if there is none")
+	rollback := flags.Bool("rollback", false, "move back the files of a run that was interrupted")
+	checksums := flags.Bool("checksums", false, "record moved files in a SHA256SUMS file in each month folder")
+	normalize := flags.String("normalize", "", "clean up file names: comma-separated `list` of nfc, chars, space and case, or all")
+	rename := flags.String("rename", "", "rename files by this `template`, such as "+string(organizer.DefaultRenameTemplate))
+	junk := flags.String("junk", "", "comma-separated junk file `patterns` for -cleanup (default "+strings.Join(organizer.DefaultJunkPatterns, ",")+")")
+	var patterns stringList
+	flags.Var(&patterns, "filename-pattern", "also find dates in file names with this `regexp`, which needs (?P<year>), (?P<month>) and (?P<day>) groups; implies -filename-dates and may be repeated")
+	var dateRules stringList
+	flags.Var(&dateRules, "dates-for", "choose the date sources for some file types, such as `.pdf,.docx=document,filename`, from media, document, filename and mtime; may be repeated")
+	var reports stringList
+	flags.Var(&reports, "report", "write a report to this `file` (.csv, .json or .html); may be repeated")
+
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if flags.NArg() != 1 || (*resume && *rollback) {
+		flags.Usage()
+		return 2
+	}
+	sourceDir := flags.Arg(0)
+
+	options, err := organizeOptions(*fixExtensions, *metadataDates || *mediaDates, *metadataDates || *documentDates, *filenameDates || len(patterns) > 0, *monthNames, *language, *timeZone)
+	if err != nil {
+		fmt.Fprintf(stderr, "declutter: %v\n", err)
+		return 2
+	}
+	if options.DateSourcesByExt, err = organizer.ParseDateRules(dateRules); err != nil {
+		fmt.Fprintf(stderr, "declutter: %v\n", err)
+		return 2
+	}
+	if len(patterns) > 0 {
+		if options.FilenameMatcher, err = metadata.NewFilenameMatcher(patterns...); err != nil {
+			fmt.Fprintf(stderr, "declutter: %v\n", err)
+			return 2
+		}
+	}
+	options.Checksums = *checksums
+	options.MediaTimesAreLocal = *mediaLocal
+	if options.Normalize, err = organizer.ParseNormalizeOptions(*normalize); err != nil {
+		fmt.Fprintf(stderr, "declutter: %v\n", err)
+		return 2
+	}
+	if options.RenameTemplate, err = organizer.ParseRenameTemplate(*rename); err != nil {
+		fmt.Fprintf(stderr, "declutter: %v\n", err)
+		return 2
+	}
+	options.Cleanup = organizer.CleanupOptions{
+		EmptyDirs:  *cleanup || *cleanupDirs,
+		EmptyFiles: *cleanup || *cleanupFiles,
+		Junk:       *cleanup || *cleanupJunk,
+	}
+	if options.Cleanup.Junk {
+		if options.Cleanup.JunkPatterns, err = organizer.ParseJunkPatterns(*junk); err != nil {
+			fmt.Fprintf(stderr, "declutter: %v\n", err)
+			return 2
+		}
+	}
+	if *useTrash {
+		if t, err := trash.New(); err == nil {
+			options.Trash = t
+		}
+	}
+	formats := make([]report.Format, len(reports))
+	for i, path := range reports {
+		if formats[i], err = report.FormatFor(path); err != nil {
+			fmt.Fprintf(stderr, "declutter: %v\n", err)
+			return 2
+		}
+	}
+
+	logf := func(msg string) {
+		if !*quiet {
+			fmt.Fprintln(stdout, msg)
+		}
+	}
+	org := organizer.NewWithOptions(sourceDir, options, logf)
+	var results []organizer.Result
+	org.SetResultCallback(func(r organizer.Result) {
+		results = append(results, r)
+	})
+
+	run, err := organizer.FindInterruptedRun(sourceDir)
+	if err != nil {
+		fmt.Fprintf(stderr, "declutter: %v\n", err)
+		return 1
+	}
+	if *rollback {
+		if run == nil {
+			fmt.Fprintln(stdout, "No interrupted run to roll back")
+			return 0
+		}
+		restored, failed := org.Rollback(run)
+		fmt.Fprintf(stdout, "Rolled back: %d restored, %d failed\n", restored, failed)
+		if failed > 0 {
+			return 1
+		}
+		return 0
+	}
+	if run != nil && !*resume {
+		fmt.Fprintf(stderr, "declutter: a run started %s was interrupted; pass -resume to finish it or -rollback to undo it\n",
+			run.Started.Local().Format(time.DateTime))
+		return 1
+	}
+
+	started := time.Now()
+	var plan []organizer.PlannedMove
+	execute := org.Execute
+	if run != nil {
+		plan = run.Plan
+		execute = func([]organizer.PlannedMove) (int, int, error) { return org.Resume(run) }
+	} else {
+		files, err := org.GetFiles()
+		if err != nil {
+			fmt.Fprintf(stderr, "declutter: %v\n", err)
+			return 1
+		}
+		plan = org.Plan(files)
+	}
+	cleanupItems, err := org.PlanCleanup()
+	if err != nil {
+		fmt.Fprintf(stderr, "declutter: %v\n", err)
+		return 1
+	}
+
+	issues := org.Preflight(plan)
+	for _, issue := range issues {
+		if issue.Blocking {
+			fmt.Fprintf(stderr, "declutter: %s\n", issue)
+		} else {
+			logf(fmt.Sprintf("Warning: %s", issue))
+		}
+	}
+	if organizer.HasBlocking(issues) {
+		return 1
+	}
+
+	var rep *report.Report
+	failed := 0
+	if *dryRun {
+		for _, move := range plan {
+			if move.Conflict {
+				logf(fmt.Sprintf("Would skip (already exists): %s", move.Name))
+			} else {
+				logf(fmt.Sprintf("Would move: %s → %s/%s", move.File.Name, move.Folder, move.Name))
+			}
+		}
+		for _, item := range cleanupItems {
+			logf(fmt.Sprintf("Would remove (%s): %s", item.Kind, item.Rel))
+		}
+		rep = report.FromPlan(sourceDir, started, plan)
+		fmt.Fprintf(stdout, "Dry run: %d files planned\n", len(plan))
+	} else {
+		moved, skipped, err := execute(plan)
+		if err != nil {
+			fmt.Fprintf(stderr, "declutter: %v\n", err)
+			return 1
+		}
+		removed, err := org.ExecuteCleanup(cleanupItems)
+		if err != nil {
+			fmt.Fprintf(stderr, "declutter: %v\n", err)
+			return 1
+		}
+		rep = report.FromResults(sourceDir, started, time.Now(), results)
+		overall, _ := rep.Summary()
+		failed = overall.Failed
+		fmt.Fprintf(stdout, "Done: %d moved, %d skipped, %d failed\n", moved, skipped, failed)
+		if c := options.Cleanup; c.EmptyDirs || c.EmptyFiles || c.Junk {
+			fmt.Fprintf(stdout, "Cleaned up: %d removed\n", removed)
+		}
+	}
+
+	for i, path := range reports {
+		if err := writeReport(rep, path, formats[i]); err != nil {
+			fmt.Fprintf(stderr, "declutter: writing report: %v\n", err)
+			return 1
+		}
+	}
+
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+func organizeOptions(fixExtensions, mediaDates, documentDates, filenameDates bool, monthNames, language, timeZone string) (organizer.Options, error) {
+	options := organizer.Options{FixExtensions: fixExtensions}
+	if mediaDates {
+		options.DateSources = append(options.DateSources, organizer.DateSourceMedia)
+	}
+	if documentDates {
+		options.DateSources = append(options.DateSources, organizer.DateSourceDocument)
+	}
+	if filenameDates {
+		options.DateSources = append(options.DateSources, organizer.DateSourceFilename)
+	}
+
+	style := organizer.MonthStyle(monthNames)
+	switch style {
+	case organizer.MonthStyleEnglish, organizer.MonthStyleLocalized, organizer.MonthStyleAbbreviated, organizer.MonthStyleNumeric:
+	default:
+		return options, fmt.Errorf("unknown month name style %q", monthNames)
+	}
+	lang := i18n.Language(language)
+	known := false
+	for _, l := range i18n.Languages() {
+		known = known || l == lang
+	}
+	if !known {
+		return options, fmt.Errorf("unsupported language %q", language)
+	}
+	options.MonthFormat = organizer.MonthFormat{Style: style, Language: lang}
+
+	policy, err := organizer.ParseTimeZonePolicy(timeZone)
+	if err != nil {
+		return options, err
+	}
+	options.TimeZone = policy
+	return options, nil
+}
+
+func writeReport(rep *report.Report, path string, format report.Format) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := rep.Write(f, format); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,342 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dale-tomson/declutter/internal/report"
+)
+
+func writeFileAt(t *testing.T, path string, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(filepath.Base(path)), 0644); err != nil {
+		t.Fatalf("Failed to create %s: %v", path, err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set mod time: %v", err)
+	}
+}
+
+func mp4Box(typ string, payload []byte) []byte {
+	buf := binary.BigEndian.AppendUint32(nil, uint32(8+len(payload)))
+	return append(append(buf, typ...), payload...)
+}
+
+// TestIsCommand verifies only known commands bypass the GUI
+func TestIsCommand(t *testing.T) {
+	for arg, want := range map[string]bool{
+		"organize":        true,
+		"archive":         true,
+		"help":            true,
+		"--help":          true,
+		"-psn_0_12345":    false,
+		"/home/me/Photos": false,
+	} {
+		if got := IsCommand(arg); got != want {
+			t.Errorf("IsCommand(%q) = %v, want %v", arg, got, want)
+		}
+	}
+}
+
+// TestRunOrganize verifies a headless run moves files and writes reports
+func TestRunOrganize(t *testing.T) {
+	tmpDir := t.TempDir()
+	source := filepath.Join(tmpDir, "src")
+	if err := os.Mkdir(source, 0755); err != nil {
+		t.Fatalf("Failed to create source: %v", err)
+	}
+	writeFileAt(t, filepath.Join(source, "a.txt"), time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	writeFileAt(t, filepath.Join(source, "b.txt"), time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC))
+	jsonReport := filepath.Join(tmpDir, "report.json")
+	htmlReport := filepath.Join(tmpDir, "report.html")
+
+	var stdout, stderr bytes.Buffer
+	code := Run([]string{"organize", "-quiet", "-time-zone", "utc", "-month-names", "numeric",
+		"-report", jsonReport, "-report", htmlReport, source}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("Run returned %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Done: 2 moved, 0 skipped, 0 failed") {
+		t.Errorf("Unexpected output %q", stdout.String())
+	}
+	if _, err := os.Stat(filepath.Join(source, "2024", "03", "a.txt")); err != nil {
+		t.Errorf("Expected a.txt to be moved: %v", err)
+	}
+
+	data, err := os.ReadFile(jsonReport)
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+	var rep report.Report
+	if err := json.Unmarshal(data, &rep); err != nil {
+		t.Fatalf("Failed to parse report: %v", err)
+	}
+	if len(rep.Files) != 2 || rep.Files[0].Outcome != "moved" {
+		t.Errorf("Unexpected report %+v", rep.Files)
+	}
+	if _, err := os.Stat(htmlReport); err != nil {
+		t.Errorf("Expected HTML report: %v", err)
+	}
+}
+
+// TestRunOrganizeDryRun verifies a dry run leaves files in place
+func TestRunOrganizeDryRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileAt(t, filepath.Join(tmpDir, "a.txt"), time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"organize", "-dry-run", "-time-zone", "utc", tmpDir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Run returned %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Would move: a.txt → 2024/03-March/a.txt") {
+		t.Errorf("Unexpected output %q", stdout.String())
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "a.txt")); err != nil {
+		t.Errorf("Expected a.txt to stay in place: %v", err)
+	}
+}
+
+// TestRunOrganizeCleanup verifies -cleanup removes empty folders and junk,
+// and only lists them in a dry run
+func TestRunOrganizeCleanup(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileAt(t, filepath.Join(tmpDir, "a.txt"), time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	writeFileAt(t, filepath.Join(tmpDir, "Thumbs.db"), time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	if err := os.Mkdir(filepath.Join(tmpDir, "empty"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"organize", "-dry-run", "-cleanup", tmpDir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Run returned %d: %s", code, stderr.String())
+	}
+	for _, want := range []string{"Would remove (junk): Thumbs.db", "Would remove (empty-folder): empty"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("Expected %q in %q", want, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+	if code := Run([]string{"organize", "-quiet", "-cleanup", "-trash=false", tmpDir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Run returned %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Cleaned up: 2 removed") {
+		t.Errorf("Unexpected output %q", stdout.String())
+	}
+	for _, name := range []string{"Thumbs.db", "empty"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, name)); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed, got %v", name, err)
+		}
+	}
+}
+
+// TestRunOrganizeCleanupJunk verifies -cleanup-junk removes junk but keeps
+// empty folders and files
+func TestRunOrganizeCleanupJunk(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileAt(t, filepath.Join(tmpDir, "Thumbs.db"), time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	if err := os.Mkdir(filepath.Join(tmpDir, "empty"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"organize", "-quiet", "-cleanup-junk", "-trash=false", tmpDir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Run returned %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Cleaned up: 1 removed") {
+		t.Errorf("Unexpected output %q", stdout.String())
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "Thumbs.db")); !os.IsNotExist(err) {
+		t.Errorf("Expected Thumbs.db to be removed, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "empty")); err != nil {
+		t.Errorf("Expected the empty folder kept: %v", err)
+	}
+}
+
+// TestRunOrganizeNormalize verifies -normalize renames files as they are
+// moved and the rename shows in the log
+func TestRunOrganizeNormalize(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileAt(t, filepath.Join(tmpDir, " notes .txt"), time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"organize", "-normalize", "space", tmpDir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Run returned %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Moved:  notes .txt → 2024/03-March/notes.txt") {
+		t.Errorf("Expected the rename in %q", stdout.String())
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "2024", "03-March", "notes.txt")); err != nil {
+		t.Errorf("Expected notes.txt to be moved: %v", err)
+	}
+}
+
+// TestRunOrganizeRename verifies -rename names files by the template as
+// they are moved
+func TestRunOrganizeRename(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileAt(t, filepath.Join(tmpDir, "IMG_1234.jpg"), time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"organize", "-time-zone", "utc", "-rename", "{date}_{name}", tmpDir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Run returned %d: %s", code, stderr.String())
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "2024", "03-March", "2024-03-15_IMG_1234.jpg")); err != nil {
+		t.Errorf("Expected IMG_1234.jpg to be renamed: %v", err)
+	}
+}
+
+// TestRunOrganizeMediaLocal verifies -media-local files a video by the
+// wall clock time its camera wrote rather than as UTC
+func TestRunOrganizeMediaLocal(t *testing.T) {
+	tmpDir := t.TempDir()
+	mvhd := make([]byte, 100)
+	recorded := time.Date(2023, 12, 31, 23, 30, 0, 0, time.UTC)
+	binary.BigEndian.PutUint32(mvhd[4:], uint32(recorded.Sub(time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC))/time.Second))
+	data := append(mp4Box("ftyp", []byte("isom\x00\x00\x00\x00")), mp4Box("moov", mp4Box("mvhd", mvhd))...)
+	if err := os.WriteFile(filepath.Join(tmpDir, "clip.mp4"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"organize", "-quiet", "-metadata-dates", "-media-local", "-time-zone", "+05:00", tmpDir}
+	if code := Run(args, &stdout, &stderr); code != 0 {
+		t.Fatalf("Run returned %d: %s", code, stderr.String())
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "2023", "12-December", "clip.mp4")); err != nil {
+		t.Errorf("Expected clip.mp4 in December 2023: %v", err)
+	}
+}
+
+// TestRunOrganizeFilenamePattern verifies -filename-pattern dates files by
+// a custom pattern in their names
+func TestRunOrganizeFilenamePattern(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileAt(t, filepath.Join(tmpDir, "scan 24.12.2023.pdf"), time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"organize", "-quiet", "-filename-pattern", `(?P<day>\d{2})\.(?P<month>\d{2})\.(?P<year>\d{4})`, tmpDir}
+	if code := Run(args, &stdout, &stderr); code != 0 {
+		t.Fatalf("Run returned %d: %s", code, stderr.String())
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "2023", "12-December", "scan 24.12.2023.pdf")); err != nil {
+		t.Errorf("Expected the scan in December 2023: %v", err)
+	}
+}
+
+// TestRunOrganizeDatesFor verifies -dates-for picks the date sources of a
+// file type without enabling them for the rest
+func TestRunOrganizeDatesFor(t *testing.T) {
+	tmpDir := t.TempDir()
+	march := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	pdf := filepath.Join(tmpDir, "invoice.pdf")
+	if err := os.WriteFile(pdf, []byte("%PDF-1.4\n<< /CreationDate (D:20231104083000Z) >>\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(pdf, march, march); err != nil {
+		t.Fatal(err)
+	}
+	writeFileAt(t, filepath.Join(tmpDir, "2023-11-04 notes.txt"), march)
+
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"organize", "-quiet", "-time-zone", "utc", "-dates-for", ".pdf=document", tmpDir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Run returned %d: %s", code, stderr.String())
+	}
+	for _, path := range []string{"2023/11-November/invoice.pdf", "2024/03-March/2023-11-04 notes.txt"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, filepath.FromSlash(path))); err != nil {
+			t.Errorf("Expected %s: %v", path, err)
+		}
+	}
+}
+
+// TestRunOrganizeInterrupted verifies an interrupted run must be resumed or
+// rolled back before another starts
+func TestRunOrganizeInterrupted(t *testing.T) {
+	tmpDir := t.TempDir()
+	source := filepath.Join(tmpDir, "a.txt")
+	writeFileAt(t, source, time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	dest := filepath.Join(tmpDir, "2024", "03-March", "a.txt")
+	journal, _ := json.Marshal(map[string]any{
+		"started": time.Now(),
+		"plan":    []map[string]any{{"File": map[string]any{"Path": source, "Name": "a.txt"}, "Folder": "2024/03-March", "Name": "a.txt", "Dest": dest}},
+	})
+	if err := os.WriteFile(filepath.Join(tmpDir, ".declutter-run.json"), journal, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"organize", tmpDir}, &stdout, &stderr); code != 1 || !strings.Contains(stderr.String(), "-resume") {
+		t.Errorf("Expected the interrupted run to be reported, got %d: %q", code, stderr.String())
+	}
+
+	stdout.Reset()
+	if code := Run([]string{"organize", "-quiet", "-resume", tmpDir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Run returned %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Done: 1 moved, 0 skipped, 0 failed") {
+		t.Errorf("Unexpected output %q", stdout.String())
+	}
+	if _, err := os.Stat(dest); err != nil {
+		t.Errorf("Expected a.txt to be moved: %v", err)
+	}
+
+	stdout.Reset()
+	if code := Run([]string{"organize", "-rollback", tmpDir}, &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), "No interrupted run") {
+		t.Errorf("Expected nothing to roll back, got %d: %q", code, stdout.String())
+	}
+}
+
+// TestRunOrganizePreflight verifies a folder that can't be written is
+// reported before anything is moved
+func TestRunOrganizePreflight(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("permissions don't apply to root")
+	}
+	tmpDir := t.TempDir()
+	writeFileAt(t, filepath.Join(tmpDir, "a.txt"), time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	if err := os.Chmod(tmpDir, 0555); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chmod(tmpDir, 0755)
+
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"organize", "-quiet", tmpDir}, &stdout, &stderr); code != 1 {
+		t.Errorf("Expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "folder is not writable") {
+		t.Errorf("Expected the unwritable folder to be reported, got %q", stderr.String())
+	}
+}
+
+// TestRunUsageErrors verifies bad arguments exit with status 2
+func TestRunUsageErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{},
+		{"shuffle"},
+		{"organize"},
+		{"organize", "-month-names", "roman", "."},
+		{"organize", "-time-zone", "Nowhere/Special", "."},
+		{"organize", "-report", "out.txt", "."},
+		{"organize", "-cleanup", "-junk", "[a-", "."},
+		{"organize", "-resume", "-rollback", "."},
+		{"organize", "-normalize", "upper", "."},
+		{"organize", "-rename", "{when}", "."},
+		{"organize", "-dates-for", "pdf=exif", "."},
+		{"organize", "-filename-pattern", "(?P<year>\\d{4})", "."},
+		{"archive"},
+		{"archive", "-older-than", "-1", "."},
+		{"restore"},
+	} {
+		var stdout, stderr bytes.Buffer
+		if code := Run(args, &stdout, &stderr); code != 2 {
+			t.Errorf("Run(%q) = %d, want 2", args, code)
+		}
+	}
+}
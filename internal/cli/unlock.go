@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dale-tomson/declutter/internal/organizer"
+)
+
+func runUnlock(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("unlock", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: declutter unlock <folder>")
+		fmt.Fprintln(stderr, "Removes the lock of a run that is gone but whose lock doesn't look stale yet.")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+	dir := flags.Arg(0)
+
+	owner := organizer.LockOwner(dir)
+	if err := organizer.ForceUnlock(dir); err != nil {
+		fmt.Fprintf(stderr, "declutter: %v\n", err)
+		return 1
+	}
+	if owner == nil {
+		fmt.Fprintf(stdout, "%s is not locked\n", dir)
+		return 0
+	}
+	fmt.Fprintf(stdout, "Unlocked %s (held by process %d on %s since %s)\n",
+		dir, owner.PID, owner.Hostname, owner.Started.Local().Format(time.DateTime))
+	return 0
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRunUnlock verifies a locked folder refuses to be organized until it
+// is unlocked
+func TestRunUnlock(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileAt(t, filepath.Join(tmpDir, "a.txt"), time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	now := time.Now().UTC().Format(time.RFC3339)
+	lock := `{"pid":42,"hostname":"elsewhere.invalid","started":"` + now + `","updated":"` + now + `"}`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".declutter-lock"), []byte(lock), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"organize", "-quiet", tmpDir}, &stdout, &stderr); code != 1 {
+		t.Fatalf("Expected exit code 1 while locked, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "process 42 on elsewhere.invalid") {
+		t.Errorf("Expected the lock holder in %q", stderr.String())
+	}
+
+	stdout.Reset()
+	if code := Run([]string{"unlock", tmpDir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Run returned %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Unlocked") {
+		t.Errorf("Unexpected output %q", stdout.String())
+	}
+	if code := Run([]string{"organize", "-quiet", tmpDir}, &stdout, &stderr); code != 0 {
+		t.Errorf("Run returned %d after unlocking: %s", code, stderr.String())
+	}
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/dale-tomson/declutter/internal/organizer"
+)
+
+func runVerify(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: declutter verify [flags] <organized folder>")
+		flags.PrintDefaults()
+	}
+
+	quiet := flags.Bool("quiet", false, "only print the summary")
+
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	org := organizer.New(flags.Arg(0), logger(stdout, *quiet))
+	results, err := org.VerifyChecksums()
+	if err != nil {
+		fmt.Fprintf(stderr, "declutter: %v\n", err)
+		return 1
+	}
+
+	counts := make(map[organizer.ChecksumStatus]int)
+	for _, r := range results {
+		counts[r.Status]++
+	}
+	fmt.Fprintf(stdout, "Verified: %d ok, %d changed, %d missing, %d unexpected\n",
+		counts[organizer.ChecksumOK], counts[organizer.ChecksumChanged],
+		counts[organizer.ChecksumMissing], counts[organizer.ChecksumUnexpected])
+	if counts[organizer.ChecksumOK] < len(results) {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRunVerify verifies files organized with -checksums can be checked
+// and that a changed file fails the check
+func TestRunVerify(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileAt(t, filepath.Join(tmpDir, "a.txt"), time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	writeFileAt(t, filepath.Join(tmpDir, "b.txt"), time.Date(2024, 3, 16, 12, 0, 0, 0, time.UTC))
+
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"organize", "-quiet", "-checksums", tmpDir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Run returned %d: %s", code, stderr.String())
+	}
+
+	stdout.Reset()
+	if code := Run([]string{"verify", tmpDir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Run returned %d: %s", code, stdout.String())
+	}
+	if !strings.Contains(stdout.String(), "Verified: 2 ok, 0 changed, 0 missing, 0 unexpected") {
+		t.Errorf("Unexpected output %q", stdout.String())
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "2024", "03-March", "a.txt"), []byte("edited"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stdout.Reset()
+	if code := Run([]string{"verify", tmpDir}, &stdout, &stderr); code != 1 {
+		t.Errorf("Expected exit code 1 for a changed file, got %d", code)
+	}
+	if !strings.Contains(stdout.String(), "Changed: 2024/03-March/a.txt") {
+		t.Errorf("Unexpected output %q", stdout.String())
+	}
+}
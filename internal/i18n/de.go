@@ -0,0 +1,193 @@
+package i18n
+
+var de = catalog{
+	name: "Deutsch",
+	months: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni",
+		"Juli", "August", "September", "Oktober", "November", "Dezember"},
+	monthsShort: [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun",
+		"Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+	messages: map[string]string{
+		"app.description":            "Ordnen Sie Ihre Dateien anhand ihrer Zeitstempel\nin Jahr/Monat-Ordner",
+		"folder.none":                "Kein Ordner ausgewählt",
+		"folder.selected":            "Ausgewählter Ordner:",
+		"folder.error":               "Fehler beim Lesen des Ordners: %v",
+		"folder.found":               "%d Dateien zum Ordnen gefunden",
+		"folder.mismatched":          "%d Dateien haben eine fehlende oder falsche Endung",
+		"button.select":              "Ordner auswählen",
+		"button.organize":            "Dateien ordnen",
+		"button.settings":            "Einstellungen",
+		"button.saveReport":          "Bericht speichern",
+		"button.addToQueue":          "Zur Warteschlange",
+		"button.runQueue":            "Warteschlange starten",
+		"button.clearQueue":          "Erledigte entfernen",
+		"option.fixExtensions":       "Falsche Dateiendungen korrigieren",
+		"option.mediaDates":          "Datum aus Video- und Audiometadaten verwenden",
+		"option.documentDates":       "Datum aus Dokumentmetadaten verwenden",
+		"option.filenameDates":       "Datum aus Dateinamen verwenden",
+		"tab.files":                  "Dateien",
+		"tab.log":                    "Aktivitätsprotokoll",
+		"tab.queue":                  "Warteschlange",
+		"tab.folders":                "Ordner",
+		"table.name":                 "Name",
+		"table.date":                 "Datum",
+		"table.source":               "Datumsquelle",
+		"table.destination":          "Ziel",
+		"table.status":               "Status",
+		"table.error":                "Fehler",
+		"table.search":               "Dateien suchen...",
+		"table.errorsOnly":           "Nur Fehler anzeigen",
+		"result.pending":             "Ausstehend",
+		"result.moved":               "Verschoben",
+		"result.skipped":             "Übersprungen",
+		"result.excluded":            "Ausgeschlossen",
+		"result.failed":              "Fehlgeschlagen",
+		"result.exists":              "Existiert bereits",
+		"source.mtime":               "Änderungsdatum",
+		"source.media":               "Medien-Metadaten",
+		"source.document":            "Dokument-Metadaten",
+		"source.filename":            "Dateiname",
+		"dialog.info":                "Hinweis",
+		"dialog.selectFirst":         "Bitte wählen Sie zuerst einen Ordner",
+		"dialog.confirm.title":       "Ordnen bestätigen",
+		"dialog.cancel":              "Abbrechen",
+		"preview.intro":              "Dateien in %s werden in diese Ordner verschoben. Entfernen Sie das Häkchen bei allem, was bleiben soll.",
+		"preview.summary":            "%d Dateien in %d Ordner, %d Konflikte werden übersprungen",
+		"preview.folder":             "%s (%d Dateien)",
+		"preview.conflicts":          "%d Konflikte",
+		"preview.conflict":           "%s (existiert bereits, wird übersprungen)",
+		"status.scanning":            "Dateien werden gesucht...",
+		"status.organizing":          "Wird geordnet...",
+		"status.error":               "Ein Fehler ist aufgetreten",
+		"status.noFiles":             "Keine Dateien zum Ordnen",
+		"status.done":                "Fertig! %d Dateien verschoben, %d übersprungen",
+		"log.error":                  "Fehler: %v",
+		"log.noFiles":                "Keine Dateien zum Ordnen gefunden",
+		"log.starting":               "Ordnen wird gestartet...",
+		"log.organizeError":          "Fehler beim Ordnen: %v",
+		"log.complete":               "✅ Fertig! Verschoben: %d, Übersprungen: %d",
+		"report.saved":               "Bericht gespeichert unter %s",
+		"report.error":               "Bericht konnte nicht gespeichert werden: %v",
+		"queue.pending":              "Wartet",
+		"queue.running":              "Wird geordnet...",
+		"queue.done":                 "Fertig: %d verschoben, %d übersprungen, %d fehlgeschlagen",
+		"queue.failed":               "Fehlgeschlagen: %v",
+		"queue.added":                "%d Ordner zur Warteschlange hinzugefügt",
+		"queue.starting":             "%s wird geordnet...",
+		"queue.finished":             "Warteschlange abgeschlossen",
+		"recent.never":               "Noch nicht geordnet",
+		"recent.lastRun":             "Zuletzt am %s: %d verschoben, %d übersprungen, %d fehlgeschlagen",
+		"recent.pin":                 "Anheften",
+		"recent.unpin":               "Lösen",
+		"settings.title":             "Einstellungen",
+		"settings.language":          "Sprache",
+		"settings.theme":             "Design",
+		"settings.largeText":         "Größere Schrift",
+		"settings.themeFile":         "Designdatei",
+		"settings.themeFile.hint":    "Optionale .json- oder .toml-Datei mit eigenen Farben, Größen und Schriften",
+		"settings.monthNames":        "Namen der Monatsordner",
+		"settings.timeZone":          "Zeitzone",
+		"settings.timeZone.hint":     "local, utc, metadata, +02:00 oder Europe/Berlin",
+		"settings.mediaLocal":        "Videozeiten sind Ortszeit",
+		"settings.mediaLocal.hint":   "Für Kameras, die MP4- und MKV-Zeiten in Ortszeit statt UTC speichern",
+		"settings.namePatterns":      "Datumsmuster",
+		"settings.namePatterns.hint": "Zusätzliche reguläre Ausdrücke für Datumsangaben in Dateinamen, einer pro Zeile, mit den Gruppen (?P<year>), (?P<month>) und (?P<day>)",
+		"settings.dateRules":         "Datumsquellen je Dateityp",
+		"settings.dateRules.hint":    "Eine Regel pro Zeile, etwa .pdf,.docx=document,filename; Quellen sind media, document, filename und mtime",
+		"settings.save":              "Speichern",
+		"settings.cancel":            "Abbrechen",
+		"settings.invalid":           "Ungültige Einstellung: %v",
+		"theme.system":               "Wie System",
+		"theme.light":                "Hell",
+		"theme.dark":                 "Dunkel",
+		"theme.highContrast":         "Hoher Kontrast",
+		"theme.fileError":            "Eigenes Design nicht geladen: %v",
+		"month.english":              "Englisch (03-March)",
+		"month.localized":            "Übersetzt (%s)",
+		"month.abbreviated":          "Abgekürzt (%s)",
+		"month.numeric":              "Nur Zahlen (03)",
+		"menu.file":                  "Datei",
+		"menu.edit":                  "Bearbeiten",
+		"menu.view":                  "Ansicht",
+		"menu.undo":                  "Letzten Lauf rückgängig machen",
+		"menu.cancel":                "Lauf abbrechen",
+		"menu.find":                  "Im Protokoll suchen",
+		"menu.clearLog":              "Protokoll leeren",
+		"log.search":                 "Im Protokoll suchen...",
+		"undo.title":                 "Letzten Lauf rückgängig machen",
+		"undo.confirm":               "Die %d Dateien des letzten Laufs in %s an ihren ursprünglichen Ort zurückverschieben?",
+		"undo.done":                  "%d Dateien wiederhergestellt, %d konnten nicht wiederhergestellt werden",
+		"undo.nothing":               "Es gibt keinen Lauf zum Rückgängigmachen",
+		"status.cancelling":          "Wird abgebrochen...",
+		"status.cancelled":           "Abgebrochen",
+		"status.undoing":             "Letzter Lauf wird rückgängig gemacht...",
+		"settings.notify":            "Benachrichtigungen",
+		"notify.unfocused":           "Wenn das Fenster im Hintergrund ist",
+		"notify.always":              "Immer",
+		"notify.off":                 "Aus",
+		"notify.default":             "Wie in den Einstellungen",
+		"notify.doneTitle":           "%s geordnet",
+		"notify.failedTitle":         "Ordnen von %s fehlgeschlagen",
+		"notify.batchTitle":          "%d Ordner geordnet",
+		"notify.counts":              "%d verschoben, %d übersprungen, %d fehlgeschlagen",
+		"notify.foldersFailed":       "%d Ordner konnten nicht geordnet werden",
+		"notify.failedFiles":         "Fehlgeschlagen: %s",
+		"notify.failedFilesMore":     "Fehlgeschlagen: %s und %d weitere",
+		"option.emptyDirs":           "Leere Ordner entfernen",
+		"option.emptyFiles":          "Leere Dateien entfernen",
+		"option.junk":                "Datenmüll entfernen",
+		"settings.junkPatterns":      "Datenmüll",
+		"settings.junkPatterns.hint": "Kommagetrennte Namensmuster, die beim Aufräumen entfernt werden",
+		"settings.rename":            "Dateien umbenennen",
+		"settings.rename.hint":       "Platzhalter wie {date}, {time}, {name}, {ext}, {camera}, {hash} und {counter}; leer lassen, um Namen zu behalten",
+		"preview.cleanup":            "Aufräumen (%d)",
+		"preview.cleanupItem":        "%s (%s)",
+		"preview.cleanupSummary":     "%d leere oder unnötige Einträge werden entfernt",
+		"cleanup.empty-folder":       "leerer Ordner",
+		"cleanup.empty-file":         "leere Datei",
+		"cleanup.junk":               "Datenmüll",
+		"cleanup.done":               "%d Einträge aufgeräumt",
+		"menu.archive":               "Alte Monate archivieren",
+		"menu.restoreArchive":        "Archiv wiederherstellen",
+		"archive.title":              "Alte Monate archivieren",
+		"archive.years":              "Älter als (Jahre)",
+		"archive.years.hint":         "Monate werden als .zip gepackt, das einzige Format",
+		"archive.next":               "Weiter",
+		"archive.invalidYears":       "Bitte eine ganze Zahl von Jahren eingeben",
+		"archive.none":               "Keine Monate älter als %d Jahre zu archivieren",
+		"archive.confirm":            "%d Monatsordner in geprüfte .zip-Archive packen und die Originale endgültig löschen?",
+		"archive.done":               "%d von %d Monaten archiviert",
+		"archive.restored":           "%d Dateien wiederhergestellt, %d vorhandene behalten",
+		"status.archiving":           "Archiviere...",
+		"status.restoring":           "Stelle wieder her...",
+		"option.checksums":           "Prüfsummen schreiben",
+		"option.normalize":           "Dateinamen bereinigen",
+		"menu.verify":                "Prüfsummen überprüfen",
+		"verify.title":               "Prüfsummen überprüfen",
+		"verify.done":                "%d in Ordnung, %d geändert, %d fehlend, %d unerwartet",
+		"verify.none":                "Keine SHA256SUMS-Dateien in den Monatsordnern gefunden",
+		"status.verifying":           "Überprüfe...",
+		"resume.title":               "Unterbrochener Lauf",
+		"resume.message":             "Ein am %s gestarteter Lauf in diesem Ordner wurde nach %d von %d Dateien unterbrochen. Fortsetzen verschiebt den Rest, Zurücksetzen bringt die verschobenen Dateien zurück.",
+		"resume.resume":              "Fortsetzen",
+		"resume.rollback":            "Zurücksetzen",
+		"resume.later":               "Später",
+		"resume.pending":             "In diesem Ordner muss zuerst ein unterbrochener Lauf fortgesetzt oder zurückgesetzt werden",
+		"status.rollingBack":         "Setze zurück...",
+		"lock.title":                 "Ordner in Benutzung",
+		"lock.message":               "Ein anderer Lauf ordnet diesen Ordner gerade: Prozess %d auf %s, gestartet am %s. Wenn dieser Lauf beendet ist, etwa weil sein Rechner abgestürzt ist, können Sie die Sperre aufheben.",
+		"lock.force":                 "Sperre aufheben",
+		"lock.unlocked":              "Sperre aufgehoben: %s",
+		"preflight.title":            "Vor dem Ordnen",
+		"preflight.blocking":         "Das Ordnen kann erst beginnen, wenn diese Probleme behoben sind:",
+		"preflight.warnings":         "Einige Dateien können so nicht verschoben werden. Mit den übrigen fortfahren?",
+		"preflight.continue":         "Fortfahren",
+		"preflight.space":            "Nicht genug freier Speicher: %s zu kopieren, %s verfügbar",
+		"preflight.unwritable":       "%s: Ordner ist nicht beschreibbar",
+		"preflight.unreadable":       "%s: Datei kann nicht gelesen werden",
+		"preflight.read-only":        "%s: Datei ist schreibgeschützt, das Original kann nach dem Kopieren nicht entfernt werden",
+		"preflight.path-length":      "%s: Name oder Pfad zu lang für das Ziellaufwerk",
+		"preflight.invalid-name":     "%s: Name auf dem Ziellaufwerk nicht erlaubt",
+		"preflight.sourceFolder":     "Der ausgewählte Ordner",
+		"status.preflightFailed":     "Probleme vor dem Ordnen gefunden",
+	},
+}
@@ -0,0 +1,193 @@
+package i18n
+
+var en = catalog{
+	name: "English",
+	months: [12]string{"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December"},
+	monthsShort: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun",
+		"Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	messages: map[string]string{
+		"app.description":            "Organize your files into Year/Month folders\nbased on their timestamps",
+		"folder.none":                "No folder selected",
+		"folder.selected":            "Selected Folder:",
+		"folder.error":               "Error reading folder: %v",
+		"folder.found":               "Found %d files to organize",
+		"folder.mismatched":          "%d files have a missing or mismatched extension",
+		"button.select":              "Select Folder",
+		"button.organize":            "Organize Files",
+		"button.settings":            "Settings",
+		"button.saveReport":          "Save report",
+		"button.addToQueue":          "Add to queue",
+		"button.runQueue":            "Run queue",
+		"button.clearQueue":          "Clear finished",
+		"option.fixExtensions":       "Fix mislabeled file extensions",
+		"option.mediaDates":          "Use dates from video and audio metadata",
+		"option.documentDates":       "Use dates from document metadata",
+		"option.filenameDates":       "Use dates in file names",
+		"tab.files":                  "Files",
+		"tab.log":                    "Activity log",
+		"tab.queue":                  "Queue",
+		"tab.folders":                "Folders",
+		"table.name":                 "Name",
+		"table.date":                 "Date",
+		"table.source":               "Date source",
+		"table.destination":          "Destination",
+		"table.status":               "Status",
+		"table.error":                "Error",
+		"table.search":               "Search files...",
+		"table.errorsOnly":           "Only show errors",
+		"result.pending":             "Pending",
+		"result.moved":               "Moved",
+		"result.skipped":             "Skipped",
+		"result.excluded":            "Excluded",
+		"result.failed":              "Failed",
+		"result.exists":              "Already exists",
+		"source.mtime":               "Modified",
+		"source.media":               "Media metadata",
+		"source.document":            "Document metadata",
+		"source.filename":            "File name",
+		"dialog.info":                "Info",
+		"dialog.selectFirst":         "Please select a folder first",
+		"dialog.confirm.title":       "Confirm Organization",
+		"dialog.cancel":              "Cancel",
+		"preview.intro":              "Files in %s will be moved into these folders. Untick anything you want to leave in place.",
+		"preview.summary":            "%d files into %d folders, %d conflicts will be skipped",
+		"preview.folder":             "%s (%d files)",
+		"preview.conflicts":          "%d conflicts",
+		"preview.conflict":           "%s (already exists, will be skipped)",
+		"status.scanning":            "Scanning files...",
+		"status.organizing":          "Organizing...",
+		"status.error":               "Error occurred",
+		"status.noFiles":             "No files to organize",
+		"status.done":                "Done! %d files moved, %d skipped",
+		"log.error":                  "Error: %v",
+		"log.noFiles":                "No files found to organize",
+		"log.starting":               "Starting organization...",
+		"log.organizeError":          "Error during organization: %v",
+		"log.complete":               "✅ Complete! Moved: %d, Skipped: %d",
+		"report.saved":               "Report saved to %s",
+		"report.error":               "Could not save the report: %v",
+		"queue.pending":              "Waiting",
+		"queue.running":              "Organizing...",
+		"queue.done":                 "Done: %d moved, %d skipped, %d failed",
+		"queue.failed":               "Failed: %v",
+		"queue.added":                "Added %d folders to the queue",
+		"queue.starting":             "Organizing %s...",
+		"queue.finished":             "Queue finished",
+		"recent.never":               "Not organized yet",
+		"recent.lastRun":             "Last run %s: %d moved, %d skipped, %d failed",
+		"recent.pin":                 "Pin",
+		"recent.unpin":               "Unpin",
+		"settings.title":             "Settings",
+		"settings.language":          "Language",
+		"settings.theme":             "Theme",
+		"settings.largeText":         "Larger text",
+		"settings.themeFile":         "Theme file",
+		"settings.themeFile.hint":    "Optional .json or .toml file with custom colours, sizes and fonts",
+		"settings.monthNames":        "Month folder names",
+		"settings.timeZone":          "Time zone",
+		"settings.timeZone.hint":     "local, utc, metadata, +02:00 or Europe/Berlin",
+		"settings.mediaLocal":        "Video times are local time",
+		"settings.mediaLocal.hint":   "For cameras that record MP4 and MKV times in local time instead of UTC",
+		"settings.namePatterns":      "Date patterns",
+		"settings.namePatterns.hint": "Extra regular expressions for dates in file names, one per line, with (?P<year>), (?P<month>) and (?P<day>) groups",
+		"settings.dateRules":         "Date sources per file type",
+		"settings.dateRules.hint":    "One rule per line, such as .pdf,.docx=document,filename; sources are media, document, filename and mtime",
+		"settings.save":              "Save",
+		"settings.cancel":            "Cancel",
+		"settings.invalid":           "Invalid setting: %v",
+		"theme.system":               "Follow system",
+		"theme.light":                "Light",
+		"theme.dark":                 "Dark",
+		"theme.highContrast":         "High contrast",
+		"theme.fileError":            "Custom theme not loaded: %v",
+		"month.english":              "English (03-March)",
+		"month.localized":            "Localized (%s)",
+		"month.abbreviated":          "Abbreviated (%s)",
+		"month.numeric":              "Numbers only (03)",
+		"menu.file":                  "File",
+		"menu.edit":                  "Edit",
+		"menu.view":                  "View",
+		"menu.undo":                  "Undo last run",
+		"menu.cancel":                "Cancel run",
+		"menu.find":                  "Find in log",
+		"menu.clearLog":              "Clear log",
+		"log.search":                 "Find in log...",
+		"undo.title":                 "Undo last run",
+		"undo.confirm":               "Move the %d files of the last run in %s back to where they were?",
+		"undo.done":                  "Restored %d files, %d could not be restored",
+		"undo.nothing":               "There is no run to undo",
+		"status.cancelling":          "Cancelling...",
+		"status.cancelled":           "Cancelled",
+		"status.undoing":             "Undoing the last run...",
+		"settings.notify":            "Notifications",
+		"notify.unfocused":           "When the window is in the background",
+		"notify.always":              "Always",
+		"notify.off":                 "Off",
+		"notify.default":             "As in Settings",
+		"notify.doneTitle":           "Organized %s",
+		"notify.failedTitle":         "Organizing %s failed",
+		"notify.batchTitle":          "Organized %d folders",
+		"notify.counts":              "%d moved, %d skipped, %d failed",
+		"notify.foldersFailed":       "%d folders could not be organized",
+		"notify.failedFiles":         "Failed: %s",
+		"notify.failedFilesMore":     "Failed: %s and %d more",
+		"option.emptyDirs":           "Remove empty folders",
+		"option.emptyFiles":          "Remove empty files",
+		"option.junk":                "Remove junk files",
+		"settings.junkPatterns":      "Junk files",
+		"settings.junkPatterns.hint": "Comma-separated name patterns removed by the cleanup",
+		"settings.rename":            "Rename files",
+		"settings.rename.hint":       "Tokens such as {date}, {time}, {name}, {ext}, {camera}, {hash} and {counter}; leave empty to keep names",
+		"preview.cleanup":            "Clean up (%d)",
+		"preview.cleanupItem":        "%s (%s)",
+		"preview.cleanupSummary":     "%d empty or junk items will be removed",
+		"cleanup.empty-folder":       "empty folder",
+		"cleanup.empty-file":         "empty file",
+		"cleanup.junk":               "junk",
+		"cleanup.done":               "Cleaned up %d items",
+		"menu.archive":               "Archive old months",
+		"menu.restoreArchive":        "Restore archive",
+		"archive.title":              "Archive old months",
+		"archive.years":              "Older than (years)",
+		"archive.years.hint":         "Months are packed as .zip, the only format",
+		"archive.next":               "Next",
+		"archive.invalidYears":       "Enter a whole number of years",
+		"archive.none":               "No months older than %d years to archive",
+		"archive.confirm":            "Pack %d month folders into verified .zip archives and permanently delete the originals?",
+		"archive.done":               "Archived %d of %d months",
+		"archive.restored":           "Restored %d files, kept %d already there",
+		"status.archiving":           "Archiving...",
+		"status.restoring":           "Restoring...",
+		"option.checksums":           "Write checksums",
+		"option.normalize":           "Clean up file names",
+		"menu.verify":                "Verify checksums",
+		"verify.title":               "Verify checksums",
+		"verify.done":                "%d ok, %d changed, %d missing, %d unexpected",
+		"verify.none":                "No SHA256SUMS files found in the month folders",
+		"status.verifying":           "Verifying...",
+		"resume.title":               "Interrupted run",
+		"resume.message":             "A run in this folder started %s stopped after moving %d of %d files. Resume it to move the rest, or roll it back to put the moved files back.",
+		"resume.resume":              "Resume",
+		"resume.rollback":            "Roll back",
+		"resume.later":               "Later",
+		"resume.pending":             "This folder has an interrupted run to resume or roll back first",
+		"status.rollingBack":         "Rolling back...",
+		"lock.title":                 "Folder in use",
+		"lock.message":               "Another run is organizing this folder: process %d on %s, started %s. If that run has stopped, for example because its computer crashed, you can force the folder unlocked.",
+		"lock.force":                 "Force unlock",
+		"lock.unlocked":              "Unlocked %s",
+		"preflight.title":            "Before organizing",
+		"preflight.blocking":         "Organizing can't start until these problems are fixed:",
+		"preflight.warnings":         "Some files can't be moved as things stand. Continue with the rest?",
+		"preflight.continue":         "Continue",
+		"preflight.space":            "Not enough free space: %s to copy, %s available",
+		"preflight.unwritable":       "%s: folder is not writable",
+		"preflight.unreadable":       "%s: file can't be read",
+		"preflight.read-only":        "%s: file is read-only, so its original can't be removed after copying",
+		"preflight.path-length":      "%s: name or path too long for the destination drive",
+		"preflight.invalid-name":     "%s: name not allowed on the destination drive",
+		"preflight.sourceFolder":     "The selected folder",
+		"status.preflightFailed":     "Problems found before organizing",
+	},
+}
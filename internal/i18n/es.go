@@ -0,0 +1,193 @@
+package i18n
+
+var es = catalog{
+	name: "Español",
+	months: [12]string{"Enero", "Febrero", "Marzo", "Abril", "Mayo", "Junio",
+		"Julio", "Agosto", "Septiembre", "Octubre", "Noviembre", "Diciembre"},
+	monthsShort: [12]string{"Ene", "Feb", "Mar", "Abr", "May", "Jun",
+		"Jul", "Ago", "Sep", "Oct", "Nov", "Dic"},
+	messages: map[string]string{
+		"app.description":            "Organiza tus archivos en carpetas Año/Mes\nsegún su fecha",
+		"folder.none":                "Ninguna carpeta seleccionada",
+		"folder.selected":            "Carpeta seleccionada:",
+		"folder.error":               "Error al leer la carpeta: %v",
+		"folder.found":               "Se encontraron %d archivos para organizar",
+		"folder.mismatched":          "%d archivos tienen una extensión ausente o incorrecta",
+		"button.select":              "Seleccionar carpeta",
+		"button.organize":            "Organizar archivos",
+		"button.settings":            "Ajustes",
+		"button.saveReport":          "Guardar informe",
+		"button.addToQueue":          "Añadir a la cola",
+		"button.runQueue":            "Ejecutar cola",
+		"button.clearQueue":          "Quitar terminados",
+		"option.fixExtensions":       "Corregir extensiones incorrectas",
+		"option.mediaDates":          "Usar fechas de los metadatos de vídeo y audio",
+		"option.documentDates":       "Usar fechas de los metadatos de documentos",
+		"option.filenameDates":       "Usar fechas del nombre de archivo",
+		"tab.files":                  "Archivos",
+		"tab.log":                    "Registro de actividad",
+		"tab.queue":                  "Cola",
+		"tab.folders":                "Carpetas",
+		"table.name":                 "Nombre",
+		"table.date":                 "Fecha",
+		"table.source":               "Origen de la fecha",
+		"table.destination":          "Destino",
+		"table.status":               "Estado",
+		"table.error":                "Error",
+		"table.search":               "Buscar archivos...",
+		"table.errorsOnly":           "Mostrar solo errores",
+		"result.pending":             "Pendiente",
+		"result.moved":               "Movido",
+		"result.skipped":             "Omitido",
+		"result.excluded":            "Excluido",
+		"result.failed":              "Fallido",
+		"result.exists":              "Ya existe",
+		"source.mtime":               "Fecha de modificación",
+		"source.media":               "Metadatos multimedia",
+		"source.document":            "Metadatos del documento",
+		"source.filename":            "Nombre de archivo",
+		"dialog.info":                "Información",
+		"dialog.selectFirst":         "Primero selecciona una carpeta",
+		"dialog.confirm.title":       "Confirmar organización",
+		"dialog.cancel":              "Cancelar",
+		"preview.intro":              "Los archivos de %s se moverán a estas carpetas. Desmarca lo que quieras dejar en su sitio.",
+		"preview.summary":            "%d archivos en %d carpetas, se omitirán %d conflictos",
+		"preview.folder":             "%s (%d archivos)",
+		"preview.conflicts":          "%d conflictos",
+		"preview.conflict":           "%s (ya existe, se omitirá)",
+		"status.scanning":            "Analizando archivos...",
+		"status.organizing":          "Organizando...",
+		"status.error":               "Se produjo un error",
+		"status.noFiles":             "No hay archivos para organizar",
+		"status.done":                "¡Listo! %d archivos movidos, %d omitidos",
+		"log.error":                  "Error: %v",
+		"log.noFiles":                "No se encontraron archivos para organizar",
+		"log.starting":               "Iniciando organización...",
+		"log.organizeError":          "Error durante la organización: %v",
+		"log.complete":               "✅ ¡Completado! Movidos: %d, omitidos: %d",
+		"report.saved":               "Informe guardado en %s",
+		"report.error":               "No se pudo guardar el informe: %v",
+		"queue.pending":              "En espera",
+		"queue.running":              "Organizando...",
+		"queue.done":                 "Listo: %d movidos, %d omitidos, %d fallidos",
+		"queue.failed":               "Error: %v",
+		"queue.added":                "%d carpetas añadidas a la cola",
+		"queue.starting":             "Organizando %s...",
+		"queue.finished":             "Cola terminada",
+		"recent.never":               "Aún sin organizar",
+		"recent.lastRun":             "Última ejecución %s: %d movidos, %d omitidos, %d fallidos",
+		"recent.pin":                 "Fijar",
+		"recent.unpin":               "Desfijar",
+		"settings.title":             "Ajustes",
+		"settings.language":          "Idioma",
+		"settings.theme":             "Tema",
+		"settings.largeText":         "Texto más grande",
+		"settings.themeFile":         "Archivo de tema",
+		"settings.themeFile.hint":    "Archivo .json o .toml opcional con colores, tamaños y fuentes propios",
+		"settings.monthNames":        "Nombres de las carpetas de mes",
+		"settings.timeZone":          "Zona horaria",
+		"settings.timeZone.hint":     "local, utc, metadata, +02:00 o Europe/Madrid",
+		"settings.mediaLocal":        "Las horas de vídeo son locales",
+		"settings.mediaLocal.hint":   "Para cámaras que graban la hora de MP4 y MKV en hora local en lugar de UTC",
+		"settings.namePatterns":      "Patrones de fecha",
+		"settings.namePatterns.hint": "Expresiones regulares adicionales para fechas en nombres de archivo, una por línea, con los grupos (?P<year>), (?P<month>) y (?P<day>)",
+		"settings.dateRules":         "Fuentes de fecha por tipo de archivo",
+		"settings.dateRules.hint":    "Una regla por línea, como .pdf,.docx=document,filename; las fuentes son media, document, filename y mtime",
+		"settings.save":              "Guardar",
+		"settings.cancel":            "Cancelar",
+		"settings.invalid":           "Ajuste no válido: %v",
+		"theme.system":               "Según el sistema",
+		"theme.light":                "Claro",
+		"theme.dark":                 "Oscuro",
+		"theme.highContrast":         "Alto contraste",
+		"theme.fileError":            "No se cargó el tema personalizado: %v",
+		"month.english":              "Inglés (03-March)",
+		"month.localized":            "Traducido (%s)",
+		"month.abbreviated":          "Abreviado (%s)",
+		"month.numeric":              "Solo números (03)",
+		"menu.file":                  "Archivo",
+		"menu.edit":                  "Editar",
+		"menu.view":                  "Ver",
+		"menu.undo":                  "Deshacer la última ejecución",
+		"menu.cancel":                "Cancelar ejecución",
+		"menu.find":                  "Buscar en el registro",
+		"menu.clearLog":              "Borrar registro",
+		"log.search":                 "Buscar en el registro...",
+		"undo.title":                 "Deshacer la última ejecución",
+		"undo.confirm":               "¿Devolver los %d archivos de la última ejecución en %s a su ubicación original?",
+		"undo.done":                  "%d archivos restaurados, %d no se pudieron restaurar",
+		"undo.nothing":               "No hay ninguna ejecución que deshacer",
+		"status.cancelling":          "Cancelando...",
+		"status.cancelled":           "Cancelado",
+		"status.undoing":             "Deshaciendo la última ejecución...",
+		"settings.notify":            "Notificaciones",
+		"notify.unfocused":           "Cuando la ventana está en segundo plano",
+		"notify.always":              "Siempre",
+		"notify.off":                 "Desactivadas",
+		"notify.default":             "Como en Ajustes",
+		"notify.doneTitle":           "%s organizada",
+		"notify.failedTitle":         "Error al organizar %s",
+		"notify.batchTitle":          "%d carpetas organizadas",
+		"notify.counts":              "%d movidos, %d omitidos, %d con error",
+		"notify.foldersFailed":       "%d carpetas no se pudieron organizar",
+		"notify.failedFiles":         "Con error: %s",
+		"notify.failedFilesMore":     "Con error: %s y %d más",
+		"option.emptyDirs":           "Eliminar carpetas vacías",
+		"option.emptyFiles":          "Eliminar archivos vacíos",
+		"option.junk":                "Eliminar archivos basura",
+		"settings.junkPatterns":      "Archivos basura",
+		"settings.junkPatterns.hint": "Patrones de nombre separados por comas que elimina la limpieza",
+		"settings.rename":            "Renombrar archivos",
+		"settings.rename.hint":       "Marcadores como {date}, {time}, {name}, {ext}, {camera}, {hash} y {counter}; déjelo vacío para conservar los nombres",
+		"preview.cleanup":            "Limpieza (%d)",
+		"preview.cleanupItem":        "%s (%s)",
+		"preview.cleanupSummary":     "Se eliminarán %d elementos vacíos o basura",
+		"cleanup.empty-folder":       "carpeta vacía",
+		"cleanup.empty-file":         "archivo vacío",
+		"cleanup.junk":               "basura",
+		"cleanup.done":               "%d elementos limpiados",
+		"menu.archive":               "Archivar meses antiguos",
+		"menu.restoreArchive":        "Restaurar archivo",
+		"archive.title":              "Archivar meses antiguos",
+		"archive.years":              "Más antiguos que (años)",
+		"archive.years.hint":         "Los meses se empaquetan en .zip, el único formato",
+		"archive.next":               "Siguiente",
+		"archive.invalidYears":       "Introduce un número entero de años",
+		"archive.none":               "No hay meses de más de %d años para archivar",
+		"archive.confirm":            "¿Empaquetar %d carpetas de meses en archivos .zip verificados y eliminar definitivamente los originales?",
+		"archive.done":               "%d de %d meses archivados",
+		"archive.restored":           "%d archivos restaurados, %d existentes conservados",
+		"status.archiving":           "Archivando...",
+		"status.restoring":           "Restaurando...",
+		"option.checksums":           "Escribir sumas de verificación",
+		"option.normalize":           "Limpiar nombres de archivo",
+		"menu.verify":                "Verificar sumas de verificación",
+		"verify.title":               "Verificar sumas de verificación",
+		"verify.done":                "%d correctos, %d modificados, %d ausentes, %d inesperados",
+		"verify.none":                "No se encontraron archivos SHA256SUMS en las carpetas de meses",
+		"status.verifying":           "Verificando...",
+		"resume.title":               "Ejecución interrumpida",
+		"resume.message":             "Una ejecución iniciada el %s en esta carpeta se detuvo tras mover %d de %d archivos. Reanúdala para mover el resto o reviértela para devolver los archivos movidos.",
+		"resume.resume":              "Reanudar",
+		"resume.rollback":            "Revertir",
+		"resume.later":               "Más tarde",
+		"resume.pending":             "Esta carpeta tiene una ejecución interrumpida que reanudar o revertir primero",
+		"status.rollingBack":         "Revirtiendo...",
+		"lock.title":                 "Carpeta en uso",
+		"lock.message":               "Otra ejecución está organizando esta carpeta: proceso %d en %s, iniciado el %s. Si esa ejecución se ha detenido, por ejemplo porque su equipo falló, puedes forzar el desbloqueo.",
+		"lock.force":                 "Forzar desbloqueo",
+		"lock.unlocked":              "Desbloqueada: %s",
+		"preflight.title":            "Antes de organizar",
+		"preflight.blocking":         "No se puede organizar hasta corregir estos problemas:",
+		"preflight.warnings":         "Algunos archivos no se podrán mover tal como están. ¿Continuar con el resto?",
+		"preflight.continue":         "Continuar",
+		"preflight.space":            "Espacio libre insuficiente: %s por copiar, %s disponibles",
+		"preflight.unwritable":       "%s: la carpeta no admite escritura",
+		"preflight.unreadable":       "%s: no se puede leer el archivo",
+		"preflight.read-only":        "%s: el archivo es de solo lectura y el original no se podrá eliminar tras copiarlo",
+		"preflight.path-length":      "%s: nombre o ruta demasiado largos para la unidad de destino",
+		"preflight.invalid-name":     "%s: nombre no permitido en la unidad de destino",
+		"preflight.sourceFolder":     "La carpeta seleccionada",
+		"status.preflightFailed":     "Problemas encontrados antes de organizar",
+	},
+}
@@ -0,0 +1,193 @@
+package i18n
+
+var fr = catalog{
+	name: "Français",
+	months: [12]string{"Janvier", "Février", "Mars", "Avril", "Mai", "Juin",
+		"Juillet", "Août", "Septembre", "Octobre", "Novembre", "Décembre"},
+	monthsShort: [12]string{"Janv", "Févr", "Mars", "Avr", "Mai", "Juin",
+		"Juil", "Août", "Sept", "Oct", "Nov", "Déc"},
+	messages: map[string]string{
+		"app.description":            "Rangez vos fichiers dans des dossiers Année/Mois\nselon leur date",
+		"folder.none":                "Aucun dossier sélectionné",
+		"folder.selected":            "Dossier sélectionné :",
+		"folder.error":               "Erreur de lecture du dossier : %v",
+		"folder.found":               "%d fichiers à ranger",
+		"folder.mismatched":          "%d fichiers ont une extension manquante ou incorrecte",
+		"button.select":              "Choisir un dossier",
+		"button.organize":            "Ranger les fichiers",
+		"button.settings":            "Paramètres",
+		"button.saveReport":          "Enregistrer le rapport",
+		"button.addToQueue":          "Ajouter à la file",
+		"button.runQueue":            "Lancer la file",
+		"button.clearQueue":          "Retirer les terminés",
+		"option.fixExtensions":       "Corriger les extensions erronées",
+		"option.mediaDates":          "Utiliser la date des métadonnées vidéo et audio",
+		"option.documentDates":       "Utiliser la date des métadonnées des documents",
+		"option.filenameDates":       "Utiliser la date du nom de fichier",
+		"tab.files":                  "Fichiers",
+		"tab.log":                    "Journal d'activité",
+		"tab.queue":                  "File d'attente",
+		"tab.folders":                "Dossiers",
+		"table.name":                 "Nom",
+		"table.date":                 "Date",
+		"table.source":               "Source de la date",
+		"table.destination":          "Destination",
+		"table.status":               "État",
+		"table.error":                "Erreur",
+		"table.search":               "Rechercher des fichiers...",
+		"table.errorsOnly":           "Afficher uniquement les erreurs",
+		"result.pending":             "En attente",
+		"result.moved":               "Déplacé",
+		"result.skipped":             "Ignoré",
+		"result.excluded":            "Exclu",
+		"result.failed":              "Échec",
+		"result.exists":              "Existe déjà",
+		"source.mtime":               "Date de modification",
+		"source.media":               "Métadonnées média",
+		"source.document":            "Métadonnées du document",
+		"source.filename":            "Nom du fichier",
+		"dialog.info":                "Information",
+		"dialog.selectFirst":         "Veuillez d'abord choisir un dossier",
+		"dialog.confirm.title":       "Confirmer le rangement",
+		"dialog.cancel":              "Annuler",
+		"preview.intro":              "Les fichiers de %s seront déplacés dans ces dossiers. Décochez ce que vous souhaitez laisser en place.",
+		"preview.summary":            "%d fichiers dans %d dossiers, %d conflits seront ignorés",
+		"preview.folder":             "%s (%d fichiers)",
+		"preview.conflicts":          "%d conflits",
+		"preview.conflict":           "%s (existe déjà, sera ignoré)",
+		"status.scanning":            "Analyse des fichiers...",
+		"status.organizing":          "Rangement en cours...",
+		"status.error":               "Une erreur est survenue",
+		"status.noFiles":             "Aucun fichier à ranger",
+		"status.done":                "Terminé ! %d fichiers déplacés, %d ignorés",
+		"log.error":                  "Erreur : %v",
+		"log.noFiles":                "Aucun fichier à ranger",
+		"log.starting":               "Début du rangement...",
+		"log.organizeError":          "Erreur pendant le rangement : %v",
+		"log.complete":               "✅ Terminé ! Déplacés : %d, ignorés : %d",
+		"report.saved":               "Rapport enregistré dans %s",
+		"report.error":               "Impossible d'enregistrer le rapport : %v",
+		"queue.pending":              "En attente",
+		"queue.running":              "Organisation...",
+		"queue.done":                 "Terminé : %d déplacés, %d ignorés, %d en échec",
+		"queue.failed":               "Échec : %v",
+		"queue.added":                "%d dossiers ajoutés à la file",
+		"queue.starting":             "Organisation de %s...",
+		"queue.finished":             "File d'attente terminée",
+		"recent.never":               "Pas encore organisé",
+		"recent.lastRun":             "Dernière exécution le %s : %d déplacés, %d ignorés, %d en échec",
+		"recent.pin":                 "Épingler",
+		"recent.unpin":               "Désépingler",
+		"settings.title":             "Paramètres",
+		"settings.language":          "Langue",
+		"settings.theme":             "Thème",
+		"settings.largeText":         "Texte plus grand",
+		"settings.themeFile":         "Fichier de thème",
+		"settings.themeFile.hint":    "Fichier .json ou .toml facultatif avec couleurs, tailles et polices personnalisées",
+		"settings.monthNames":        "Noms des dossiers de mois",
+		"settings.timeZone":          "Fuseau horaire",
+		"settings.timeZone.hint":     "local, utc, metadata, +02:00 ou Europe/Paris",
+		"settings.mediaLocal":        "Heures des vidéos en heure locale",
+		"settings.mediaLocal.hint":   "Pour les caméras qui enregistrent l'heure des MP4 et MKV en heure locale plutôt qu'en UTC",
+		"settings.namePatterns":      "Motifs de date",
+		"settings.namePatterns.hint": "Expressions régulières supplémentaires pour les dates dans les noms de fichiers, une par ligne, avec les groupes (?P<year>), (?P<month>) et (?P<day>)",
+		"settings.dateRules":         "Sources de date par type de fichier",
+		"settings.dateRules.hint":    "Une règle par ligne, par exemple .pdf,.docx=document,filename ; les sources sont media, document, filename et mtime",
+		"settings.save":              "Enregistrer",
+		"settings.cancel":            "Annuler",
+		"settings.invalid":           "Paramètre invalide : %v",
+		"theme.system":               "Comme le système",
+		"theme.light":                "Clair",
+		"theme.dark":                 "Sombre",
+		"theme.highContrast":         "Contraste élevé",
+		"theme.fileError":            "Thème personnalisé non chargé : %v",
+		"month.english":              "Anglais (03-March)",
+		"month.localized":            "Traduit (%s)",
+		"month.abbreviated":          "Abrégé (%s)",
+		"month.numeric":              "Chiffres uniquement (03)",
+		"menu.file":                  "Fichier",
+		"menu.edit":                  "Édition",
+		"menu.view":                  "Affichage",
+		"menu.undo":                  "Annuler la dernière exécution",
+		"menu.cancel":                "Interrompre l’exécution",
+		"menu.find":                  "Rechercher dans le journal",
+		"menu.clearLog":              "Effacer le journal",
+		"log.search":                 "Rechercher dans le journal...",
+		"undo.title":                 "Annuler la dernière exécution",
+		"undo.confirm":               "Remettre les %d fichiers de la dernière exécution dans %s à leur place d’origine ?",
+		"undo.done":                  "%d fichiers restaurés, %d n’ont pas pu être restaurés",
+		"undo.nothing":               "Aucune exécution à annuler",
+		"status.cancelling":          "Interruption...",
+		"status.cancelled":           "Interrompu",
+		"status.undoing":             "Annulation de la dernière exécution...",
+		"settings.notify":            "Notifications",
+		"notify.unfocused":           "Quand la fenêtre est en arrière-plan",
+		"notify.always":              "Toujours",
+		"notify.off":                 "Désactivées",
+		"notify.default":             "Comme dans les paramètres",
+		"notify.doneTitle":           "%s organisé",
+		"notify.failedTitle":         "Échec de l’organisation de %s",
+		"notify.batchTitle":          "%d dossiers organisés",
+		"notify.counts":              "%d déplacés, %d ignorés, %d en échec",
+		"notify.foldersFailed":       "%d dossiers n’ont pas pu être organisés",
+		"notify.failedFiles":         "Échec : %s",
+		"notify.failedFilesMore":     "Échec : %s et %d autres",
+		"option.emptyDirs":           "Supprimer les dossiers vides",
+		"option.emptyFiles":          "Supprimer les fichiers vides",
+		"option.junk":                "Supprimer les fichiers inutiles",
+		"settings.junkPatterns":      "Fichiers inutiles",
+		"settings.junkPatterns.hint": "Motifs de noms séparés par des virgules, supprimés au nettoyage",
+		"settings.rename":            "Renommer les fichiers",
+		"settings.rename.hint":       "Jetons tels que {date}, {time}, {name}, {ext}, {camera}, {hash} et {counter} ; laisser vide pour garder les noms",
+		"preview.cleanup":            "Nettoyage (%d)",
+		"preview.cleanupItem":        "%s (%s)",
+		"preview.cleanupSummary":     "%d éléments vides ou inutiles seront supprimés",
+		"cleanup.empty-folder":       "dossier vide",
+		"cleanup.empty-file":         "fichier vide",
+		"cleanup.junk":               "inutile",
+		"cleanup.done":               "%d éléments nettoyés",
+		"menu.archive":               "Archiver les anciens mois",
+		"menu.restoreArchive":        "Restaurer une archive",
+		"archive.title":              "Archiver les anciens mois",
+		"archive.years":              "Plus anciens que (années)",
+		"archive.years.hint":         "Les mois sont compressés en .zip, le seul format",
+		"archive.next":               "Suivant",
+		"archive.invalidYears":       "Saisissez un nombre entier d'années",
+		"archive.none":               "Aucun mois de plus de %d ans à archiver",
+		"archive.confirm":            "Compresser %d dossiers de mois dans des archives .zip vérifiées et supprimer définitivement les originaux ?",
+		"archive.done":               "%d mois archivés sur %d",
+		"archive.restored":           "%d fichiers restaurés, %d déjà présents conservés",
+		"status.archiving":           "Archivage...",
+		"status.restoring":           "Restauration...",
+		"option.checksums":           "Écrire les sommes de contrôle",
+		"option.normalize":           "Nettoyer les noms de fichiers",
+		"menu.verify":                "Vérifier les sommes de contrôle",
+		"verify.title":               "Vérifier les sommes de contrôle",
+		"verify.done":                "%d intacts, %d modifiés, %d manquants, %d inattendus",
+		"verify.none":                "Aucun fichier SHA256SUMS trouvé dans les dossiers de mois",
+		"status.verifying":           "Vérification...",
+		"resume.title":               "Exécution interrompue",
+		"resume.message":             "Une exécution lancée le %s dans ce dossier s'est arrêtée après avoir déplacé %d fichiers sur %d. Reprenez-la pour déplacer le reste, ou annulez-la pour remettre les fichiers déplacés en place.",
+		"resume.resume":              "Reprendre",
+		"resume.rollback":            "Annuler",
+		"resume.later":               "Plus tard",
+		"resume.pending":             "Ce dossier a une exécution interrompue à reprendre ou annuler d'abord",
+		"status.rollingBack":         "Annulation...",
+		"lock.title":                 "Dossier en cours d'utilisation",
+		"lock.message":               "Une autre exécution organise ce dossier : processus %d sur %s, lancé le %s. Si cette exécution s'est arrêtée, par exemple parce que son ordinateur a planté, vous pouvez forcer le déverrouillage.",
+		"lock.force":                 "Forcer le déverrouillage",
+		"lock.unlocked":              "Déverrouillé : %s",
+		"preflight.title":            "Avant l'organisation",
+		"preflight.blocking":         "L'organisation ne peut pas commencer tant que ces problèmes ne sont pas corrigés :",
+		"preflight.warnings":         "Certains fichiers ne pourront pas être déplacés en l'état. Continuer avec les autres ?",
+		"preflight.continue":         "Continuer",
+		"preflight.space":            "Espace libre insuffisant : %s à copier, %s disponibles",
+		"preflight.unwritable":       "%s : dossier non accessible en écriture",
+		"preflight.unreadable":       "%s : fichier illisible",
+		"preflight.read-only":        "%s : fichier en lecture seule, l'original ne pourra pas être supprimé après la copie",
+		"preflight.path-length":      "%s : nom ou chemin trop long pour le lecteur de destination",
+		"preflight.invalid-name":     "%s : nom non autorisé sur le lecteur de destination",
+		"preflight.sourceFolder":     "Le dossier sélectionné",
+		"status.preflightFailed":     "Problèmes détectés avant l'organisation",
+	},
+}
@@ -0,0 +1,122 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Language string
+
+const (
+	English    Language = "en"
+	German     Language = "de"
+	French     Language = "fr"
+	Spanish    Language = "es"
+	Portuguese Language = "pt"
+	Japanese   Language = "ja"
+)
+
+type catalog struct {
+	name        string
+	months      [12]string
+	monthsShort [12]string
+	messages    map[string]string
+}
+
+var catalogs = map[Language]*catalog{
+	English:    &en,
+	German:     &de,
+	French:     &fr,
+	Spanish:    &es,
+	Portuguese: &pt,
+	Japanese:   &ja,
+}
+
+var (
+	mu      sync.RWMutex
+	current = English
+)
+
+// Languages returns the supported languages, English first.
+func Languages() []Language {
+	return []Language{English, German, French, Spanish, Portuguese, Japanese}
+}
+
+// Name returns the language's name in that language, for pickers.
+func (l Language) Name() string {
+	if c, ok := catalogs[l]; ok {
+		return c.name
+	}
+	return string(l)
+}
+
+func SetLanguage(l Language) {
+	if _, ok := catalogs[l]; !ok {
+		l = English
+	}
+	mu.Lock()
+	current = l
+	mu.Unlock()
+}
+
+func Current() Language {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Match returns the supported language for a locale such as "de-DE" or
+// "pt_BR.UTF-8", falling back to English.
+func Match(locale string) Language {
+	locale = strings.ToLower(locale)
+	if i := strings.IndexAny(locale, "-_."); i >= 0 {
+		locale = locale[:i]
+	}
+	if _, ok := catalogs[Language(locale)]; ok {
+		return Language(locale)
+	}
+	return English
+}
+
+// T returns the message for key in the current language, formatted with
+// args. Missing translations fall back to English, then to the key itself.
+func T(key string, args ...any) string {
+	msg, ok := catalogs[Current()].messages[key]
+	if !ok {
+		if msg, ok = en.messages[key]; !ok {
+			msg = key
+		}
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+func MonthName(l Language, m time.Month) string {
+	c, ok := catalogs[l]
+	if !ok || m < time.January || m > time.December {
+		return m.String()
+	}
+	return c.months[m-1]
+}
+
+func MonthAbbrev(l Language, m time.Month) string {
+	c, ok := catalogs[l]
+	if !ok || m < time.January || m > time.December {
+		return m.String()[:3]
+	}
+	return c.monthsShort[m-1]
+}
+
+// AllMonthNames returns every full and abbreviated name for m across the
+// supported languages, used to recognise folders created with any setting.
+func AllMonthNames(m time.Month) []string {
+	var names []string
+	for _, l := range Languages() {
+		names = append(names, MonthName(l, m), MonthAbbrev(l, m))
+	}
+	return names
+}
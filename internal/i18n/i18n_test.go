@@ -0,0 +1,88 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestCatalogsComplete verifies every language translates every English key
+// with the same format verbs
+func TestCatalogsComplete(t *testing.T) {
+	for _, lang := range Languages() {
+		c := catalogs[lang]
+		for key, msg := range en.messages {
+			translated, ok := c.messages[key]
+			if !ok {
+				t.Errorf("%s: missing translation for %q", lang, key)
+				continue
+			}
+			for _, verb := range []string{"%d", "%s", "%v"} {
+				if strings.Count(msg, verb) != strings.Count(translated, verb) {
+					t.Errorf("%s: %q has mismatched %s verbs", lang, key, verb)
+				}
+			}
+		}
+		for key := range c.messages {
+			if _, ok := en.messages[key]; !ok {
+				t.Errorf("%s: unknown key %q", lang, key)
+			}
+		}
+		for i := range c.months {
+			if c.months[i] == "" || c.monthsShort[i] == "" {
+				t.Errorf("%s: missing name for month %d", lang, i+1)
+			}
+		}
+	}
+}
+
+// TestT verifies lookup, formatting and fallbacks
+func TestT(t *testing.T) {
+	defer SetLanguage(Current())
+
+	SetLanguage(German)
+	if got := T("button.select"); got != "Ordner auswählen" {
+		t.Errorf("Expected German text, got %q", got)
+	}
+	if got := T("status.done", 3, 1); got != "Fertig! 3 Dateien verschoben, 1 übersprungen" {
+		t.Errorf("Unexpected formatted text %q", got)
+	}
+	if got := T("no.such.key"); got != "no.such.key" {
+		t.Errorf("Expected key fallback, got %q", got)
+	}
+
+	SetLanguage("xx")
+	if Current() != English {
+		t.Errorf("Expected unsupported language to fall back to English, got %s", Current())
+	}
+}
+
+// TestMatch verifies locale strings are mapped to supported languages
+func TestMatch(t *testing.T) {
+	tests := map[string]Language{
+		"de-DE":       German,
+		"pt_BR.UTF-8": Portuguese,
+		"ja":          Japanese,
+		"FR-ca":       French,
+		"nl-NL":       English,
+		"":            English,
+	}
+	for locale, expected := range tests {
+		if got := Match(locale); got != expected {
+			t.Errorf("Match(%q): expected %s, got %s", locale, expected, got)
+		}
+	}
+}
+
+// TestMonthNames verifies month lookups
+func TestMonthNames(t *testing.T) {
+	if got := MonthName(Spanish, time.May); got != "Mayo" {
+		t.Errorf("Expected Mayo, got %s", got)
+	}
+	if got := MonthAbbrev(German, time.October); got != "Okt" {
+		t.Errorf("Expected Okt, got %s", got)
+	}
+	if got := MonthName("xx", time.May); got != "May" {
+		t.Errorf("Expected English fallback, got %s", got)
+	}
+}
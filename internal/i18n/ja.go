@@ -0,0 +1,193 @@
+package i18n
+
+var ja = catalog{
+	name: "日本語",
+	months: [12]string{"1月", "2月", "3月", "4月", "5月", "6月",
+		"7月", "8月", "9月", "10月", "11月", "12月"},
+	monthsShort: [12]string{"1月", "2月", "3月", "4月", "5月", "6月",
+		"7月", "8月", "9月", "10月", "11月", "12月"},
+	messages: map[string]string{
+		"app.description":            "ファイルを日付に基づいて\n年/月フォルダに整理します",
+		"folder.none":                "フォルダが選択されていません",
+		"folder.selected":            "選択したフォルダ:",
+		"folder.error":               "フォルダの読み込みエラー: %v",
+		"folder.found":               "整理するファイルが %d 件見つかりました",
+		"folder.mismatched":          "%d 件のファイルの拡張子が欠落しているか誤っています",
+		"button.select":              "フォルダを選択",
+		"button.organize":            "ファイルを整理",
+		"button.settings":            "設定",
+		"button.saveReport":          "レポートを保存",
+		"button.addToQueue":          "キューに追加",
+		"button.runQueue":            "キューを実行",
+		"button.clearQueue":          "完了分を削除",
+		"option.fixExtensions":       "誤った拡張子を修正する",
+		"option.mediaDates":          "動画と音声のメタデータの日付を使用する",
+		"option.documentDates":       "文書のメタデータの日付を使用する",
+		"option.filenameDates":       "ファイル名の日付を使用する",
+		"tab.files":                  "ファイル",
+		"tab.log":                    "アクティビティログ",
+		"tab.queue":                  "キュー",
+		"tab.folders":                "フォルダ",
+		"table.name":                 "名前",
+		"table.date":                 "日付",
+		"table.source":               "日付の取得元",
+		"table.destination":          "移動先",
+		"table.status":               "状態",
+		"table.error":                "エラー",
+		"table.search":               "ファイルを検索...",
+		"table.errorsOnly":           "エラーのみ表示",
+		"result.pending":             "待機中",
+		"result.moved":               "移動済み",
+		"result.skipped":             "スキップ",
+		"result.excluded":            "除外",
+		"result.failed":              "失敗",
+		"result.exists":              "既に存在します",
+		"source.mtime":               "更新日時",
+		"source.media":               "メディアのメタデータ",
+		"source.document":            "文書のメタデータ",
+		"source.filename":            "ファイル名",
+		"dialog.info":                "お知らせ",
+		"dialog.selectFirst":         "先にフォルダを選択してください",
+		"dialog.confirm.title":       "整理の確認",
+		"dialog.cancel":              "キャンセル",
+		"preview.intro":              "%s 内のファイルを次のフォルダに移動します。移動しないものはチェックを外してください。",
+		"preview.summary":            "%d 件のファイルを %d 個のフォルダへ、競合 %d 件はスキップされます",
+		"preview.folder":             "%s (%d 件)",
+		"preview.conflicts":          "競合 %d 件",
+		"preview.conflict":           "%s (既に存在するためスキップ)",
+		"status.scanning":            "ファイルをスキャンしています...",
+		"status.organizing":          "整理しています...",
+		"status.error":               "エラーが発生しました",
+		"status.noFiles":             "整理するファイルがありません",
+		"status.done":                "完了しました! 移動 %d 件、スキップ %d 件",
+		"log.error":                  "エラー: %v",
+		"log.noFiles":                "整理するファイルが見つかりません",
+		"log.starting":               "整理を開始します...",
+		"log.organizeError":          "整理中のエラー: %v",
+		"log.complete":               "✅ 完了! 移動: %d、スキップ: %d",
+		"report.saved":               "レポートを %s に保存しました",
+		"report.error":               "レポートを保存できませんでした: %v",
+		"queue.pending":              "待機中",
+		"queue.running":              "整理しています...",
+		"queue.done":                 "完了: 移動 %d 件、スキップ %d 件、失敗 %d 件",
+		"queue.failed":               "失敗: %v",
+		"queue.added":                "%d 個のフォルダをキューに追加しました",
+		"queue.starting":             "%s を整理しています...",
+		"queue.finished":             "キューの処理が完了しました",
+		"recent.never":               "まだ整理されていません",
+		"recent.lastRun":             "前回 %s: 移動 %d 件、スキップ %d 件、失敗 %d 件",
+		"recent.pin":                 "ピン留め",
+		"recent.unpin":               "ピン留めを解除",
+		"settings.title":             "設定",
+		"settings.language":          "言語",
+		"settings.theme":             "テーマ",
+		"settings.largeText":         "大きな文字",
+		"settings.themeFile":         "テーマファイル",
+		"settings.themeFile.hint":    "独自の色・サイズ・フォントを定義した .json または .toml ファイル（任意）",
+		"settings.monthNames":        "月フォルダの名前",
+		"settings.timeZone":          "タイムゾーン",
+		"settings.timeZone.hint":     "local、utc、metadata、+09:00 または Asia/Tokyo",
+		"settings.mediaLocal":        "動画の時刻を現地時刻とみなす",
+		"settings.mediaLocal.hint":   "MP4 や MKV の時刻を UTC ではなく現地時刻で記録するカメラ向け",
+		"settings.namePatterns":      "日付のパターン",
+		"settings.namePatterns.hint": "ファイル名の日付を探す追加の正規表現（1 行に 1 つ、(?P<year>)・(?P<month>)・(?P<day>) グループを含む）",
+		"settings.dateRules":         "ファイルの種類ごとの日付の取得元",
+		"settings.dateRules.hint":    "1 行に 1 つのルール（例: .pdf,.docx=document,filename）。取得元は media、document、filename、mtime",
+		"settings.save":              "保存",
+		"settings.cancel":            "キャンセル",
+		"settings.invalid":           "無効な設定: %v",
+		"theme.system":               "システムに合わせる",
+		"theme.light":                "ライト",
+		"theme.dark":                 "ダーク",
+		"theme.highContrast":         "ハイコントラスト",
+		"theme.fileError":            "カスタムテーマを読み込めませんでした: %v",
+		"month.english":              "英語 (03-March)",
+		"month.localized":            "翻訳 (%s)",
+		"month.abbreviated":          "短縮形 (%s)",
+		"month.numeric":              "数字のみ (03)",
+		"menu.file":                  "ファイル",
+		"menu.edit":                  "編集",
+		"menu.view":                  "表示",
+		"menu.undo":                  "前回の実行を元に戻す",
+		"menu.cancel":                "実行を中止",
+		"menu.find":                  "ログ内を検索",
+		"menu.clearLog":              "ログを消去",
+		"log.search":                 "ログ内を検索...",
+		"undo.title":                 "前回の実行を元に戻す",
+		"undo.confirm":               "前回の実行で移動した %d 個のファイル（%s）を元の場所に戻しますか？",
+		"undo.done":                  "%d 個のファイルを復元しました（%d 個は復元できませんでした）",
+		"undo.nothing":               "元に戻せる実行はありません",
+		"status.cancelling":          "中止しています...",
+		"status.cancelled":           "中止しました",
+		"status.undoing":             "前回の実行を元に戻しています...",
+		"settings.notify":            "通知",
+		"notify.unfocused":           "ウィンドウがバックグラウンドのとき",
+		"notify.always":              "常に",
+		"notify.off":                 "オフ",
+		"notify.default":             "設定に従う",
+		"notify.doneTitle":           "%s を整理しました",
+		"notify.failedTitle":         "%s の整理に失敗しました",
+		"notify.batchTitle":          "%d 個のフォルダを整理しました",
+		"notify.counts":              "移動 %d、スキップ %d、失敗 %d",
+		"notify.foldersFailed":       "%d 個のフォルダを整理できませんでした",
+		"notify.failedFiles":         "失敗: %s",
+		"notify.failedFilesMore":     "失敗: %s ほか %d 件",
+		"option.emptyDirs":           "空のフォルダーを削除",
+		"option.emptyFiles":          "空のファイルを削除",
+		"option.junk":                "不要なファイルを削除",
+		"settings.junkPatterns":      "不要なファイル",
+		"settings.junkPatterns.hint": "クリーンアップで削除する名前のパターン（カンマ区切り）",
+		"settings.rename":            "ファイル名の変更",
+		"settings.rename.hint":       "{date}、{time}、{name}、{ext}、{camera}、{hash}、{counter} などのトークン。空欄なら名前を変えません",
+		"preview.cleanup":            "クリーンアップ (%d)",
+		"preview.cleanupItem":        "%s（%s）",
+		"preview.cleanupSummary":     "空または不要な項目 %d 件を削除します",
+		"cleanup.empty-folder":       "空のフォルダー",
+		"cleanup.empty-file":         "空のファイル",
+		"cleanup.junk":               "不要",
+		"cleanup.done":               "%d 件をクリーンアップしました",
+		"menu.archive":               "古い月をアーカイブ",
+		"menu.restoreArchive":        "アーカイブを復元",
+		"archive.title":              "古い月をアーカイブ",
+		"archive.years":              "経過年数（年）",
+		"archive.years.hint":         "月は .zip 形式でまとめられます（対応形式は .zip のみ）",
+		"archive.next":               "次へ",
+		"archive.invalidYears":       "年数を整数で入力してください",
+		"archive.none":               "%d 年より古いアーカイブ対象の月はありません",
+		"archive.confirm":            "%d 個の月フォルダーを検証済みの .zip アーカイブにまとめ、元のフォルダーを完全に削除しますか？",
+		"archive.done":               "%d / %d か月をアーカイブしました",
+		"archive.restored":           "%d 個のファイルを復元し、既存の %d 個を残しました",
+		"status.archiving":           "アーカイブ中...",
+		"status.restoring":           "復元中...",
+		"option.checksums":           "チェックサムを書き込む",
+		"option.normalize":           "ファイル名を整える",
+		"menu.verify":                "チェックサムを検証",
+		"verify.title":               "チェックサムを検証",
+		"verify.done":                "正常 %d、変更 %d、欠落 %d、想定外 %d",
+		"verify.none":                "月フォルダーに SHA256SUMS ファイルが見つかりません",
+		"status.verifying":           "検証しています...",
+		"resume.title":               "中断された実行",
+		"resume.message":             "このフォルダーで %s に開始した実行が、%d / %d 個のファイルを移動したところで中断されました。再開すると残りを移動し、ロールバックすると移動したファイルを元に戻します。",
+		"resume.resume":              "再開",
+		"resume.rollback":            "ロールバック",
+		"resume.later":               "後で",
+		"resume.pending":             "このフォルダーには先に再開または元に戻す必要がある中断された処理があります",
+		"status.rollingBack":         "ロールバックしています...",
+		"lock.title":                 "フォルダーは使用中です",
+		"lock.message":               "別の実行がこのフォルダーを整理中です（プロセス %d、ホスト %s、開始 %s）。コンピューターのクラッシュなどでその実行が停止している場合は、ロックを強制解除できます。",
+		"lock.force":                 "強制解除",
+		"lock.unlocked":              "ロックを解除しました: %s",
+		"preflight.title":            "整理の前に",
+		"preflight.blocking":         "次の問題を解決するまで整理を開始できません:",
+		"preflight.warnings":         "一部のファイルはこのままでは移動できません。残りのファイルで続行しますか？",
+		"preflight.continue":         "続行",
+		"preflight.space":            "空き容量が不足しています: コピーするサイズ %s、空き %s",
+		"preflight.unwritable":       "%s: フォルダーに書き込めません",
+		"preflight.unreadable":       "%s: ファイルを読み取れません",
+		"preflight.read-only":        "%s: ファイルが読み取り専用のため、コピー後に元のファイルを削除できません",
+		"preflight.path-length":      "%s: 名前またはパスが移動先のドライブには長すぎます",
+		"preflight.invalid-name":     "%s: 移動先のドライブでは使用できない名前です",
+		"preflight.sourceFolder":     "選択したフォルダー",
+		"status.preflightFailed":     "整理の前に問題が見つかりました",
+	},
+}
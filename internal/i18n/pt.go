@@ -0,0 +1,193 @@
+package i18n
+
+var pt = catalog{
+	name: "Português",
+	months: [12]string{"Janeiro", "Fevereiro", "Março", "Abril", "Maio", "Junho",
+		"Julho", "Agosto", "Setembro", "Outubro", "Novembro", "Dezembro"},
+	monthsShort: [12]string{"Jan", "Fev", "Mar", "Abr", "Mai", "Jun",
+		"Jul", "Ago", "Set", "Out", "Nov", "Dez"},
+	messages: map[string]string{
+		"app.description":            "Organize seus arquivos em pastas Ano/Mês\ncom base nas datas",
+		"folder.none":                "Nenhuma pasta selecionada",
+		"folder.selected":            "Pasta selecionada:",
+		"folder.error":               "Erro ao ler a pasta: %v",
+		"folder.found":               "%d arquivos encontrados para organizar",
+		"folder.mismatched":          "%d arquivos têm extensão ausente ou incorreta",
+		"button.select":              "Selecionar pasta",
+		"button.organize":            "Organizar arquivos",
+		"button.settings":            "Configurações",
+		"button.saveReport":          "Salvar relatório",
+		"button.addToQueue":          "Adicionar à fila",
+		"button.runQueue":            "Executar fila",
+		"button.clearQueue":          "Remover concluídos",
+		"option.fixExtensions":       "Corrigir extensões incorretas",
+		"option.mediaDates":          "Usar datas dos metadados de vídeo e áudio",
+		"option.documentDates":       "Usar datas dos metadados de documentos",
+		"option.filenameDates":       "Usar datas do nome do arquivo",
+		"tab.files":                  "Arquivos",
+		"tab.log":                    "Registro de atividades",
+		"tab.queue":                  "Fila",
+		"tab.folders":                "Pastas",
+		"table.name":                 "Nome",
+		"table.date":                 "Data",
+		"table.source":               "Origem da data",
+		"table.destination":          "Destino",
+		"table.status":               "Status",
+		"table.error":                "Erro",
+		"table.search":               "Pesquisar arquivos...",
+		"table.errorsOnly":           "Mostrar apenas erros",
+		"result.pending":             "Pendente",
+		"result.moved":               "Movido",
+		"result.skipped":             "Ignorado",
+		"result.excluded":            "Excluído",
+		"result.failed":              "Falhou",
+		"result.exists":              "Já existe",
+		"source.mtime":               "Data de modificação",
+		"source.media":               "Metadados de mídia",
+		"source.document":            "Metadados do documento",
+		"source.filename":            "Nome do arquivo",
+		"dialog.info":                "Informação",
+		"dialog.selectFirst":         "Selecione uma pasta primeiro",
+		"dialog.confirm.title":       "Confirmar organização",
+		"dialog.cancel":              "Cancelar",
+		"preview.intro":              "Os arquivos de %s serão movidos para estas pastas. Desmarque o que quiser deixar no lugar.",
+		"preview.summary":            "%d arquivos em %d pastas, %d conflitos serão ignorados",
+		"preview.folder":             "%s (%d arquivos)",
+		"preview.conflicts":          "%d conflitos",
+		"preview.conflict":           "%s (já existe, será ignorado)",
+		"status.scanning":            "Analisando arquivos...",
+		"status.organizing":          "Organizando...",
+		"status.error":               "Ocorreu um erro",
+		"status.noFiles":             "Nenhum arquivo para organizar",
+		"status.done":                "Concluído! %d arquivos movidos, %d ignorados",
+		"log.error":                  "Erro: %v",
+		"log.noFiles":                "Nenhum arquivo encontrado para organizar",
+		"log.starting":               "Iniciando organização...",
+		"log.organizeError":          "Erro durante a organização: %v",
+		"log.complete":               "✅ Concluído! Movidos: %d, ignorados: %d",
+		"report.saved":               "Relatório salvo em %s",
+		"report.error":               "Não foi possível salvar o relatório: %v",
+		"queue.pending":              "Aguardando",
+		"queue.running":              "Organizando...",
+		"queue.done":                 "Concluído: %d movidos, %d ignorados, %d com falha",
+		"queue.failed":               "Falhou: %v",
+		"queue.added":                "%d pastas adicionadas à fila",
+		"queue.starting":             "Organizando %s...",
+		"queue.finished":             "Fila concluída",
+		"recent.never":               "Ainda não organizada",
+		"recent.lastRun":             "Última execução em %s: %d movidos, %d ignorados, %d com falha",
+		"recent.pin":                 "Fixar",
+		"recent.unpin":               "Desafixar",
+		"settings.title":             "Configurações",
+		"settings.language":          "Idioma",
+		"settings.theme":             "Tema",
+		"settings.largeText":         "Texto maior",
+		"settings.themeFile":         "Arquivo de tema",
+		"settings.themeFile.hint":    "Arquivo .json ou .toml opcional com cores, tamanhos e fontes próprios",
+		"settings.monthNames":        "Nomes das pastas de mês",
+		"settings.timeZone":          "Fuso horário",
+		"settings.timeZone.hint":     "local, utc, metadata, -03:00 ou America/Sao_Paulo",
+		"settings.mediaLocal":        "Horários de vídeo em hora local",
+		"settings.mediaLocal.hint":   "Para câmeras que gravam o horário de MP4 e MKV em hora local em vez de UTC",
+		"settings.namePatterns":      "Padrões de data",
+		"settings.namePatterns.hint": "Expressões regulares adicionais para datas em nomes de arquivo, uma por linha, com os grupos (?P<year>), (?P<month>) e (?P<day>)",
+		"settings.dateRules":         "Fontes de data por tipo de arquivo",
+		"settings.dateRules.hint":    "Uma regra por linha, como .pdf,.docx=document,filename; as fontes são media, document, filename e mtime",
+		"settings.save":              "Salvar",
+		"settings.cancel":            "Cancelar",
+		"settings.invalid":           "Configuração inválida: %v",
+		"theme.system":               "Seguir o sistema",
+		"theme.light":                "Claro",
+		"theme.dark":                 "Escuro",
+		"theme.highContrast":         "Alto contraste",
+		"theme.fileError":            "Tema personalizado não carregado: %v",
+		"month.english":              "Inglês (03-March)",
+		"month.localized":            "Traduzido (%s)",
+		"month.abbreviated":          "Abreviado (%s)",
+		"month.numeric":              "Somente números (03)",
+		"menu.file":                  "Arquivo",
+		"menu.edit":                  "Editar",
+		"menu.view":                  "Exibir",
+		"menu.undo":                  "Desfazer a última execução",
+		"menu.cancel":                "Cancelar execução",
+		"menu.find":                  "Localizar no registro",
+		"menu.clearLog":              "Limpar registro",
+		"log.search":                 "Localizar no registro...",
+		"undo.title":                 "Desfazer a última execução",
+		"undo.confirm":               "Devolver os %d arquivos da última execução em %s ao local original?",
+		"undo.done":                  "%d arquivos restaurados, %d não puderam ser restaurados",
+		"undo.nothing":               "Não há execução para desfazer",
+		"status.cancelling":          "Cancelando...",
+		"status.cancelled":           "Cancelado",
+		"status.undoing":             "Desfazendo a última execução...",
+		"settings.notify":            "Notificações",
+		"notify.unfocused":           "Quando a janela está em segundo plano",
+		"notify.always":              "Sempre",
+		"notify.off":                 "Desativadas",
+		"notify.default":             "Como nas Configurações",
+		"notify.doneTitle":           "%s organizada",
+		"notify.failedTitle":         "Falha ao organizar %s",
+		"notify.batchTitle":          "%d pastas organizadas",
+		"notify.counts":              "%d movidos, %d ignorados, %d com falha",
+		"notify.foldersFailed":       "%d pastas não puderam ser organizadas",
+		"notify.failedFiles":         "Com falha: %s",
+		"notify.failedFilesMore":     "Com falha: %s e mais %d",
+		"option.emptyDirs":           "Remover pastas vazias",
+		"option.emptyFiles":          "Remover arquivos vazios",
+		"option.junk":                "Remover arquivos inúteis",
+		"settings.junkPatterns":      "Arquivos inúteis",
+		"settings.junkPatterns.hint": "Padrões de nome separados por vírgulas removidos na limpeza",
+		"settings.rename":            "Renomear arquivos",
+		"settings.rename.hint":       "Marcadores como {date}, {time}, {name}, {ext}, {camera}, {hash} e {counter}; deixe vazio para manter os nomes",
+		"preview.cleanup":            "Limpeza (%d)",
+		"preview.cleanupItem":        "%s (%s)",
+		"preview.cleanupSummary":     "%d itens vazios ou inúteis serão removidos",
+		"cleanup.empty-folder":       "pasta vazia",
+		"cleanup.empty-file":         "arquivo vazio",
+		"cleanup.junk":               "inútil",
+		"cleanup.done":               "%d itens limpos",
+		"menu.archive":               "Arquivar meses antigos",
+		"menu.restoreArchive":        "Restaurar arquivo",
+		"archive.title":              "Arquivar meses antigos",
+		"archive.years":              "Mais antigos que (anos)",
+		"archive.years.hint":         "Os meses são compactados em .zip, o único formato",
+		"archive.next":               "Avançar",
+		"archive.invalidYears":       "Digite um número inteiro de anos",
+		"archive.none":               "Nenhum mês com mais de %d anos para arquivar",
+		"archive.confirm":            "Compactar %d pastas de meses em arquivos .zip verificados e excluir definitivamente os originais?",
+		"archive.done":               "%d de %d meses arquivados",
+		"archive.restored":           "%d arquivos restaurados, %d existentes mantidos",
+		"status.archiving":           "Arquivando...",
+		"status.restoring":           "Restaurando...",
+		"option.checksums":           "Gravar somas de verificação",
+		"option.normalize":           "Limpar nomes de arquivos",
+		"menu.verify":                "Verificar somas de verificação",
+		"verify.title":               "Verificar somas de verificação",
+		"verify.done":                "%d corretos, %d alterados, %d ausentes, %d inesperados",
+		"verify.none":                "Nenhum arquivo SHA256SUMS encontrado nas pastas de meses",
+		"status.verifying":           "Verificando...",
+		"resume.title":               "Execução interrompida",
+		"resume.message":             "Uma execução iniciada em %s nesta pasta parou após mover %d de %d arquivos. Retome-a para mover o restante ou reverta-a para devolver os arquivos movidos.",
+		"resume.resume":              "Retomar",
+		"resume.rollback":            "Reverter",
+		"resume.later":               "Mais tarde",
+		"resume.pending":             "Esta pasta tem uma execução interrompida para retomar ou reverter primeiro",
+		"status.rollingBack":         "Revertendo...",
+		"lock.title":                 "Pasta em uso",
+		"lock.message":               "Outra execução está organizando esta pasta: processo %d em %s, iniciado em %s. Se essa execução parou, por exemplo porque o computador travou, você pode forçar o desbloqueio.",
+		"lock.force":                 "Forçar desbloqueio",
+		"lock.unlocked":              "Desbloqueada: %s",
+		"preflight.title":            "Antes de organizar",
+		"preflight.blocking":         "A organização não pode começar até que estes problemas sejam corrigidos:",
+		"preflight.warnings":         "Alguns arquivos não poderão ser movidos como estão. Continuar com o restante?",
+		"preflight.continue":         "Continuar",
+		"preflight.space":            "Espaço livre insuficiente: %s para copiar, %s disponíveis",
+		"preflight.unwritable":       "%s: a pasta não permite gravação",
+		"preflight.unreadable":       "%s: o arquivo não pode ser lido",
+		"preflight.read-only":        "%s: o arquivo é somente leitura e o original não poderá ser removido após a cópia",
+		"preflight.path-length":      "%s: nome ou caminho longo demais para a unidade de destino",
+		"preflight.invalid-name":     "%s: nome não permitido na unidade de destino",
+		"preflight.sourceFolder":     "A pasta selecionada",
+		"status.preflightFailed":     "Problemas encontrados antes de organizar",
+	},
+}
@@ -0,0 +1,132 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var ErrNoCamera = errors.New("no camera model found")
+
+const (
+	tiffTagModel = 0x0110
+	tiffASCII    = 2
+	// maxModelLen caps the model string, which cameras keep short.
+	maxModelLen = 256
+)
+
+// CameraModel returns the camera model recorded in a photo's EXIF data.
+// JPEG and TIFF-based files, which include most raw formats (CR2, NEF,
+// ARW, DNG), are supported.
+func CameraModel(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	head := make([]byte, 4)
+	n, _ := io.ReadFull(f, head)
+	head = head[:n]
+
+	switch {
+	case bytes.HasPrefix(head, []byte{0xFF, 0xD8}):
+		tiff, err := jpegExif(f)
+		if err != nil {
+			return "", err
+		}
+		return tiffModel(bytes.NewReader(tiff))
+	case bytes.HasPrefix(head, []byte("II*\x00")), bytes.HasPrefix(head, []byte("MM\x00*")):
+		return tiffModel(f)
+	}
+	return "", fmt.Errorf("%s: unsupported photo format", filepath.Base(path))
+}
+
+// jpegExif returns the TIFF structure in a JPEG's Exif APP1 segment.
+func jpegExif(r io.ReadSeeker) ([]byte, error) {
+	if _, err := r.Seek(2, io.SeekStart); err != nil {
+		return nil, err
+	}
+	header := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			return nil, ErrNoCamera
+		}
+		if header[0] != 0xFF {
+			return nil, ErrNoCamera
+		}
+		marker := header[1]
+		size := int64(binary.BigEndian.Uint16(header[2:])) - 2
+		// Start of scan: the metadata segments all come before it.
+		if marker == 0xDA || size < 0 {
+			return nil, ErrNoCamera
+		}
+		if marker != 0xE1 {
+			if _, err := r.Seek(size, io.SeekCurrent); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		segment := make([]byte, size)
+		if _, err := io.ReadFull(r, segment); err != nil {
+			return nil, ErrNoCamera
+		}
+		if tiff, ok := bytes.CutPrefix(segment, []byte("Exif\x00\x00")); ok {
+			return tiff, nil
+		}
+	}
+}
+
+// tiffModel reads the Model tag from the first IFD of a TIFF structure.
+func tiffModel(r io.ReaderAt) (string, error) {
+	header := make([]byte, 8)
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return "", ErrNoCamera
+	}
+	var order binary.ByteOrder
+	switch string(header[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return "", ErrNoCamera
+	}
+	ifd := int64(order.Uint32(header[4:]))
+
+	count := make([]byte, 2)
+	if _, err := r.ReadAt(count, ifd); err != nil {
+		return "", ErrNoCamera
+	}
+	entry := make([]byte, 12)
+	for i := range int64(order.Uint16(count)) {
+		if _, err := r.ReadAt(entry, ifd+2+i*12); err != nil {
+			return "", ErrNoCamera
+		}
+		if order.Uint16(entry) != tiffTagModel || order.Uint16(entry[2:]) != tiffASCII {
+			continue
+		}
+		n := order.Uint32(entry[4:])
+		if n == 0 || n > maxModelLen {
+			return "", ErrNoCamera
+		}
+		value := entry[8:12]
+		if n > 4 {
+			value = make([]byte, n)
+			if _, err := r.ReadAt(value, int64(order.Uint32(entry[8:]))); err != nil {
+				return "", ErrNoCamera
+			}
+		}
+		model := strings.TrimSpace(strings.TrimRight(string(value[:min(n, uint32(len(value)))]), "\x00"))
+		if model == "" {
+			return "", ErrNoCamera
+		}
+		return model, nil
+	}
+	return "", ErrNoCamera
+}
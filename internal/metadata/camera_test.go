@@ -0,0 +1,79 @@
+package metadata
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// tiffWithModel builds a TIFF structure whose first IFD holds a Model tag
+func tiffWithModel(order binary.AppendByteOrder, model string) []byte {
+	value := append([]byte(model), 0)
+	var tiff []byte
+	if order == binary.LittleEndian {
+		tiff = []byte("II*\x00")
+	} else {
+		tiff = []byte("MM\x00*")
+	}
+	tiff = order.AppendUint32(tiff, 8)
+	tiff = order.AppendUint16(tiff, 2)
+	// An unrelated tag (Make) first, then Model.
+	tiff = order.AppendUint16(tiff, 0x010F)
+	tiff = order.AppendUint16(tiff, tiffASCII)
+	tiff = order.AppendUint32(tiff, 1)
+	tiff = order.AppendUint32(tiff, 0)
+	tiff = order.AppendUint16(tiff, tiffTagModel)
+	tiff = order.AppendUint16(tiff, tiffASCII)
+	tiff = order.AppendUint32(tiff, uint32(len(value)))
+	if len(value) <= 4 {
+		inline := make([]byte, 4)
+		copy(inline, value)
+		tiff = append(tiff, inline...)
+		return order.AppendUint32(tiff, 0)
+	}
+	tiff = order.AppendUint32(tiff, uint32(len(tiff)+8))
+	tiff = order.AppendUint32(tiff, 0)
+	return append(tiff, value...)
+}
+
+func jpegSegment(marker byte, payload []byte) []byte {
+	segment := []byte{0xFF, marker}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+// TestCameraModel verifies the model is read from JPEG and TIFF files in
+// either byte order
+func TestCameraModel(t *testing.T) {
+	jfif := jpegSegment(0xE0, []byte("JFIF\x00\x01\x02"))
+	exif := jpegSegment(0xE1, append([]byte("Exif\x00\x00"), tiffWithModel(binary.LittleEndian, "Canon EOS R5")...))
+	jpeg := append(append(append([]byte{0xFF, 0xD8}, jfif...), exif...), 0xFF, 0xDA, 0, 2)
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"photo.jpg", jpeg, "Canon EOS R5"},
+		{"photo.dng", tiffWithModel(binary.BigEndian, "ILCE-7M3"), "ILCE-7M3"},
+		{"photo.tif", tiffWithModel(binary.LittleEndian, "X1"), "X1"},
+	}
+	for _, tt := range tests {
+		got, err := CameraModel(writeFile(t, tt.name, tt.data))
+		if err != nil || got != tt.want {
+			t.Errorf("%s: expected %q, got %q, %v", tt.name, tt.want, got, err)
+		}
+	}
+}
+
+// TestCameraModelMissing verifies photos without a model report
+// ErrNoCamera and other files an error
+func TestCameraModelMissing(t *testing.T) {
+	jpeg := append(append([]byte{0xFF, 0xD8}, jpegSegment(0xE0, []byte("JFIF\x00"))...), 0xFF, 0xDA, 0, 2)
+	if _, err := CameraModel(writeFile(t, "plain.jpg", jpeg)); !errors.Is(err, ErrNoCamera) {
+		t.Errorf("Expected ErrNoCamera, got %v", err)
+	}
+	if _, err := CameraModel(writeFile(t, "notes.txt", []byte("hello"))); err == nil {
+		t.Error("Expected an error for a text file")
+	}
+}
@@ -0,0 +1,254 @@
+package metadata
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+const (
+	nsDCTerms = "http://purl.org/dc/terms/"
+	nsDC      = "http://purl.org/dc/elements/1.1/"
+	nsODFMeta = "urn:oasis:names:tc:opendocument:xmlns:meta:1.0"
+)
+
+// pdfScanBytes is how much of the start and end of a PDF is searched. The
+// info dictionary and XMP packet live near one end in practically every
+// file, and scanning the whole document would be slow for large scans.
+const pdfScanBytes = 1 << 20
+
+// DocumentDate returns the authoring date stored in a PDF, OOXML (docx,
+// xlsx, pptx) or ODF (odt, ods, odp) document. The creation date is
+// preferred; the modification date is used when no creation date is set.
+func DocumentDate(path string) (Date, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".pdf":
+		return pdfDate(path)
+	case ".docx", ".docm", ".xlsx", ".xlsm", ".pptx", ".pptm":
+		return zipXMLDate(path, "docProps/core.xml", [][2]string{{nsDCTerms, "created"}, {nsDCTerms, "modified"}})
+	case ".odt", ".ods", ".odp", ".odg":
+		return zipXMLDate(path, "meta.xml", [][2]string{{nsODFMeta, "creation-date"}, {nsDC, "date"}})
+	}
+	return Date{}, fmt.Errorf("%s: unsupported document format", filepath.Base(path))
+}
+
+// IsDocumentExt reports whether DocumentDate understands files with this
+// extension.
+func IsDocumentExt(ext string) bool {
+	switch strings.ToLower(ext) {
+	case ".pdf", ".docx", ".docm", ".xlsx", ".xlsm", ".pptx", ".pptm", ".odt", ".ods", ".odp", ".odg":
+		return true
+	}
+	return false
+}
+
+// ---- PDF ----
+
+var (
+	pdfInfoDate = regexp.MustCompile(`/(CreationDate|ModDate)\s*(\([^)]*\)|<[0-9A-Fa-f\s]*>)`)
+	xmpDate     = regexp.MustCompile(`xmp:(CreateDate|ModifyDate)(?:="([^"]+)"|>([^<]+)<)`)
+)
+
+func pdfDate(path string) (Date, error) {
+	data, err := readEnds(path, pdfScanBytes)
+	if err != nil {
+		return Date{}, err
+	}
+
+	found := make(map[string]Date)
+	for _, m := range pdfInfoDate.FindAllSubmatch(data, -1) {
+		if _, ok := found[string(m[1])]; ok {
+			continue
+		}
+		if d, ok := parsePDFDate(decodePDFString(m[2])); ok {
+			found[string(m[1])] = d
+		}
+	}
+	for _, m := range xmpDate.FindAllSubmatch(data, -1) {
+		key := "CreationDate"
+		if string(m[1]) == "ModifyDate" {
+			key = "ModDate"
+		}
+		if _, ok := found[key]; ok {
+			continue
+		}
+		if d, ok := parseDate(string(m[2]) + string(m[3])); ok {
+			found[key] = d
+		}
+	}
+
+	for _, key := range []string{"CreationDate", "ModDate"} {
+		if d, ok := found[key]; ok {
+			return d, nil
+		}
+	}
+	return Date{}, ErrNoDate
+}
+
+func readEnds(path string, n int64) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() <= 2*n {
+		return io.ReadAll(f)
+	}
+
+	data := make([]byte, 2*n)
+	if _, err := f.ReadAt(data[:n], 0); err != nil {
+		return nil, err
+	}
+	if _, err := f.ReadAt(data[n:], info.Size()-n); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return data, nil
+}
+
+// decodePDFString handles literal strings, hex strings and the UTF-16BE
+// encoding some producers use for dates.
+func decodePDFString(raw []byte) string {
+	s := raw[1 : len(raw)-1]
+	if raw[0] == '<' {
+		decoded, err := hex.DecodeString(string(bytes.Join(bytes.Fields(s), nil)))
+		if err != nil {
+			return ""
+		}
+		s = decoded
+	}
+	if bytes.HasPrefix(s, []byte{0xFE, 0xFF}) {
+		units := make([]uint16, 0, len(s)/2)
+		for i := 2; i+1 < len(s); i += 2 {
+			units = append(units, uint16(s[i])<<8|uint16(s[i+1]))
+		}
+		return string(utf16.Decode(units))
+	}
+	return string(s)
+}
+
+// parsePDFDate parses the PDF date format D:YYYYMMDDHHmmSSOHH'mm', where
+// everything after the year is optional.
+func parsePDFDate(s string) (Date, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "D:")
+	digits := 0
+	for digits < len(s) && digits < 14 && s[digits] >= '0' && s[digits] <= '9' {
+		digits++
+	}
+	rest := strings.TrimSpace(s[digits:])
+	digits -= digits % 2
+	// A year alone cannot place the file in a month folder.
+	if digits < 6 {
+		return Date{}, false
+	}
+
+	const defaults = "00000101000000"
+	stamp := s[:digits] + defaults[digits:]
+	t, err := time.Parse("20060102150405", stamp)
+	if err != nil {
+		return Date{}, false
+	}
+
+	d := Date{Time: t, Floating: true}
+	if rest != "" {
+		switch rest[0] {
+		case 'Z':
+			d.Floating = false
+		case '+', '-':
+			offset := strings.ReplaceAll(rest[1:], "'", "")
+			if len(offset) == 2 {
+				offset += "00"
+			}
+			if len(offset) >= 4 {
+				if zoned, err := time.Parse("20060102150405-0700", stamp+rest[:1]+offset[:4]); err == nil {
+					d = Date{Time: zoned}
+				}
+			}
+		}
+	}
+
+	if !plausible(d.Time) {
+		return Date{}, false
+	}
+	return d, true
+}
+
+// ---- OOXML and ODF ----
+
+func zipXMLDate(path, member string, fields [][2]string) (Date, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return Date{}, err
+	}
+	defer zr.Close()
+
+	for _, zf := range zr.File {
+		if zf.Name != member {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return Date{}, err
+		}
+		values, err := xmlElementText(io.LimitReader(rc, maxTagBytes), fields)
+		rc.Close()
+		if err != nil {
+			return Date{}, err
+		}
+		for _, field := range fields {
+			if d, ok := parseDate(values[field]); ok {
+				return d, nil
+			}
+		}
+		return Date{}, ErrNoDate
+	}
+	return Date{}, ErrNoDate
+}
+
+// xmlElementText returns the text of the first element matching each
+// namespace/local-name pair.
+func xmlElementText(r io.Reader, fields [][2]string) (map[[2]string]string, error) {
+	values := make(map[[2]string]string)
+	dec := xml.NewDecoder(r)
+	var current *[2]string
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return values, nil
+		}
+		if err != nil {
+			return values, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			current = nil
+			for i, f := range fields {
+				if t.Name.Space == f[0] && t.Name.Local == f[1] {
+					if _, seen := values[f]; !seen {
+						current = &fields[i]
+					}
+				}
+			}
+		case xml.CharData:
+			if current != nil {
+				values[*current] += string(t)
+			}
+		case xml.EndElement:
+			current = nil
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package metadata
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeZipMember(t *testing.T, name, member, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create %s: %v", name, err)
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	w, err := zw.Create(member)
+	if err != nil {
+		t.Fatalf("Failed to add %s: %v", member, err)
+	}
+	w.Write([]byte(content))
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to close zip: %v", err)
+	}
+	return path
+}
+
+// TestParsePDFDate verifies the PDF date format and its optional parts
+func TestParsePDFDate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Time
+		floating bool
+		ok       bool
+	}{
+		{"D:20240312101500+01'00'", time.Date(2024, 3, 12, 9, 15, 0, 0, time.UTC), false, true},
+		{"D:20240312101500Z", time.Date(2024, 3, 12, 10, 15, 0, 0, time.UTC), false, true},
+		{"D:20240312101500-05'00", time.Date(2024, 3, 12, 15, 15, 0, 0, time.UTC), false, true},
+		{"D:20240312", time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC), true, true},
+		{"D:202403", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), true, true},
+		{"D:2024", time.Time{}, false, false},
+		{"garbage", time.Time{}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d, ok := parsePDFDate(tt.input)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok=%v, got %v", tt.ok, ok)
+			}
+			if !ok {
+				return
+			}
+			if !d.Time.Equal(tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, d.Time)
+			}
+			if d.Floating != tt.floating {
+				t.Errorf("Expected floating=%v, got %v", tt.floating, d.Floating)
+			}
+		})
+	}
+}
+
+// TestDocumentDate verifies dates are read from each supported document type
+func TestDocumentDate(t *testing.T) {
+	expected := time.Date(2023, 11, 4, 8, 30, 0, 0, time.UTC)
+
+	pdfInfo := "%PDF-1.4\n1 0 obj\n<< /Producer (Scanner) /ModDate (D:20240101000000Z) /CreationDate (D:20231104083000Z) >>\nendobj\n%%EOF\n"
+	pdfHex := "%PDF-1.7\n<< /CreationDate <FEFF0044003A00320030003200330031003100300034003000380033003000300030005A> >>\n"
+	pdfXMP := "%PDF-1.7\n<x:xmpmeta><rdf:Description xmp:CreateDate=\"2023-11-04T09:30:00+01:00\"/></x:xmpmeta>\n"
+
+	coreXML := `<?xml version="1.0" encoding="UTF-8"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dcterms="http://purl.org/dc/terms/">
+  <dcterms:created>2023-11-04T08:30:00Z</dcterms:created>
+  <dcterms:modified>2024-02-01T10:00:00Z</dcterms:modified>
+</cp:coreProperties>`
+	metaXML := `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-meta xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:meta="urn:oasis:names:tc:opendocument:xmlns:meta:1.0">
+  <office:meta><meta:creation-date>2023-11-04T08:30:00.123</meta:creation-date></office:meta>
+</office:document-meta>`
+
+	tests := []struct {
+		name     string
+		path     string
+		floating bool
+	}{
+		{"info dictionary", writeFile(t, "scan.pdf", []byte(pdfInfo)), false},
+		{"hex string", writeFile(t, "hex.pdf", []byte(pdfHex)), false},
+		{"xmp", writeFile(t, "xmp.pdf", []byte(pdfXMP)), false},
+		{"docx", writeZipMember(t, "report.docx", "docProps/core.xml", coreXML), false},
+		{"odt", writeZipMember(t, "report.odt", "meta.xml", metaXML), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := DocumentDate(tt.path)
+			if err != nil {
+				t.Fatalf("DocumentDate failed: %v", err)
+			}
+			if !d.Time.Truncate(time.Second).Equal(expected) {
+				t.Errorf("Expected %v, got %v", expected, d.Time)
+			}
+			if d.Floating != tt.floating {
+				t.Errorf("Expected floating=%v, got %v", tt.floating, d.Floating)
+			}
+		})
+	}
+}
+
+// TestDocumentDateMissing verifies documents without dates return ErrNoDate
+func TestDocumentDateMissing(t *testing.T) {
+	path := writeFile(t, "blank.pdf", []byte("%PDF-1.4\n<< /Producer (x) >>\n"))
+	if _, err := DocumentDate(path); err != ErrNoDate {
+		t.Errorf("Expected ErrNoDate, got %v", err)
+	}
+}
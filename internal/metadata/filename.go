@@ -0,0 +1,110 @@
+package metadata
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// BuiltinFilenamePatterns recognise the names written by common cameras,
+// phones and messengers. Each pattern uses named groups; year, month and day
+// are required, hour, minute and second are optional.
+var BuiltinFilenamePatterns = []string{
+	// IMG_20240101_120000.jpg, PXL_20240101_120000123.jpg
+	`(?:IMG|VID|PXL|MVIMG|PANO|BURST\d*)_(?P<year>\d{4})(?P<month>\d{2})(?P<day>\d{2})_(?P<hour>\d{2})(?P<minute>\d{2})(?P<second>\d{2})`,
+	// IMG-20240101-WA0003.jpg, VID-20240101-WA0003.mp4
+	`(?:IMG|VID|AUD|PTT|DOC|STK)-(?P<year>\d{4})(?P<month>\d{2})(?P<day>\d{2})-WA\d+`,
+	// WhatsApp Image 2024-02-03 at 10.11.12.jpeg
+	`WhatsApp (?:Image|Video|Audio|Ptt) (?P<year>\d{4})-(?P<month>\d{2})-(?P<day>\d{2}) at (?P<hour>\d{1,2})\.(?P<minute>\d{2})\.(?P<second>\d{2})`,
+	// Screenshot_20240305-101010.png, Screenshot_2024-03-05-10-10-10.png
+	`Screenshot_(?P<year>\d{4})-?(?P<month>\d{2})-?(?P<day>\d{2})[-_](?P<hour>\d{2})-?(?P<minute>\d{2})-?(?P<second>\d{2})`,
+	// Screenshot 2024-03-05 at 10.10.10.png (macOS)
+	`Screen ?[Ss]hot (?P<year>\d{4})-(?P<month>\d{2})-(?P<day>\d{2}) at (?P<hour>\d{1,2})\.(?P<minute>\d{2})\.(?P<second>\d{2})`,
+	// signal-2024-03-05-101010.jpg
+	`signal-(?P<year>\d{4})-(?P<month>\d{2})-(?P<day>\d{2})-(?P<hour>\d{2})(?P<minute>\d{2})(?P<second>\d{2})`,
+	// 2023-11-04 Scan.pdf, 2023_11_04-10.15.00 notes.txt
+	`(?:^|\D)(?P<year>\d{4})[-_.](?P<month>\d{2})[-_.](?P<day>\d{2})(?:[ _T-](?P<hour>\d{2})[-.:h]?(?P<minute>\d{2})[-.:m]?(?P<second>\d{2}))?(?:\D|$)`,
+	// 20231104_101500.jpg, scan20231104.pdf
+	`(?:^|\D)(?P<year>(?:19|20)\d{2})(?P<month>\d{2})(?P<day>\d{2})(?:[_-]?(?P<hour>\d{2})(?P<minute>\d{2})(?P<second>\d{2}))?(?:\D|$)`,
+}
+
+// FilenameMatcher extracts dates from file names. Dates outside [Min, Max]
+// are rejected, which filters out serial numbers that happen to look like
+// dates.
+type FilenameMatcher struct {
+	Min, Max time.Time
+	patterns []*regexp.Regexp
+}
+
+// NewFilenameMatcher compiles the custom patterns, which are tried before the
+// built-in ones. Custom patterns must define year, month and day groups.
+func NewFilenameMatcher(custom ...string) (*FilenameMatcher, error) {
+	m := &FilenameMatcher{
+		Min: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		Max: now().Add(24 * time.Hour),
+	}
+	for _, p := range append(append([]string{}, custom...), BuiltinFilenamePatterns...) {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+		for _, group := range []string{"year", "month", "day"} {
+			if re.SubexpIndex(group) < 0 {
+				return nil, fmt.Errorf("pattern %q has no (?P<%s>...) group", p, group)
+			}
+		}
+		m.patterns = append(m.patterns, re)
+	}
+	return m, nil
+}
+
+// Match returns the first valid date found in name. The result is always a
+// floating wall-clock time.
+func (m *FilenameMatcher) Match(name string) (Date, bool) {
+	for _, re := range m.patterns {
+		for _, groups := range re.FindAllStringSubmatch(name, -1) {
+			if t, ok := m.dateFromGroups(re, groups); ok {
+				return Date{Time: t, Floating: true}, true
+			}
+		}
+	}
+	return Date{}, false
+}
+
+func (m *FilenameMatcher) dateFromGroups(re *regexp.Regexp, groups []string) (time.Time, bool) {
+	value := func(name string, fallback int) (int, bool) {
+		i := re.SubexpIndex(name)
+		if i < 0 || groups[i] == "" {
+			return fallback, true
+		}
+		n, err := strconv.Atoi(groups[i])
+		return n, err == nil
+	}
+
+	parts := make([]int, 6)
+	for i, name := range []string{"year", "month", "day", "hour", "minute", "second"} {
+		n, ok := value(name, 0)
+		if !ok {
+			return time.Time{}, false
+		}
+		parts[i] = n
+	}
+	year, month, day, hour, minute, second := parts[0], parts[1], parts[2], parts[3], parts[4], parts[5]
+	if year < 100 {
+		year += 2000
+	}
+
+	if month < 1 || month > 12 || day < 1 || day > 31 || hour > 23 || minute > 59 || second > 59 {
+		return time.Time{}, false
+	}
+	t := time.Date(year, time.Month(month), day, hour, minute, second, 0, time.UTC)
+	// time.Date normalises 31 April to 1 May; treat that as invalid.
+	if t.Day() != day {
+		return time.Time{}, false
+	}
+	if t.Before(m.Min) || t.After(m.Max) {
+		return time.Time{}, false
+	}
+	return t, true
+}
@@ -0,0 +1,94 @@
+package metadata
+
+import (
+	"testing"
+	"time"
+)
+
+// TestFilenameMatcherBuiltins verifies the built-in camera and messenger patterns
+func TestFilenameMatcherBuiltins(t *testing.T) {
+	m, err := NewFilenameMatcher()
+	if err != nil {
+		t.Fatalf("NewFilenameMatcher failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		expected time.Time
+	}{
+		{"2023-11-04 Scan.pdf", time.Date(2023, 11, 4, 0, 0, 0, 0, time.UTC)},
+		{"IMG_20240101_120000.jpg", time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{"PXL_20240101_120000123.jpg", time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{"WhatsApp Image 2024-02-03 at 10.11.12.jpeg", time.Date(2024, 2, 3, 10, 11, 12, 0, time.UTC)},
+		{"Screenshot_20240305-101010.png", time.Date(2024, 3, 5, 10, 10, 10, 0, time.UTC)},
+		{"Screenshot 2024-03-05 at 9.10.10.png", time.Date(2024, 3, 5, 9, 10, 10, 0, time.UTC)},
+		{"VID-20240101-WA0003.mp4", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"signal-2024-03-05-101010.jpg", time.Date(2024, 3, 5, 10, 10, 10, 0, time.UTC)},
+		{"20231104_101500.jpg", time.Date(2023, 11, 4, 10, 15, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, ok := m.Match(tt.name)
+			if !ok {
+				t.Fatal("Expected a match")
+			}
+			if !d.Time.Equal(tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, d.Time)
+			}
+			if !d.Floating {
+				t.Error("Expected a floating date")
+			}
+		})
+	}
+}
+
+// TestFilenameMatcherRejectsNonsense verifies sanity bounds on matched dates
+func TestFilenameMatcherRejectsNonsense(t *testing.T) {
+	m, err := NewFilenameMatcher()
+	if err != nil {
+		t.Fatalf("NewFilenameMatcher failed: %v", err)
+	}
+	m.Max = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, name := range []string{
+		"invoice-12345678.pdf",
+		"2023-13-04 report.pdf",
+		"2023-04-31 report.pdf",
+		"IMG_20240101_256000.jpg",
+		"1850-01-01 portrait.jpg",
+		"2099-01-01 plan.txt",
+		"holiday.jpg",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if d, ok := m.Match(name); ok {
+				t.Errorf("Expected no match, got %v", d.Time)
+			}
+		})
+	}
+}
+
+// TestFilenameMatcherCustom verifies user-defined patterns are tried first
+func TestFilenameMatcherCustom(t *testing.T) {
+	m, err := NewFilenameMatcher(`Invoice (?P<day>\d{2})\.(?P<month>\d{2})\.(?P<year>\d{2})`)
+	if err != nil {
+		t.Fatalf("NewFilenameMatcher failed: %v", err)
+	}
+
+	d, ok := m.Match("Invoice 04.11.23.pdf")
+	if !ok {
+		t.Fatal("Expected a match")
+	}
+	if expected := time.Date(2023, 11, 4, 0, 0, 0, 0, time.UTC); !d.Time.Equal(expected) {
+		t.Errorf("Expected %v, got %v", expected, d.Time)
+	}
+}
+
+// TestFilenameMatcherInvalidPattern verifies pattern validation
+func TestFilenameMatcherInvalidPattern(t *testing.T) {
+	for _, p := range []string{`(?P<year>\d{4}`, `(?P<year>\d{4})-(?P<month>\d{2})`} {
+		if _, err := NewFilenameMatcher(p); err == nil {
+			t.Errorf("Expected error for pattern %q", p)
+		}
+	}
+}
@@ -0,0 +1,571 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+var (
+	mp4Epoch      = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+	matroskaEpoch = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// maxTagBytes caps how much of a file is read while looking for tags, so a
+// corrupt size field cannot make us allocate gigabytes.
+const maxTagBytes = 4 << 20
+
+// MediaDate returns the recording date stored in an audio or video file.
+// ISO-BMFF (mp4, mov, m4a, 3gp), Matroska/WebM, MP3 (ID3v2), FLAC and
+// Ogg (Vorbis comments) are supported.
+func MediaDate(path string) (Date, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Date{}, err
+	}
+	defer f.Close()
+
+	head := make([]byte, 12)
+	n, _ := io.ReadFull(f, head)
+	head = head[:n]
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return Date{}, err
+	}
+
+	switch {
+	case len(head) >= 8 && isBMFFBox(string(head[4:8])):
+		return bmffDate(f)
+	case bytes.HasPrefix(head, []byte{0x1A, 0x45, 0xDF, 0xA3}):
+		return matroskaDate(f)
+	case bytes.HasPrefix(head, []byte("ID3")):
+		return id3Date(f)
+	case bytes.HasPrefix(head, []byte("fLaC")):
+		return flacDate(f)
+	case bytes.HasPrefix(head, []byte("OggS")):
+		return oggDate(f)
+	}
+	return Date{}, fmt.Errorf("%s: unsupported media format", filepath.Base(path))
+}
+
+// IsMediaExt reports whether MediaDate understands files with this extension.
+func IsMediaExt(ext string) bool {
+	switch strings.ToLower(ext) {
+	case ".mp4", ".m4v", ".mov", ".qt", ".m4a", ".m4b", ".3gp", ".3g2",
+		".mkv", ".mka", ".webm", ".mp3", ".flac", ".ogg", ".oga", ".opus":
+		return true
+	}
+	return false
+}
+
+func isBMFFBox(typ string) bool {
+	switch typ {
+	case "ftyp", "moov", "mdat", "wide", "free", "skip":
+		return true
+	}
+	return false
+}
+
+// ---- ISO base media file format (mp4, mov, 3gp, m4a) ----
+
+type box struct {
+	typ        string
+	start, end int64 // payload range
+}
+
+func readBoxes(r io.ReaderAt, start, end int64) ([]box, error) {
+	var boxes []box
+	header := make([]byte, 16)
+	for pos := start; pos+8 <= end; {
+		if _, err := r.ReadAt(header[:8], pos); err != nil {
+			return boxes, err
+		}
+		size := int64(binary.BigEndian.Uint32(header[0:4]))
+		typ := string(header[4:8])
+		headerLen := int64(8)
+
+		switch size {
+		case 0:
+			size = end - pos
+		case 1:
+			if _, err := r.ReadAt(header[8:16], pos+8); err != nil {
+				return boxes, err
+			}
+			size = int64(binary.BigEndian.Uint64(header[8:16]))
+			headerLen = 16
+		}
+		// Compared as size > end-pos, since pos+size can overflow for a
+		// 64-bit size.
+		if size < headerLen || size > end-pos {
+			return boxes, fmt.Errorf("invalid %q box size", typ)
+		}
+
+		boxes = append(boxes, box{typ: typ, start: pos + headerLen, end: pos + size})
+		pos += size
+	}
+	return boxes, nil
+}
+
+func findBox(boxes []box, typ string) (box, bool) {
+	for _, b := range boxes {
+		if b.typ == typ {
+			return b, true
+		}
+	}
+	return box{}, false
+}
+
+func bmffDate(f *os.File) (Date, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return Date{}, err
+	}
+
+	top, _ := readBoxes(f, 0, info.Size())
+	moov, ok := findBox(top, "moov")
+	if !ok {
+		return Date{}, ErrNoDate
+	}
+	children, _ := readBoxes(f, moov.start, moov.end)
+
+	// ©day carries an explicit offset on iPhones and most recent Android
+	// phones, so it wins over the UTC-or-maybe-local mvhd value.
+	if udta, ok := findBox(children, "udta"); ok {
+		if d, ok := quicktimeDay(f, udta); ok {
+			return d, nil
+		}
+	}
+
+	mvhd, ok := findBox(children, "mvhd")
+	if !ok || mvhd.end-mvhd.start < 12 {
+		return Date{}, ErrNoDate
+	}
+	buf := make([]byte, 12)
+	if _, err := f.ReadAt(buf, mvhd.start); err != nil {
+		return Date{}, err
+	}
+
+	var secs uint64
+	if buf[0] == 1 {
+		secs = binary.BigEndian.Uint64(buf[4:12])
+	} else {
+		secs = uint64(binary.BigEndian.Uint32(buf[4:8]))
+	}
+	if secs == 0 {
+		return Date{}, ErrNoDate
+	}
+
+	t := mp4Epoch.Add(time.Duration(secs) * time.Second)
+	if !plausible(t) {
+		return Date{}, ErrNoDate
+	}
+	return Date{Time: t, AssumedUTC: true}, nil
+}
+
+func quicktimeDay(f *os.File, udta box) (Date, bool) {
+	children, _ := readBoxes(f, udta.start, udta.end)
+	if day, ok := findBox(children, "\xa9day"); ok {
+		// QuickTime prefixes the text with a 2-byte length and 2-byte language.
+		if buf, ok := readBox(f, day); ok {
+			if len(buf) > 4 && int(binary.BigEndian.Uint16(buf[0:2])) == len(buf)-4 {
+				buf = buf[4:]
+			}
+			return parseDate(string(buf))
+		}
+	}
+
+	// iTunes-style metadata (m4a) nests the value in meta/ilst/©day/data.
+	meta, ok := findBox(children, "meta")
+	if !ok {
+		return Date{}, false
+	}
+	metaChildren, _ := readBoxes(f, meta.start+4, meta.end)
+	if _, ok := findBox(metaChildren, "ilst"); !ok {
+		metaChildren, _ = readBoxes(f, meta.start, meta.end)
+	}
+	ilst, ok := findBox(metaChildren, "ilst")
+	if !ok {
+		return Date{}, false
+	}
+	items, _ := readBoxes(f, ilst.start, ilst.end)
+	day, ok := findBox(items, "\xa9day")
+	if !ok {
+		return Date{}, false
+	}
+	values, _ := readBoxes(f, day.start, day.end)
+	data, ok := findBox(values, "data")
+	if !ok {
+		return Date{}, false
+	}
+	buf, ok := readBox(f, data)
+	if !ok || len(buf) < 8 {
+		return Date{}, false
+	}
+	return parseDate(string(buf[8:]))
+}
+
+func readBox(f *os.File, b box) ([]byte, bool) {
+	if b.end-b.start > 256 || b.end < b.start {
+		return nil, false
+	}
+	buf := make([]byte, b.end-b.start)
+	if _, err := f.ReadAt(buf, b.start); err != nil {
+		return nil, false
+	}
+	return buf, true
+}
+
+// ---- Matroska / WebM ----
+
+const (
+	ebmlSegment = 0x18538067
+	ebmlInfo    = 0x1549A966
+	ebmlDateUTC = 0x4461
+	ebmlCluster = 0x1F43B675
+)
+
+type ebmlReader struct {
+	r   io.ReaderAt
+	pos int64
+}
+
+// vint reads an EBML variable-length integer. IDs keep their length marker,
+// sizes drop it and report whether all value bits are set ("unknown size").
+func (e *ebmlReader) vint(keepMarker bool) (uint64, bool, error) {
+	first := make([]byte, 1)
+	if _, err := e.r.ReadAt(first, e.pos); err != nil {
+		return 0, false, err
+	}
+	length := 1
+	for mask := byte(0x80); length <= 8 && first[0]&mask == 0; mask >>= 1 {
+		length++
+	}
+	if length > 8 {
+		return 0, false, fmt.Errorf("invalid EBML vint")
+	}
+
+	buf := make([]byte, length)
+	if _, err := e.r.ReadAt(buf, e.pos); err != nil {
+		return 0, false, err
+	}
+	e.pos += int64(length)
+
+	if !keepMarker {
+		buf[0] &^= 0x80 >> (length - 1)
+	}
+	var v uint64
+	for _, b := range buf {
+		v = v<<8 | uint64(b)
+	}
+	if keepMarker {
+		return v, false, nil
+	}
+	return v, v == 1<<(7*length)-1, nil
+}
+
+func (e *ebmlReader) element() (id uint64, size int64, unknown bool, err error) {
+	id, _, err = e.vint(true)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	s, unknown, err := e.vint(false)
+	return id, int64(s), unknown, err
+}
+
+func matroskaDate(f *os.File) (Date, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return Date{}, err
+	}
+	e := &ebmlReader{r: f}
+
+	// Skip the EBML header to reach the Segment.
+	_, size, _, err := e.element()
+	if err != nil {
+		return Date{}, err
+	}
+	e.pos += size
+
+	id, size, unknown, err := e.element()
+	if err != nil || id != ebmlSegment {
+		return Date{}, ErrNoDate
+	}
+	segEnd := info.Size()
+	if !unknown && e.pos+size < segEnd {
+		segEnd = e.pos + size
+	}
+
+	for e.pos < segEnd {
+		id, size, unknown, err := e.element()
+		if err != nil || unknown || id == ebmlCluster {
+			break
+		}
+		if id != ebmlInfo {
+			e.pos += size
+			continue
+		}
+
+		infoEnd := e.pos + size
+		for e.pos < infoEnd {
+			cid, csize, _, err := e.element()
+			if err != nil {
+				return Date{}, err
+			}
+			if cid == ebmlDateUTC && csize == 8 {
+				buf := make([]byte, 8)
+				if _, err := f.ReadAt(buf, e.pos); err != nil {
+					return Date{}, err
+				}
+				ns := int64(binary.BigEndian.Uint64(buf))
+				t := matroskaEpoch.Add(time.Duration(ns))
+				if ns == 0 || !plausible(t) {
+					return Date{}, ErrNoDate
+				}
+				return Date{Time: t, AssumedUTC: true}, nil
+			}
+			e.pos += csize
+		}
+		break
+	}
+	return Date{}, ErrNoDate
+}
+
+// ---- ID3v2 (mp3) ----
+
+func id3Date(f *os.File) (Date, error) {
+	header := make([]byte, 10)
+	if _, err := io.ReadFull(f, header); err != nil {
+		return Date{}, err
+	}
+	major := header[3]
+	flags := header[5]
+	size := syncsafe(header[6:10])
+	if size > maxTagBytes {
+		return Date{}, fmt.Errorf("ID3 tag too large")
+	}
+
+	tag := make([]byte, size)
+	if _, err := io.ReadFull(f, tag); err != nil {
+		return Date{}, err
+	}
+	if flags&0x80 != 0 && major < 4 {
+		tag = bytes.ReplaceAll(tag, []byte{0xFF, 0x00}, []byte{0xFF})
+	}
+	if flags&0x40 != 0 && len(tag) >= 4 {
+		extSize := int(binary.BigEndian.Uint32(tag[0:4]))
+		if major == 4 {
+			extSize = int(syncsafe(tag[0:4]))
+		} else {
+			extSize += 4
+		}
+		if extSize > len(tag) {
+			return Date{}, ErrNoDate
+		}
+		tag = tag[extSize:]
+	}
+
+	frames := id3Frames(tag, major)
+
+	for _, id := range []string{"TDRC", "TDOR", "TDEN"} {
+		if d, ok := parseDate(frames[id]); ok {
+			return d, nil
+		}
+	}
+
+	// ID3v2.3 and v2.2 split the date into year, DDMM and HHMM frames.
+	year := firstNonEmpty(frames["TYER"], frames["TYE"])
+	ddmm := firstNonEmpty(frames["TDAT"], frames["TDA"])
+	hhmm := firstNonEmpty(frames["TIME"], frames["TIM"])
+	if len(year) == 4 && len(ddmm) == 4 {
+		s := fmt.Sprintf("%s-%s-%s", year, ddmm[2:4], ddmm[0:2])
+		if len(hhmm) == 4 {
+			s += fmt.Sprintf("T%s:%s:00", hhmm[0:2], hhmm[2:4])
+		}
+		if d, ok := parseDate(s); ok {
+			return d, nil
+		}
+	}
+	return Date{}, ErrNoDate
+}
+
+func id3Frames(tag []byte, major byte) map[string]string {
+	frames := make(map[string]string)
+	idLen, headerLen := 4, 10
+	if major == 2 {
+		idLen, headerLen = 3, 6
+	}
+
+	for pos := 0; pos+headerLen <= len(tag); {
+		id := string(tag[pos : pos+idLen])
+		if id[0] == 0 {
+			break
+		}
+
+		var size int
+		switch major {
+		case 2:
+			size = int(tag[pos+3])<<16 | int(tag[pos+4])<<8 | int(tag[pos+5])
+		case 4:
+			size = int(syncsafe(tag[pos+4 : pos+8]))
+		default:
+			size = int(binary.BigEndian.Uint32(tag[pos+4 : pos+8]))
+		}
+		pos += headerLen
+		if size <= 0 || pos+size > len(tag) {
+			break
+		}
+		if id[0] == 'T' {
+			frames[id] = decodeID3Text(tag[pos : pos+size])
+		}
+		pos += size
+	}
+	return frames
+}
+
+func decodeID3Text(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	enc, data := data[0], data[1:]
+	switch enc {
+	case 1, 2:
+		bigEndian := enc == 2
+		if len(data) >= 2 {
+			switch {
+			case data[0] == 0xFF && data[1] == 0xFE:
+				bigEndian, data = false, data[2:]
+			case data[0] == 0xFE && data[1] == 0xFF:
+				bigEndian, data = true, data[2:]
+			}
+		}
+		units := make([]uint16, 0, len(data)/2)
+		for i := 0; i+1 < len(data); i += 2 {
+			if bigEndian {
+				units = append(units, binary.BigEndian.Uint16(data[i:]))
+			} else {
+				units = append(units, binary.LittleEndian.Uint16(data[i:]))
+			}
+		}
+		return strings.TrimRight(string(utf16.Decode(units)), "\x00")
+	case 0:
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		return strings.TrimRight(string(runes), "\x00")
+	default:
+		return strings.TrimRight(string(data), "\x00")
+	}
+}
+
+func syncsafe(b []byte) uint32 {
+	return uint32(b[0]&0x7F)<<21 | uint32(b[1]&0x7F)<<14 | uint32(b[2]&0x7F)<<7 | uint32(b[3]&0x7F)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// ---- Vorbis comments (FLAC, Ogg Vorbis, Opus) ----
+
+var vorbisDateKeys = []string{"DATE", "ORIGINALDATE", "CREATION_TIME", "YEAR"}
+
+func flacDate(f *os.File) (Date, error) {
+	if _, err := f.Seek(4, io.SeekStart); err != nil {
+		return Date{}, err
+	}
+	header := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(f, header); err != nil {
+			return Date{}, err
+		}
+		last := header[0]&0x80 != 0
+		blockType := header[0] & 0x7F
+		length := int64(header[1])<<16 | int64(header[2])<<8 | int64(header[3])
+
+		if blockType == 4 {
+			if length > maxTagBytes {
+				return Date{}, fmt.Errorf("FLAC comment block too large")
+			}
+			block := make([]byte, length)
+			if _, err := io.ReadFull(f, block); err != nil {
+				return Date{}, err
+			}
+			return vorbisCommentDate(block)
+		}
+		if last {
+			return Date{}, ErrNoDate
+		}
+		if _, err := f.Seek(length, io.SeekCurrent); err != nil {
+			return Date{}, err
+		}
+	}
+}
+
+// oggDate looks for the comment header in the first pages. Comment packets
+// that span pages (large embedded cover art) are cut short, which is fine
+// as long as the date comes before the picture.
+func oggDate(f *os.File) (Date, error) {
+	data, err := io.ReadAll(io.LimitReader(f, 64<<10))
+	if err != nil {
+		return Date{}, err
+	}
+	for _, marker := range []string{"\x03vorbis", "OpusTags"} {
+		if i := bytes.Index(data, []byte(marker)); i >= 0 {
+			return vorbisCommentDate(data[i+len(marker):])
+		}
+	}
+	return Date{}, ErrNoDate
+}
+
+func vorbisCommentDate(block []byte) (Date, error) {
+	pos := 0
+	readUint32 := func() (int, bool) {
+		if pos+4 > len(block) {
+			return 0, false
+		}
+		n := int(binary.LittleEndian.Uint32(block[pos:]))
+		pos += 4
+		return n, true
+	}
+
+	vendorLen, ok := readUint32()
+	if !ok || pos+vendorLen > len(block) {
+		return Date{}, ErrNoDate
+	}
+	pos += vendorLen
+	count, ok := readUint32()
+	if !ok {
+		return Date{}, ErrNoDate
+	}
+
+	comments := make(map[string]string)
+	for i := 0; i < count; i++ {
+		n, ok := readUint32()
+		if !ok || pos+n > len(block) {
+			break
+		}
+		key, value, found := strings.Cut(string(block[pos:pos+n]), "=")
+		pos += n
+		if found {
+			comments[strings.ToUpper(key)] = value
+		}
+	}
+
+	for _, key := range vorbisDateKeys {
+		if d, ok := parseDate(comments[key]); ok {
+			return d, nil
+		}
+	}
+	return Date{}, ErrNoDate
+}
@@ -0,0 +1,186 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func mp4Box(typ string, payload ...[]byte) []byte {
+	body := bytes.Join(payload, nil)
+	buf := make([]byte, 8, 8+len(body))
+	binary.BigEndian.PutUint32(buf, uint32(8+len(body)))
+	copy(buf[4:], typ)
+	return append(buf, body...)
+}
+
+func mvhd(t time.Time) []byte {
+	payload := make([]byte, 100)
+	binary.BigEndian.PutUint32(payload[4:], uint32(t.Sub(mp4Epoch)/time.Second))
+	return mp4Box("mvhd", payload)
+}
+
+func ebml(id []byte, payload []byte) []byte {
+	size := []byte{0x01, 0, 0, 0, 0, 0, 0, byte(len(payload))}
+	out := append(append([]byte{}, id...), size...)
+	return append(out, payload...)
+}
+
+func vorbisBlock(comments ...string) []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, uint32(6))
+	buf.WriteString("vendor")
+	binary.Write(&buf, binary.LittleEndian, uint32(len(comments)))
+	for _, c := range comments {
+		binary.Write(&buf, binary.LittleEndian, uint32(len(c)))
+		buf.WriteString(c)
+	}
+	return buf.Bytes()
+}
+
+func id3Tag(major byte, frames ...[]byte) []byte {
+	body := bytes.Join(frames, nil)
+	n := len(body)
+	header := []byte{'I', 'D', '3', major, 0, 0,
+		byte(n >> 21 & 0x7F), byte(n >> 14 & 0x7F), byte(n >> 7 & 0x7F), byte(n & 0x7F)}
+	return append(header, body...)
+}
+
+func id3Frame(id, text string) []byte {
+	payload := append([]byte{3}, text...)
+	frame := append([]byte(id), 0, 0, 0, byte(len(payload)), 0, 0)
+	return append(frame, payload...)
+}
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// TestMediaDate verifies dates are read from each supported container
+func TestMediaDate(t *testing.T) {
+	recorded := time.Date(2024, 3, 12, 10, 15, 0, 0, time.UTC)
+
+	dayPayload := append([]byte{0, 24, 0x15, 0xC7}, "2024-03-12T11:15:00+0100"...)
+	flacHeader := []byte{0x84, 0, 0, 0}
+	comments := vorbisBlock("TITLE=Song", "DATE=2024-03-12")
+	flacHeader[3] = byte(len(comments))
+
+	segmentInfo := ebml([]byte{0x15, 0x49, 0xA9, 0x66},
+		append([]byte{0x44, 0x61, 0x88}, binary.BigEndian.AppendUint64(nil, uint64(recorded.Sub(matroskaEpoch)))...))
+
+	tests := []struct {
+		name       string
+		data       []byte
+		expected   time.Time
+		floating   bool
+		assumedUTC bool
+	}{
+		{
+			name:       "clip.mp4",
+			data:       append(mp4Box("ftyp", []byte("isom\x00\x00\x00\x00")), mp4Box("moov", mvhd(recorded))...),
+			expected:   recorded,
+			assumedUTC: true,
+		},
+		{
+			name:     "clip.mov",
+			data:     append(mp4Box("ftyp", []byte("qt  \x00\x00\x00\x00")), mp4Box("moov", mvhd(recorded), mp4Box("udta", mp4Box("\xa9day", dayPayload)))...),
+			expected: recorded,
+		},
+		{
+			name:       "clip.mkv",
+			data:       append(ebml([]byte{0x1A, 0x45, 0xDF, 0xA3}, []byte{0x42, 0x86, 0x81, 0x01}), ebml([]byte{0x18, 0x53, 0x80, 0x67}, segmentInfo)...),
+			expected:   recorded,
+			assumedUTC: true,
+		},
+		{
+			name:     "song-v24.mp3",
+			data:     id3Tag(4, id3Frame("TIT2", "Song"), id3Frame("TDRC", "2024-03-12T10:15:00")),
+			expected: recorded,
+			floating: true,
+		},
+		{
+			name:     "song-v23.mp3",
+			data:     id3Tag(3, id3Frame("TYER", "2024"), id3Frame("TDAT", "1203"), id3Frame("TIME", "1015")),
+			expected: recorded,
+			floating: true,
+		},
+		{
+			name:     "song.flac",
+			data:     append(append([]byte("fLaC"), flacHeader...), comments...),
+			expected: time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC),
+			floating: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := MediaDate(writeFile(t, tt.name, tt.data))
+			if err != nil {
+				t.Fatalf("MediaDate failed: %v", err)
+			}
+			if !d.Time.Equal(tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, d.Time)
+			}
+			if d.Floating != tt.floating {
+				t.Errorf("Expected floating=%v, got %v", tt.floating, d.Floating)
+			}
+			if d.AssumedUTC != tt.assumedUTC {
+				t.Errorf("Expected assumedUTC=%v, got %v", tt.assumedUTC, d.AssumedUTC)
+			}
+		})
+	}
+}
+
+// TestMediaDateRejectsEpoch verifies zeroed and year-only dates are ignored
+func TestMediaDateRejectsEpoch(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"zero.mp4", append(mp4Box("ftyp", []byte("isom\x00\x00\x00\x00")), mp4Box("moov", mp4Box("mvhd", make([]byte, 100)))...)},
+		{"year.mp3", id3Tag(4, id3Frame("TDRC", "2024"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := MediaDate(writeFile(t, tt.name, tt.data)); err != ErrNoDate {
+				t.Errorf("Expected ErrNoDate, got %v", err)
+			}
+		})
+	}
+}
+
+// TestReadBoxesOversized verifies a 64-bit box size running past the end
+// of the file is rejected rather than overflowing
+func TestReadBoxesOversized(t *testing.T) {
+	huge := make([]byte, 16)
+	binary.BigEndian.PutUint32(huge, 1)
+	copy(huge[4:], "\xa9day")
+	binary.BigEndian.PutUint64(huge[8:], 1<<63-1)
+	data := append(mp4Box("free"), huge...)
+
+	boxes, err := readBoxes(bytes.NewReader(data), 0, int64(len(data)))
+	if err == nil || len(boxes) != 1 {
+		t.Errorf("Expected only the first box and an error, got %+v, %v", boxes, err)
+	}
+
+	udta := append(mp4Box("ftyp", []byte("isom\x00\x00\x00\x00")), mp4Box("moov", mp4Box("udta", huge))...)
+	if _, err := MediaDate(writeFile(t, "huge.mp4", udta)); err != ErrNoDate {
+		t.Errorf("Expected ErrNoDate, got %v", err)
+	}
+}
+
+// TestMediaDateUnsupported verifies non-media files return an error
+func TestMediaDateUnsupported(t *testing.T) {
+	if _, err := MediaDate(writeFile(t, "notes.txt", []byte("hello"))); err == nil {
+		t.Error("Expected error for unsupported file")
+	}
+}
@@ -0,0 +1,75 @@
+package metadata
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrNoDate = errors.New("no date found")
+
+// Date is a timestamp read from a file's embedded metadata.
+type Date struct {
+	Time time.Time
+	// Floating is set when the source stores a wall-clock time without a
+	// zone. Time then carries that wall clock in UTC and must not be
+	// converted to another location.
+	Floating bool
+	// AssumedUTC is set when the format defines the value as UTC but many
+	// devices write local time into it anyway (MP4 mvhd, Matroska DateUTC).
+	AssumedUTC bool
+}
+
+var (
+	minPlausible = time.Date(1971, 1, 1, 0, 0, 0, 0, time.UTC)
+	now          = time.Now
+)
+
+// plausible rejects zeroed epoch values and dates in the future, both of
+// which are common in files written by misconfigured devices.
+func plausible(t time.Time) bool {
+	return !t.Before(minPlausible) && !t.After(now().Add(24*time.Hour))
+}
+
+var zonedLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05 -0700",
+}
+
+var floatingLayouts = []string{
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02",
+	"2006:01:02 15:04:05",
+	"2006-01",
+}
+
+// parseDate accepts the ISO 8601 variants found in media tags and document
+// properties. Year-only values are rejected since they cannot place a file
+// in a month folder.
+func parseDate(s string) (Date, bool) {
+	s = strings.TrimSpace(strings.TrimRight(s, "\x00"))
+	if s == "" {
+		return Date{}, false
+	}
+
+	for _, layout := range zonedLayouts {
+		if t, err := time.Parse(layout, s); err == nil && plausible(t) {
+			return Date{Time: t}, true
+		}
+	}
+	for _, layout := range floatingLayouts {
+		if t, err := time.Parse(layout, s); err == nil && plausible(t) {
+			return Date{Time: t, Floating: true}, true
+		}
+	}
+	return Date{}, false
+}
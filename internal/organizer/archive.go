@@ -0,0 +1,439 @@
+package organizer
+
+import (
+	"archive/zip"
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ArchiveExt is the extension of month archives. They sit beside the month
+// folder they replace, as "2019/03-March.zip", with the archive's SHA-256
+// in "2019/03-March.zip.sha256". Zip is the only format written or read.
+const ArchiveExt = ".zip"
+
+// ManifestName is the manifest's name inside every archive.
+const ManifestName = ".declutter-manifest.json"
+
+// ArchiveManifest lists what an archive holds, so it can be verified before
+// the originals are removed and again before it is restored.
+type ArchiveManifest struct {
+	Folder  string         `json:"folder"`
+	Created time.Time      `json:"created"`
+	Files   []ArchivedFile `json:"files"`
+}
+
+type ArchivedFile struct {
+	// Path is relative to the month folder, with forward slashes.
+	Path    string    `json:"path"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+	SHA256  string    `json:"sha256"`
+}
+
+// MonthFolder is a Year/Month folder of the organized tree.
+type MonthFolder struct {
+	Path string
+	// Rel is Path relative to the source directory, as "2019/03-March".
+	Rel   string
+	Year  int
+	Month time.Month
+}
+
+// ArchiveCutoff is the start of the month years before now. Months before
+// it are old enough to archive.
+func ArchiveCutoff(now time.Time, years int) time.Time {
+	return time.Date(now.Year()-years, now.Month(), 1, 0, 0, 0, 0, now.Location())
+}
+
+// ArchiveCandidates lists the month folders whose month ended before
+// cutoff, oldest first.
+func (o *Organizer) ArchiveCandidates(cutoff time.Time) ([]MonthFolder, error) {
+	years, err := os.ReadDir(o.sourceDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	var months []MonthFolder
+	for _, y := range years {
+		if !y.IsDir() || !IsYearFolder(y.Name()) {
+			continue
+		}
+		year, _ := strconv.Atoi(y.Name())
+		entries, err := os.ReadDir(filepath.Join(o.sourceDir, y.Name()))
+		if err != nil {
+			o.log(fmt.Sprintf("Warning: Could not read %s: %v", y.Name(), err))
+			continue
+		}
+		for _, m := range entries {
+			month, ok := IsMonthFolder(m.Name())
+			if !m.IsDir() || !ok {
+				continue
+			}
+			if !time.Date(year, month+1, 1, 0, 0, 0, 0, cutoff.Location()).After(cutoff) {
+				months = append(months, MonthFolder{
+					Path:  filepath.Join(o.sourceDir, y.Name(), m.Name()),
+					Rel:   y.Name() + "/" + m.Name(),
+					Year:  year,
+					Month: month,
+				})
+			}
+		}
+	}
+	sort.Slice(months, func(i, j int) bool {
+		if months[i].Year != months[j].Year {
+			return months[i].Year < months[j].Year
+		}
+		return months[i].Month < months[j].Month
+	})
+	return months, nil
+}
+
+// Archive packs each month folder into an archive, verifies it by reading
+// it back and only then deletes the folder. The folder never goes to the
+// trash, where it would keep taking the space the archive was to free. A
+// month that fails is logged and left as it was.
+func (o *Organizer) Archive(months []MonthFolder) (int, error) {
+	l, err := o.lock(o.sourceDir)
+	if err != nil {
+		return 0, err
+	}
+	defer l.unlock()
+	archived := 0
+	for _, m := range months {
+		if o.cancelled.Load() {
+			o.log("Cancelled")
+			return archived, ErrCancelled
+		}
+		archivePath, err := o.archiveMonth(m)
+		if err != nil {
+			o.log(fmt.Sprintf("Error archiving %s: %v", m.Rel, err))
+			continue
+		}
+		o.log(fmt.Sprintf("Archived: %s → %s", m.Rel, filepath.Base(archivePath)))
+		archived++
+	}
+	return archived, nil
+}
+
+func (o *Organizer) archiveMonth(m MonthFolder) (string, error) {
+	archivePath := m.Path + ArchiveExt
+	if _, err := os.Lstat(archivePath); err == nil {
+		return "", fmt.Errorf("%s already exists", filepath.Base(archivePath))
+	}
+
+	manifest := ArchiveManifest{Folder: m.Rel, Created: time.Now().UTC()}
+	err := filepath.WalkDir(m.Path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return fmt.Errorf("%s is not a regular file", p)
+		}
+		rel, _ := filepath.Rel(m.Path, p)
+		manifest.Files = append(manifest.Files, ArchivedFile{Path: filepath.ToSlash(rel)})
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(manifest.Files) == 0 {
+		return "", errors.New("the folder is empty")
+	}
+
+	tmp := archivePath + ".tmp"
+	sum, err := writeArchive(tmp, m.Path, &manifest)
+	if err == nil {
+		_, err = verifyArchive(tmp, sum)
+	}
+	if err == nil {
+		err = os.Rename(tmp, archivePath)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	if err := writeChecksum(archivePath, sum); err != nil {
+		os.Remove(archivePath)
+		return "", err
+	}
+
+	if err := os.RemoveAll(m.Path); err != nil {
+		return archivePath, fmt.Errorf("archive written but the folder could not be removed: %w", err)
+	}
+	return archivePath, nil
+}
+
+// writeArchive writes the files listed in manifest, filling in their
+// sizes, times and hashes, then the manifest itself. It returns the
+// archive's SHA-256.
+func writeArchive(archivePath, dir string, manifest *ArchiveManifest) (string, error) {
+	f, err := os.OpenFile(archivePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	buf := bufio.NewWriter(io.MultiWriter(f, hash))
+	zw := zip.NewWriter(buf)
+	for i := range manifest.Files {
+		if err := addFile(zw, dir, &manifest.Files[i]); err != nil {
+			return "", err
+		}
+	}
+
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: ManifestName, Method: zip.Deflate, Modified: manifest.Created})
+	if err != nil {
+		return "", err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return "", err
+	}
+	if err := zw.Close(); err != nil {
+		return "", err
+	}
+	if err := buf.Flush(); err != nil {
+		return "", err
+	}
+	if err := f.Sync(); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), f.Close()
+}
+
+func addFile(zw *zip.Writer, dir string, file *ArchivedFile) error {
+	src, err := os.Open(filepath.Join(dir, filepath.FromSlash(file.Path)))
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = file.Path
+	header.Method = zip.Deflate
+	w, err := zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(w, hash), src); err != nil {
+		return err
+	}
+	file.Size = info.Size()
+	file.ModTime = info.ModTime().UTC()
+	file.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	return nil
+}
+
+// writeChecksum writes the archive's hash in the format of sha256sum.
+func writeChecksum(archivePath, sum string) error {
+	line := fmt.Sprintf("%s  %s\n", sum, filepath.Base(archivePath))
+	return os.WriteFile(archivePath+".sha256", []byte(line), 0644)
+}
+
+func readChecksum(archivePath string) (string, error) {
+	data, err := os.ReadFile(archivePath + ".sha256")
+	if err != nil {
+		return "", err
+	}
+	sum, _, _ := strings.Cut(strings.TrimSpace(string(data)), " ")
+	return sum, nil
+}
+
+// VerifyArchive checks an archive against its checksum file, when there is
+// one, and every file in it against the manifest.
+func VerifyArchive(archivePath string) (*ArchiveManifest, error) {
+	sum, err := readChecksum(archivePath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return verifyArchive(archivePath, sum)
+}
+
+func verifyArchive(archivePath, sum string) (*ArchiveManifest, error) {
+	if sum != "" {
+		actual, err := hashFile(archivePath)
+		if err != nil {
+			return nil, err
+		}
+		if actual != sum {
+			return nil, fmt.Errorf("%s does not match its checksum", filepath.Base(archivePath))
+		}
+	}
+
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	manifest, err := readManifest(&zr.Reader)
+	if err != nil {
+		return nil, err
+	}
+	entries := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		entries[f.Name] = f
+	}
+	for _, file := range manifest.Files {
+		if !safeArchivePath(file.Path) {
+			return nil, fmt.Errorf("unsafe path %q in the manifest", file.Path)
+		}
+		f, ok := entries[file.Path]
+		if !ok {
+			return nil, fmt.Errorf("%s is missing from the archive", file.Path)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		hash := sha256.New()
+		n, err := io.Copy(hash, rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Path, err)
+		}
+		if n != file.Size || hex.EncodeToString(hash.Sum(nil)) != file.SHA256 {
+			return nil, fmt.Errorf("%s does not match the manifest", file.Path)
+		}
+	}
+	return manifest, nil
+}
+
+func readManifest(zr *zip.Reader) (*ArchiveManifest, error) {
+	rc, err := zr.Open(ManifestName)
+	if err != nil {
+		return nil, fmt.Errorf("no manifest: %w", err)
+	}
+	defer rc.Close()
+	var manifest ArchiveManifest
+	if err := json.NewDecoder(rc).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("reading the manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// safeArchivePath rejects names that would land outside the month folder.
+func safeArchivePath(name string) bool {
+	return name != "" && fs.ValidPath(name) && name != ManifestName
+}
+
+func hashFile(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// RestoreArchive verifies an archive and unpacks it back into its month
+// folder with the original modification times. Files already in the
+// folder are kept and logged; the archive and its checksum file are only
+// removed once every file is back.
+func (o *Organizer) RestoreArchive(archivePath string) (restored, skipped int, err error) {
+	if !strings.HasSuffix(archivePath, ArchiveExt) {
+		return 0, 0, fmt.Errorf("%s is not a %s archive", filepath.Base(archivePath), ArchiveExt)
+	}
+	manifest, err := VerifyArchive(archivePath)
+	if err != nil {
+		return 0, 0, err
+	}
+	dir := strings.TrimSuffix(archivePath, ArchiveExt)
+	// The month folder sits in a year folder of the organized folder.
+	l, err := o.lock(filepath.Dir(filepath.Dir(dir)))
+	if err != nil {
+		return 0, 0, err
+	}
+	defer l.unlock()
+
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return 0, 0, err
+	}
+	entries := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		entries[f.Name] = f
+	}
+	for _, file := range manifest.Files {
+		dest := filepath.Join(dir, filepath.FromSlash(file.Path))
+		if _, err := os.Lstat(dest); err == nil {
+			o.log(fmt.Sprintf("Kept (already exists): %s", file.Path))
+			skipped++
+			continue
+		}
+		if err := extractFile(entries[file.Path], dest, file.ModTime); err != nil {
+			zr.Close()
+			return restored, skipped, fmt.Errorf("%s: %w", file.Path, err)
+		}
+		restored++
+	}
+	zr.Close()
+
+	if skipped == 0 {
+		if err := o.remove(archivePath); err != nil {
+			return restored, skipped, err
+		}
+		if err := os.Remove(archivePath + ".sha256"); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return restored, skipped, err
+		}
+	}
+	o.log(fmt.Sprintf("Restored %d files from %s", restored, filepath.Base(archivePath)))
+	return restored, skipped, nil
+}
+
+func extractFile(f *zip.File, dest string, modTime time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	tmp := dest + partSuffix
+	out, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, rc)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chtimes(tmp, modTime, modTime)
+	}
+	if err == nil {
+		err = os.Rename(tmp, dest)
+	}
+	if err != nil {
+		os.Remove(tmp)
+	}
+	return err
+}
@@ -0,0 +1,153 @@
+package organizer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeMonth(t *testing.T, dir string, files map[string]time.Time) {
+	t.Helper()
+	for name, modTime := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestArchiveCandidates verifies only months that ended before the cutoff
+// are listed, oldest first
+func TestArchiveCandidates(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, dir := range []string{"2020/11-November", "2019/03-March", "2021/01-January", "2019/notes", "misc/01-January"} {
+		if err := os.MkdirAll(filepath.Join(tmpDir, filepath.FromSlash(dir)), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cutoff := ArchiveCutoff(time.Date(2023, 1, 20, 0, 0, 0, 0, time.UTC), 2)
+	months, err := New(tmpDir, nil).ArchiveCandidates(cutoff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(months) != 2 || months[0].Rel != "2019/03-March" || months[1].Rel != "2020/11-November" {
+		t.Errorf("Expected 2019/03-March and 2020/11-November, got %+v", months)
+	}
+}
+
+// TestArchiveAndRestore verifies a month is packed, verified and deleted
+// rather than trashed, and comes back with its modification times
+func TestArchiveAndRestore(t *testing.T) {
+	tmpDir := t.TempDir()
+	month := filepath.Join(tmpDir, "2019", "03-March")
+	modTime := time.Date(2019, 3, 15, 12, 0, 0, 0, time.UTC)
+	writeMonth(t, month, map[string]time.Time{"a.jpg": modTime, "raw/b.cr2": modTime})
+
+	trashDir := t.TempDir()
+	org := NewWithOptions(tmpDir, Options{Trash: dirTrash{trashDir}}, nil)
+	months, _ := org.ArchiveCandidates(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	if archived, err := org.Archive(months); err != nil || archived != 1 {
+		t.Fatalf("Expected 1 archived, got %d, %v", archived, err)
+	}
+	if _, err := os.Stat(month); !os.IsNotExist(err) {
+		t.Errorf("Expected the month folder to be removed, got %v", err)
+	}
+	if entries, _ := os.ReadDir(trashDir); len(entries) != 0 {
+		t.Errorf("Expected nothing in the trash, got %v", entries)
+	}
+
+	archive := month + ArchiveExt
+	manifest, err := VerifyArchive(archive)
+	if err != nil {
+		t.Fatalf("VerifyArchive failed: %v", err)
+	}
+	if manifest.Folder != "2019/03-March" || len(manifest.Files) != 2 {
+		t.Errorf("Unexpected manifest %+v", manifest)
+	}
+
+	restored, skipped, err := org.RestoreArchive(archive)
+	if err != nil || restored != 2 || skipped != 0 {
+		t.Fatalf("Expected 2 restored, got %d restored, %d skipped, %v", restored, skipped, err)
+	}
+	info, err := os.Stat(filepath.Join(month, "raw", "b.cr2"))
+	if err != nil {
+		t.Fatalf("Expected b.cr2 back: %v", err)
+	}
+	if !info.ModTime().Equal(modTime) {
+		t.Errorf("Expected mod time %v, got %v", modTime, info.ModTime())
+	}
+	for _, path := range []string{archive, archive + ".sha256"} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed, got %v", filepath.Base(path), err)
+		}
+	}
+}
+
+// TestVerifyArchiveDetectsCorruption verifies a changed archive fails its
+// checksum and is not restored
+func TestVerifyArchiveDetectsCorruption(t *testing.T) {
+	tmpDir := t.TempDir()
+	month := filepath.Join(tmpDir, "2019", "03-March")
+	writeMonth(t, month, map[string]time.Time{"a.jpg": time.Date(2019, 3, 15, 12, 0, 0, 0, time.UTC)})
+
+	org := New(tmpDir, nil)
+	if _, err := org.Archive([]MonthFolder{{Path: month, Rel: "2019/03-March"}}); err != nil {
+		t.Fatal(err)
+	}
+	archive := month + ArchiveExt
+	f, err := os.OpenFile(archive, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write([]byte("x"))
+	f.Close()
+
+	if _, err := VerifyArchive(archive); err == nil {
+		t.Error("Expected a checksum error")
+	}
+	if _, _, err := org.RestoreArchive(archive); err == nil {
+		t.Error("Expected the restore to fail")
+	}
+	if _, err := os.Stat(month); !os.IsNotExist(err) {
+		t.Errorf("Expected nothing to be restored, got %v", err)
+	}
+}
+
+// TestRestoreKeepsExistingFiles verifies files already in the folder are
+// kept and the archive stays
+func TestRestoreKeepsExistingFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	month := filepath.Join(tmpDir, "2019", "03-March")
+	modTime := time.Date(2019, 3, 15, 12, 0, 0, 0, time.UTC)
+	writeMonth(t, month, map[string]time.Time{"a.jpg": modTime, "b.jpg": modTime})
+
+	org := New(tmpDir, nil)
+	if _, err := org.Archive([]MonthFolder{{Path: month, Rel: "2019/03-March"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(month, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(month, "a.jpg"), []byte("new"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, skipped, err := org.RestoreArchive(month + ArchiveExt)
+	if err != nil || restored != 1 || skipped != 1 {
+		t.Fatalf("Expected 1 restored and 1 skipped, got %d, %d, %v", restored, skipped, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(month, "a.jpg")); string(data) != "new" {
+		t.Errorf("Expected a.jpg to be kept, got %q", data)
+	}
+	if _, err := os.Stat(month + ArchiveExt); err != nil {
+		t.Errorf("Expected the archive to stay: %v", err)
+	}
+}
@@ -0,0 +1,237 @@
+package organizer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ChecksumsName is the checksum file written to each month folder, in the
+// format of sha256sum so that "sha256sum -c SHA256SUMS" can check it too.
+const ChecksumsName = "SHA256SUMS"
+
+type ChecksumStatus string
+
+const (
+	ChecksumOK         ChecksumStatus = "ok"
+	ChecksumChanged    ChecksumStatus = "changed"
+	ChecksumMissing    ChecksumStatus = "missing"
+	ChecksumUnexpected ChecksumStatus = "unexpected"
+)
+
+// ChecksumResult is the outcome of checking one file.
+type ChecksumResult struct {
+	// Rel is the file's path relative to the source directory, with
+	// forward slashes.
+	Rel    string
+	Status ChecksumStatus
+}
+
+// recordChecksum hashes a file that was just moved and notes it for its
+// month folder's checksum file.
+func (o *Organizer) recordChecksum(sums map[string]map[string]string, dest string) {
+	sum, err := hashFile(dest)
+	if err != nil {
+		o.log(fmt.Sprintf("Warning: Could not hash %s: %v", filepath.Base(dest), err))
+		return
+	}
+	folder := filepath.Dir(dest)
+	if sums[folder] == nil {
+		sums[folder] = make(map[string]string)
+	}
+	sums[folder][filepath.Base(dest)] = sum
+}
+
+// writeChecksums merges the recorded hashes into each folder's checksum
+// file.
+func (o *Organizer) writeChecksums(sums map[string]map[string]string) {
+	for folder, set := range sums {
+		if err := updateChecksums(folder, set, nil); err != nil {
+			o.log(fmt.Sprintf("Error writing %s in %s: %v", ChecksumsName, folder, err))
+		}
+	}
+}
+
+// updateChecksums adds the set hashes to a folder's checksum file and drops
+// the named entries. The file is removed once it has no entries left.
+func updateChecksums(folder string, set map[string]string, drop []string) error {
+	path := filepath.Join(folder, ChecksumsName)
+	sums, err := readChecksums(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if sums == nil {
+		sums = make(map[string]string)
+	}
+	for name, sum := range set {
+		sums[name] = sum
+	}
+	for _, name := range drop {
+		delete(sums, name)
+	}
+
+	if len(sums) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	names := make([]string, 0, len(sums))
+	for name := range sums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		if strings.ContainsAny(name, "\\\n") {
+			// sha256sum's escaping for awkward names.
+			escaped := strings.NewReplacer("\\", "\\\\", "\n", "\\n").Replace(name)
+			fmt.Fprintf(&b, "\\%s  %s\n", sums[name], escaped)
+			continue
+		}
+		fmt.Fprintf(&b, "%s  %s\n", sums[name], name)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(b.String()), 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// readChecksums parses a checksum file into hashes keyed by file name.
+func readChecksums(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if text == "" {
+			continue
+		}
+		escaped := strings.HasPrefix(text, "\\")
+		text = strings.TrimPrefix(text, "\\")
+		sum, name, ok := strings.Cut(text, " ")
+		if !ok || len(sum) != 64 || name == "" {
+			return nil, fmt.Errorf("%s:%d: not a SHA-256 checksum line", path, line)
+		}
+		// " name" is text mode and "*name" binary; both hash the same.
+		name = name[1:]
+		if escaped {
+			name = strings.NewReplacer("\\\\", "\\", "\\n", "\n").Replace(name)
+		}
+		sums[name] = strings.ToLower(sum)
+	}
+	return sums, scanner.Err()
+}
+
+// VerifyChecksums re-hashes the files of every month folder that has a
+// checksum file. Files that no longer match are reported as changed,
+// listed files that are gone as missing, and files the checksum file
+// doesn't know about as unexpected. Month folders without one are not
+// checked.
+func (o *Organizer) VerifyChecksums() ([]ChecksumResult, error) {
+	years, err := os.ReadDir(o.sourceDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	var results []ChecksumResult
+	for _, y := range years {
+		if !y.IsDir() || !IsYearFolder(y.Name()) {
+			continue
+		}
+		months, err := os.ReadDir(filepath.Join(o.sourceDir, y.Name()))
+		if err != nil {
+			o.log(fmt.Sprintf("Warning: Could not read %s: %v", y.Name(), err))
+			continue
+		}
+		for _, m := range months {
+			if _, ok := IsMonthFolder(m.Name()); !m.IsDir() || !ok {
+				continue
+			}
+			if o.cancelled.Load() {
+				o.log("Cancelled")
+				return results, ErrCancelled
+			}
+			results = append(results, o.verifyFolder(y.Name()+"/"+m.Name())...)
+		}
+	}
+	return results, nil
+}
+
+func (o *Organizer) verifyFolder(rel string) []ChecksumResult {
+	folder := filepath.Join(o.sourceDir, filepath.FromSlash(rel))
+	sums, err := readChecksums(filepath.Join(folder, ChecksumsName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		o.log(fmt.Sprintf("Error reading %s/%s: %v", rel, ChecksumsName, err))
+		return nil
+	}
+
+	var results []ChecksumResult
+	add := func(name string, status ChecksumStatus) {
+		results = append(results, ChecksumResult{Rel: rel + "/" + name, Status: status})
+		switch status {
+		case ChecksumChanged:
+			o.log(fmt.Sprintf("Changed: %s/%s", rel, name))
+		case ChecksumMissing:
+			o.log(fmt.Sprintf("Missing: %s/%s", rel, name))
+		case ChecksumUnexpected:
+			o.log(fmt.Sprintf("Unexpected: %s/%s", rel, name))
+		}
+	}
+
+	names := make([]string, 0, len(sums))
+	for name := range sums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		sum, err := hashFile(filepath.Join(folder, name))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			add(name, ChecksumMissing)
+		case err != nil:
+			o.log(fmt.Sprintf("Error reading %s/%s: %v", rel, name, err))
+			add(name, ChecksumChanged)
+		case sum != sums[name]:
+			add(name, ChecksumChanged)
+		default:
+			add(name, ChecksumOK)
+		}
+	}
+
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		o.log(fmt.Sprintf("Warning: Could not read %s: %v", rel, err))
+		return results
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || name == ChecksumsName {
+			continue
+		}
+		if _, ok := sums[name]; !ok {
+			add(name, ChecksumUnexpected)
+		}
+	}
+	return results
+}
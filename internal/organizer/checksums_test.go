@@ -0,0 +1,116 @@
+package organizer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sha256 of "a"
+const sumA = "ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb"
+
+// TestExecuteWritesChecksums verifies moved files are recorded in their
+// month folder's SHA256SUMS, merged with earlier runs
+func TestExecuteWritesChecksums(t *testing.T) {
+	tmpDir := t.TempDir()
+	march := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	writeFileAt(t, filepath.Join(tmpDir, "a.txt"), []byte("a"), march)
+
+	runAndCollect(t, NewWithOptions(tmpDir, Options{Checksums: true}, nil))
+	writeFileAt(t, filepath.Join(tmpDir, "b.txt"), []byte("b"), march)
+	runAndCollect(t, NewWithOptions(tmpDir, Options{Checksums: true}, nil))
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "2024", "03-March", ChecksumsName))
+	if err != nil {
+		t.Fatalf("Expected a checksum file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || lines[0] != sumA+"  a.txt" || !strings.HasSuffix(lines[1], "  b.txt") {
+		t.Errorf("Unexpected checksum file %q", data)
+	}
+}
+
+// TestVerifyChecksums verifies changed, missing and unexpected files are
+// reported
+func TestVerifyChecksums(t *testing.T) {
+	tmpDir := t.TempDir()
+	march := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		writeFileAt(t, filepath.Join(tmpDir, name), []byte(name), march)
+	}
+	org := NewWithOptions(tmpDir, Options{Checksums: true}, nil)
+	runAndCollect(t, org)
+
+	month := filepath.Join(tmpDir, "2024", "03-March")
+	if err := os.WriteFile(filepath.Join(month, "b.txt"), []byte("changed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(month, "c.txt")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(month, "d.txt"), []byte("d"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := org.VerifyChecksums()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]ChecksumStatus{
+		"2024/03-March/a.txt": ChecksumOK,
+		"2024/03-March/b.txt": ChecksumChanged,
+		"2024/03-March/c.txt": ChecksumMissing,
+		"2024/03-March/d.txt": ChecksumUnexpected,
+	}
+	if len(results) != len(want) {
+		t.Fatalf("Expected %d results, got %+v", len(want), results)
+	}
+	for _, r := range results {
+		if want[r.Rel] != r.Status {
+			t.Errorf("%s: expected %s, got %s", r.Rel, want[r.Rel], r.Status)
+		}
+	}
+}
+
+// TestUndoDropsChecksums verifies undo removes restored files from the
+// checksum file, so the emptied month folder goes too
+func TestUndoDropsChecksums(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileAt(t, filepath.Join(tmpDir, "a.txt"), []byte("a"), time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+
+	org := NewWithOptions(tmpDir, Options{Checksums: true}, nil)
+	results := runAndCollect(t, org)
+	if restored, failed := org.Undo(results); restored != 1 || failed != 0 {
+		t.Fatalf("Expected 1 restored, got %d restored and %d failed", restored, failed)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "2024")); !os.IsNotExist(err) {
+		t.Errorf("Expected the year folder to be removed, got %v", err)
+	}
+}
+
+// TestChecksumsEscaping verifies names are written and read back the way
+// sha256sum escapes them
+func TestChecksumsEscaping(t *testing.T) {
+	tmpDir := t.TempDir()
+	names := map[string]string{"plain.txt": sumA, `back\slash.txt`: sumA}
+	if err := updateChecksums(tmpDir, names, nil); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(filepath.Join(tmpDir, ChecksumsName))
+	if !strings.Contains(string(data), `\`+sumA+`  back\\slash.txt`) {
+		t.Errorf("Expected an escaped line, got %q", data)
+	}
+
+	sums, err := readChecksums(filepath.Join(tmpDir, ChecksumsName))
+	if err != nil || len(sums) != 2 || sums[`back\slash.txt`] != sumA {
+		t.Errorf("Unexpected round trip %v, %v", sums, err)
+	}
+	if err := updateChecksums(tmpDir, nil, []string{"plain.txt", `back\slash.txt`}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, ChecksumsName)); !os.IsNotExist(err) {
+		t.Errorf("Expected the empty checksum file to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package organizer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultJunkPatterns are the file name patterns removed as junk when no
+// others are configured: thumbnail caches and folder settings left by
+// Windows and macOS.
+var DefaultJunkPatterns = []string{"Thumbs.db", "ehthumbs.db", "desktop.ini", ".DS_Store", "._*"}
+
+type CleanupOptions struct {
+	EmptyDirs  bool
+	EmptyFiles bool
+	Junk       bool
+	// JunkPatterns are shell patterns matched case-insensitively against
+	// file names. DefaultJunkPatterns is used when it is nil.
+	JunkPatterns []string
+}
+
+// ParseJunkPatterns reads a comma-separated list of junk patterns. An empty
+// list gives nil, so the defaults apply.
+func ParseJunkPatterns(s string) ([]string, error) {
+	var patterns []string
+	for _, pattern := range strings.Split(s, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid junk pattern %q: %w", pattern, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+func (c CleanupOptions) enabled() bool {
+	return c.EmptyDirs || c.EmptyFiles || c.Junk
+}
+
+type CleanupKind string
+
+const (
+	CleanupEmptyDir  CleanupKind = "empty-folder"
+	CleanupEmptyFile CleanupKind = "empty-file"
+	CleanupJunk      CleanupKind = "junk"
+)
+
+// CleanupItem is a file or folder the cleanup pass will remove.
+type CleanupItem struct {
+	Path string
+	// Rel is Path relative to the source directory, with forward slashes.
+	Rel  string
+	Kind CleanupKind
+	// Excluded items are left in place, like excluded moves.
+	Excluded bool
+}
+
+// cleanupKind reports whether a file is for the cleanup pass rather than
+// for moving, and why.
+func (o *Organizer) cleanupKind(name string, size int64) CleanupKind {
+	c := o.options.Cleanup
+	if c.Junk {
+		patterns := c.JunkPatterns
+		if patterns == nil {
+			patterns = DefaultJunkPatterns
+		}
+		for _, pattern := range patterns {
+			if ok, _ := filepath.Match(strings.ToLower(pattern), strings.ToLower(name)); ok {
+				return CleanupJunk
+			}
+		}
+	}
+	if c.EmptyFiles && size == 0 && !strings.HasPrefix(name, ".") {
+		return CleanupEmptyFile
+	}
+	return ""
+}
+
+// PlanCleanup finds what the cleanup pass would remove: junk and empty
+// files anywhere in the source folder, and folders that are empty or would
+// be once those are gone, innermost first. Empty dotfiles are kept, as
+// they are often placeholders such as ".gitkeep" that other tools need. Year folders, and so the
+// month folders in them, are never looked into, and neither are hidden
+// folders, which usually belong to other tools.
+func (o *Organizer) PlanCleanup() ([]CleanupItem, error) {
+	if !o.options.Cleanup.enabled() {
+		return nil, nil
+	}
+	var items []CleanupItem
+	if _, err := o.scanCleanup(o.sourceDir, true, &items); err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+	return items, nil
+}
+
+// scanCleanup adds the cleanup items under dir and reports whether dir
+// will be empty once they are removed.
+func (o *Organizer) scanCleanup(dir string, top bool, items *[]CleanupItem) (bool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false, err
+	}
+
+	add := func(path string, kind CleanupKind) {
+		rel, _ := filepath.Rel(o.sourceDir, path)
+		*items = append(*items, CleanupItem{Path: path, Rel: filepath.ToSlash(rel), Kind: kind})
+	}
+
+	empty := true
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		switch {
+		case entry.IsDir():
+			if (top && IsYearFolder(entry.Name())) || strings.HasPrefix(entry.Name(), ".") {
+				empty = false
+				continue
+			}
+			subEmpty, err := o.scanCleanup(path, false, items)
+			if err != nil {
+				o.log(fmt.Sprintf("Warning: Could not read %s: %v", entry.Name(), err))
+			}
+			if err == nil && subEmpty && o.options.Cleanup.EmptyDirs {
+				add(path, CleanupEmptyDir)
+			} else {
+				empty = false
+			}
+
+		case entry.Type().IsRegular():
+			info, err := entry.Info()
+			if err != nil || isStateFile(entry.Name()) {
+				empty = false
+				continue
+			}
+			if kind := o.cleanupKind(entry.Name(), info.Size()); kind != "" {
+				add(path, kind)
+			} else {
+				empty = false
+			}
+
+		default:
+			// Symlinks and special files are left alone.
+			empty = false
+		}
+	}
+	return empty, nil
+}
+
+// ExecuteCleanup removes the items PlanCleanup found, through the trash
+// when one is configured. A folder is only removed if it is still empty.
+func (o *Organizer) ExecuteCleanup(items []CleanupItem) (int, error) {
+	l, err := o.lock(o.sourceDir)
+	if err != nil {
+		return 0, err
+	}
+	defer l.unlock()
+	removed := 0
+	for _, item := range items {
+		if o.cancelled.Load() {
+			o.log("Cancelled")
+			return removed, ErrCancelled
+		}
+		if item.Excluded {
+			continue
+		}
+		if item.Kind == CleanupEmptyDir {
+			if entries, err := os.ReadDir(item.Path); err != nil || len(entries) > 0 {
+				o.log(fmt.Sprintf("Kept (not empty): %s/", item.Rel))
+				continue
+			}
+		}
+		if err := o.remove(item.Path); err != nil {
+			o.log(fmt.Sprintf("Error removing %s: %v", item.Rel, err))
+			continue
+		}
+		o.log(fmt.Sprintf("Removed (%s): %s", item.Kind, item.Rel))
+		removed++
+	}
+	return removed, nil
+}
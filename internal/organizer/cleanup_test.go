@@ -0,0 +1,187 @@
+package organizer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func allCleanup() Options {
+	return Options{Cleanup: CleanupOptions{EmptyDirs: true, EmptyFiles: true, Junk: true}}
+}
+
+// TestPlanCleanup verifies junk, empty files and folders left empty are
+// found innermost first, and that year, hidden and non-empty folders are
+// left alone
+func TestPlanCleanup(t *testing.T) {
+	tmpDir := t.TempDir()
+	modTime := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	for _, dir := range []string{"old/nested", "2024/03-March/empty", ".git/refs", "keep"} {
+		if err := os.MkdirAll(filepath.Join(tmpDir, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFileAt(t, filepath.Join(tmpDir, "Thumbs.db"), []byte("x"), modTime)
+	writeFileAt(t, filepath.Join(tmpDir, "empty.txt"), nil, modTime)
+	writeFileAt(t, filepath.Join(tmpDir, "photo.jpg"), []byte("x"), modTime)
+	writeFileAt(t, filepath.Join(tmpDir, "old", "nested", ".DS_Store"), []byte("x"), modTime)
+	writeFileAt(t, filepath.Join(tmpDir, "keep", "notes.txt"), []byte("x"), modTime)
+	writeFileAt(t, filepath.Join(tmpDir, "keep", "DESKTOP.INI"), []byte("x"), modTime)
+
+	org := NewWithOptions(tmpDir, allCleanup(), nil)
+	items, err := org.PlanCleanup()
+	if err != nil {
+		t.Fatalf("PlanCleanup failed: %v", err)
+	}
+
+	want := map[string]CleanupKind{
+		"Thumbs.db":            CleanupJunk,
+		"empty.txt":            CleanupEmptyFile,
+		"old/nested/.DS_Store": CleanupJunk,
+		"old/nested":           CleanupEmptyDir,
+		"old":                  CleanupEmptyDir,
+		"keep/DESKTOP.INI":     CleanupJunk,
+	}
+	if len(items) != len(want) {
+		t.Fatalf("Expected %d items, got %+v", len(want), items)
+	}
+	order := make(map[string]int)
+	for i, item := range items {
+		if want[item.Rel] != item.Kind {
+			t.Errorf("Unexpected item %s (%s)", item.Rel, item.Kind)
+		}
+		order[item.Rel] = i
+	}
+	if order["old/nested/.DS_Store"] > order["old/nested"] || order["old/nested"] > order["old"] {
+		t.Errorf("Expected innermost items first, got %+v", items)
+	}
+
+	files, _ := org.GetFiles()
+	plan := org.Plan(files)
+	if len(plan) != 1 || plan[0].File.Name != "photo.jpg" {
+		t.Errorf("Expected only photo.jpg to be moved, got %+v", plan)
+	}
+}
+
+// TestExecuteCleanup verifies items are removed, excluded ones kept, and a
+// folder that is no longer empty is left in place
+func TestExecuteCleanup(t *testing.T) {
+	tmpDir := t.TempDir()
+	trashDir := t.TempDir()
+	modTime := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	if err := os.MkdirAll(filepath.Join(tmpDir, "a", "b"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFileAt(t, filepath.Join(tmpDir, "a", "b", "Thumbs.db"), []byte("x"), modTime)
+	writeFileAt(t, filepath.Join(tmpDir, "empty.txt"), nil, modTime)
+
+	options := allCleanup()
+	options.Trash = dirTrash{trashDir}
+	org := NewWithOptions(tmpDir, options, nil)
+	items, err := org.PlanCleanup()
+	if err != nil || len(items) != 4 {
+		t.Fatalf("Expected 4 items, got %+v (%v)", items, err)
+	}
+	for i := range items {
+		if items[i].Rel == "a/b/Thumbs.db" {
+			items[i].Excluded = true
+		}
+	}
+
+	removed, err := org.ExecuteCleanup(items)
+	if err != nil {
+		t.Fatalf("ExecuteCleanup failed: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("Expected only empty.txt to be removed, got %d", removed)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "a", "b", "Thumbs.db")); err != nil {
+		t.Errorf("Expected the excluded file and its folders to stay: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(trashDir, "empty.txt")); err != nil {
+		t.Errorf("Expected empty.txt in the trash: %v", err)
+	}
+}
+
+// TestCleanupKeepsEmptyDotfiles verifies a nested ".gitkeep" survives, and
+// so does the folder it keeps, while other empty files go
+func TestCleanupKeepsEmptyDotfiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	modTime := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	if err := os.MkdirAll(filepath.Join(tmpDir, "project", "build"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	gitkeep := filepath.Join(tmpDir, "project", "build", ".gitkeep")
+	writeFileAt(t, gitkeep, nil, modTime)
+	writeFileAt(t, filepath.Join(tmpDir, "project", "empty.log"), nil, modTime)
+
+	org := NewWithOptions(tmpDir, allCleanup(), nil)
+	items, err := org.PlanCleanup()
+	if err != nil {
+		t.Fatalf("PlanCleanup failed: %v", err)
+	}
+	if len(items) != 1 || items[0].Rel != "project/empty.log" {
+		t.Fatalf("Expected only project/empty.log, got %+v", items)
+	}
+	if _, err := org.ExecuteCleanup(items); err != nil {
+		t.Fatalf("ExecuteCleanup failed: %v", err)
+	}
+	if _, err := os.Stat(gitkeep); err != nil {
+		t.Errorf("Expected .gitkeep kept: %v", err)
+	}
+}
+
+// TestCleanupKindsSeparately verifies each kind of cleanup only finds its
+// own items
+func TestCleanupKindsSeparately(t *testing.T) {
+	tmpDir := t.TempDir()
+	modTime := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	if err := os.MkdirAll(filepath.Join(tmpDir, "empty"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFileAt(t, filepath.Join(tmpDir, "Thumbs.db"), []byte("x"), modTime)
+	writeFileAt(t, filepath.Join(tmpDir, "empty.txt"), nil, modTime)
+
+	for rel, cleanup := range map[string]CleanupOptions{
+		"empty":     {EmptyDirs: true},
+		"empty.txt": {EmptyFiles: true},
+		"Thumbs.db": {Junk: true},
+	} {
+		items, err := NewWithOptions(tmpDir, Options{Cleanup: cleanup}, nil).PlanCleanup()
+		if err != nil {
+			t.Fatalf("PlanCleanup failed: %v", err)
+		}
+		if len(items) != 1 || items[0].Rel != rel {
+			t.Errorf("Expected only %s for %+v, got %+v", rel, cleanup, items)
+		}
+	}
+}
+
+// TestCleanupCustomJunk verifies configured patterns replace the defaults
+func TestCleanupCustomJunk(t *testing.T) {
+	org := NewWithOptions(t.TempDir(), Options{Cleanup: CleanupOptions{Junk: true, JunkPatterns: []string{"*.tmp"}}}, nil)
+	if org.cleanupKind("Upload.TMP", 10) != CleanupJunk {
+		t.Error("Expected *.tmp to match case-insensitively")
+	}
+	if org.cleanupKind("Thumbs.db", 10) != "" {
+		t.Error("Expected the default patterns to be replaced")
+	}
+	if org.cleanupKind("empty.txt", 0) != "" {
+		t.Error("Expected empty files to be kept when not enabled")
+	}
+}
+
+// TestParseJunkPatterns verifies the comma-separated pattern list
+func TestParseJunkPatterns(t *testing.T) {
+	patterns, err := ParseJunkPatterns(" *.tmp, ,Thumbs.db ")
+	if err != nil || len(patterns) != 2 || patterns[0] != "*.tmp" || patterns[1] != "Thumbs.db" {
+		t.Errorf("Expected [*.tmp Thumbs.db], got %v, %v", patterns, err)
+	}
+	if patterns, err := ParseJunkPatterns(""); err != nil || patterns != nil {
+		t.Errorf("Expected nil for an empty list, got %v, %v", patterns, err)
+	}
+	if _, err := ParseJunkPatterns("[a-"); err == nil {
+		t.Error("Expected an error for a malformed pattern")
+	}
+}
@@ -0,0 +1,132 @@
+package organizer
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dale-tomson/declutter/internal/metadata"
+)
+
+type DateSource string
+
+const (
+	DateSourceModTime  DateSource = "mtime"
+	DateSourceMedia    DateSource = "media"
+	DateSourceDocument DateSource = "document"
+	DateSourceFilename DateSource = "filename"
+)
+
+// ParseDateRules reads date sources chosen per file type, one rule per
+// entry, such as ".pdf,.docx=document,filename" or "mp4=mtime". Sources
+// are tried in the order given; empty entries are ignored. The result
+// suits Options.DateSourcesByExt.
+func ParseDateRules(rules []string) (map[string][]DateSource, error) {
+	var byExt map[string][]DateSource
+	for _, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		exts, list, ok := strings.Cut(rule, "=")
+		if !ok {
+			return nil, fmt.Errorf("date rule %q has no \"=\"", rule)
+		}
+		var sources []DateSource
+		for _, s := range strings.Split(list, ",") {
+			switch source := DateSource(strings.ToLower(strings.TrimSpace(s))); source {
+			case DateSourceMedia, DateSourceDocument, DateSourceFilename, DateSourceModTime:
+				sources = append(sources, source)
+			default:
+				return nil, fmt.Errorf("unknown date source %q in %q", strings.TrimSpace(s), rule)
+			}
+		}
+		for _, ext := range strings.Split(exts, ",") {
+			ext = strings.ToLower(strings.TrimSpace(ext))
+			if ext = strings.TrimPrefix(ext, "."); ext == "" {
+				return nil, fmt.Errorf("date rule %q has an empty extension", rule)
+			}
+			if byExt == nil {
+				byExt = make(map[string][]DateSource)
+			}
+			byExt["."+ext] = sources
+		}
+	}
+	return byExt, nil
+}
+
+// EffectiveDate is the date used to place the file, falling back to the
+// modification time when no other source was resolved.
+func (f FileInfo) EffectiveDate() time.Time {
+	if f.Date.IsZero() {
+		return f.ModTime
+	}
+	return f.Date
+}
+
+func (o *Organizer) resolveDate(file *FileInfo) {
+	file.Date = file.ModTime
+	file.DateSource = DateSourceModTime
+
+	for _, source := range o.dateSourcesFor(file.Name) {
+		d, ok := o.dateFrom(source, file)
+		if !ok {
+			continue
+		}
+		if d.AssumedUTC && o.options.MediaTimesAreLocal {
+			d.Time = time.Date(d.Time.Year(), d.Time.Month(), d.Time.Day(),
+				d.Time.Hour(), d.Time.Minute(), d.Time.Second(), d.Time.Nanosecond(), time.UTC)
+			d.Floating = true
+		}
+		file.Date = d.Time
+		file.DateFloating = d.Floating
+		file.DateZoned = source != DateSourceModTime && !d.Floating && !d.AssumedUTC
+		file.DateSource = source
+		return
+	}
+}
+
+// dateSourcesFor returns the per-extension override for name when one is
+// configured, and the general DateSources list otherwise.
+func (o *Organizer) dateSourcesFor(name string) []DateSource {
+	if sources, ok := o.options.DateSourcesByExt[strings.ToLower(filepath.Ext(name))]; ok {
+		return sources
+	}
+	return o.options.DateSources
+}
+
+func (o *Organizer) dateFrom(source DateSource, file *FileInfo) (metadata.Date, bool) {
+	ext := filepath.Ext(file.Name)
+	switch source {
+	case DateSourceMedia:
+		if !metadata.IsMediaExt(ext) && !isMediaMIME(file.MIMEType) {
+			return metadata.Date{}, false
+		}
+		d, err := metadata.MediaDate(file.Path)
+		return d, err == nil
+	case DateSourceDocument:
+		if !metadata.IsDocumentExt(ext) {
+			return metadata.Date{}, false
+		}
+		d, err := metadata.DocumentDate(file.Path)
+		return d, err == nil
+	case DateSourceFilename:
+		return o.filenameMatcher().Match(file.Name)
+	case DateSourceModTime:
+		return metadata.Date{Time: file.ModTime}, true
+	}
+	return metadata.Date{}, false
+}
+
+func isMediaMIME(mime string) bool {
+	return strings.HasPrefix(mime, "video/") || strings.HasPrefix(mime, "audio/")
+}
+
+func (o *Organizer) filenameMatcher() *metadata.FilenameMatcher {
+	if o.options.FilenameMatcher == nil {
+		// The built-in patterns are constants, so this cannot fail.
+		o.options.FilenameMatcher, _ = metadata.NewFilenameMatcher()
+	}
+	return o.options.FilenameMatcher
+}
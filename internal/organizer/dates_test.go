@@ -0,0 +1,213 @@
+package organizer
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func bmffBox(typ string, payload []byte) []byte {
+	buf := make([]byte, 8, 8+len(payload))
+	binary.BigEndian.PutUint32(buf, uint32(8+len(payload)))
+	copy(buf[4:], typ)
+	return append(buf, payload...)
+}
+
+// writeMP4 creates a minimal MP4 whose mvhd creation time is recorded
+func writeMP4(t *testing.T, path string, recorded, modTime time.Time) {
+	t.Helper()
+	mvhd := make([]byte, 100)
+	epoch := time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+	binary.BigEndian.PutUint32(mvhd[4:], uint32(recorded.Sub(epoch)/time.Second))
+
+	data := append(bmffBox("ftyp", []byte("isom\x00\x00\x00\x00")), bmffBox("moov", bmffBox("mvhd", mvhd))...)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("Failed to create %s: %v", path, err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set mod time: %v", err)
+	}
+}
+
+// TestResolveDateMedia verifies media metadata takes priority over mtime
+func TestResolveDateMedia(t *testing.T) {
+	tmpDir := t.TempDir()
+	recorded := time.Date(2023, 7, 4, 12, 0, 0, 0, time.UTC)
+	modTime := time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC)
+	writeMP4(t, filepath.Join(tmpDir, "clip.mp4"), recorded, modTime)
+
+	org := NewWithOptions(tmpDir, Options{DateSources: []DateSource{DateSourceMedia}}, nil)
+	files, err := org.GetFiles()
+	if err != nil {
+		t.Fatalf("GetFiles failed: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("Expected 1 file, got %d", len(files))
+	}
+
+	f := files[0]
+	if f.DateSource != DateSourceMedia {
+		t.Errorf("Expected date source %s, got %s", DateSourceMedia, f.DateSource)
+	}
+	if !f.Date.Equal(recorded) {
+		t.Errorf("Expected %v, got %v", recorded, f.Date)
+	}
+
+	if _, _, err := org.OrganizeFiles(files); err != nil {
+		t.Fatalf("OrganizeFiles failed: %v", err)
+	}
+	expected := filepath.Join(GetYearMonthPath(tmpDir, recorded.In(time.Local)), "clip.mp4")
+	if _, err := os.Stat(expected); err != nil {
+		t.Errorf("Expected file at %s: %v", expected, err)
+	}
+}
+
+// TestResolveDateMediaLocal verifies MediaTimesAreLocal keeps the wall clock
+func TestResolveDateMediaLocal(t *testing.T) {
+	tmpDir := t.TempDir()
+	recorded := time.Date(2023, 12, 31, 23, 30, 0, 0, time.UTC)
+	writeMP4(t, filepath.Join(tmpDir, "clip.mov"), recorded, time.Now())
+
+	org := NewWithOptions(tmpDir, Options{
+		DateSources:        []DateSource{DateSourceMedia},
+		MediaTimesAreLocal: true,
+	}, nil)
+	files, err := org.GetFiles()
+	if err != nil {
+		t.Fatalf("GetFiles failed: %v", err)
+	}
+
+	f := files[0]
+	if !f.DateFloating {
+		t.Error("Expected a floating date")
+	}
+	if f.Date.Year() != 2023 || f.Date.Month() != time.December || f.Date.Hour() != 23 {
+		t.Errorf("Expected wall clock 2023-12-31 23:30, got %v", f.Date)
+	}
+}
+
+// TestResolveDateFallback verifies files without metadata keep their mtime
+func TestResolveDateFallback(t *testing.T) {
+	tmpDir := t.TempDir()
+	modTime := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	path := filepath.Join(tmpDir, "notes.txt")
+	if err := os.WriteFile(path, []byte("notes"), 0644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set mod time: %v", err)
+	}
+
+	org := NewWithOptions(tmpDir, Options{DateSources: []DateSource{DateSourceMedia}}, nil)
+	files, err := org.GetFiles()
+	if err != nil {
+		t.Fatalf("GetFiles failed: %v", err)
+	}
+
+	if files[0].DateSource != DateSourceModTime {
+		t.Errorf("Expected date source %s, got %s", DateSourceModTime, files[0].DateSource)
+	}
+	if !files[0].EffectiveDate().Equal(modTime) {
+		t.Errorf("Expected %v, got %v", modTime, files[0].EffectiveDate())
+	}
+}
+
+// TestResolveDatePerExtension verifies per-extension date source overrides
+func TestResolveDatePerExtension(t *testing.T) {
+	tmpDir := t.TempDir()
+	modTime := time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC)
+
+	pdfPath := filepath.Join(tmpDir, "invoice.pdf")
+	if err := os.WriteFile(pdfPath, []byte("%PDF-1.4\n<< /CreationDate (D:20231104083000Z) >>\n"), 0644); err != nil {
+		t.Fatalf("Failed to create pdf: %v", err)
+	}
+	if err := os.Chtimes(pdfPath, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set mod time: %v", err)
+	}
+	writeMP4(t, filepath.Join(tmpDir, "clip.mp4"), time.Date(2023, 7, 4, 12, 0, 0, 0, time.UTC), modTime)
+
+	org := NewWithOptions(tmpDir, Options{
+		DateSources: []DateSource{DateSourceMedia},
+		DateSourcesByExt: map[string][]DateSource{
+			".pdf": {DateSourceDocument},
+			".mp4": {DateSourceModTime},
+		},
+	}, nil)
+	files, err := org.GetFiles()
+	if err != nil {
+		t.Fatalf("GetFiles failed: %v", err)
+	}
+
+	sources := make(map[string]DateSource)
+	for _, f := range files {
+		sources[f.Name] = f.DateSource
+	}
+	if sources["invoice.pdf"] != DateSourceDocument {
+		t.Errorf("Expected invoice.pdf from %s, got %s", DateSourceDocument, sources["invoice.pdf"])
+	}
+	if sources["clip.mp4"] != DateSourceModTime {
+		t.Errorf("Expected clip.mp4 from %s, got %s", DateSourceModTime, sources["clip.mp4"])
+	}
+}
+
+// TestResolveDateFilename verifies dates are taken from recognised file names
+func TestResolveDateFilename(t *testing.T) {
+	tmpDir := t.TempDir()
+	modTime := time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC)
+	for _, name := range []string{"IMG_20240101_120000.jpg", "holiday.jpg"} {
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("Failed to set mod time: %v", err)
+		}
+	}
+
+	org := NewWithOptions(tmpDir, Options{DateSources: []DateSource{DateSourceFilename}}, nil)
+	files, err := org.GetFiles()
+	if err != nil {
+		t.Fatalf("GetFiles failed: %v", err)
+	}
+	if _, _, err := org.OrganizeFiles(files); err != nil {
+		t.Fatalf("OrganizeFiles failed: %v", err)
+	}
+
+	for _, path := range []string{
+		filepath.Join(tmpDir, "2024", "01-January", "IMG_20240101_120000.jpg"),
+		filepath.Join(GetYearMonthPath(tmpDir, modTime.In(time.Local)), "holiday.jpg"),
+	} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected file at %s: %v", path, err)
+		}
+	}
+}
+
+// TestParseDateRules verifies per-type rules are read with or without the
+// extension's dot and bad rules are rejected
+func TestParseDateRules(t *testing.T) {
+	byExt, err := ParseDateRules([]string{".PDF, docx = document, filename", "", "mp4=mtime"})
+	if err != nil {
+		t.Fatalf("ParseDateRules failed: %v", err)
+	}
+	want := map[string][]DateSource{
+		".pdf":  {DateSourceDocument, DateSourceFilename},
+		".docx": {DateSourceDocument, DateSourceFilename},
+		".mp4":  {DateSourceModTime},
+	}
+	if !reflect.DeepEqual(byExt, want) {
+		t.Errorf("Expected %v, got %v", want, byExt)
+	}
+
+	if byExt, err := ParseDateRules(nil); err != nil || byExt != nil {
+		t.Errorf("Expected no rules, got %v, %v", byExt, err)
+	}
+	for _, rule := range []string{"pdf", "pdf=exif", "=media", "pdf,=media"} {
+		if _, err := ParseDateRules([]string{rule}); err == nil {
+			t.Errorf("Expected %q to be rejected", rule)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package organizer
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// statePrefix starts the names of Declutter's own files in the source
+	// folder, which are never organized.
+	statePrefix = ".declutter-"
+	// journalPlan and journalLog record a run in progress: the plan once at
+	// the start, then a line per file handled.
+	journalPlan = ".declutter-run.json"
+	journalLog  = ".declutter-run.log"
+	// partSuffix marks a copy still being written. It is renamed into place
+	// once complete, so a file with this suffix is left from a run that
+	// was interrupted.
+	partSuffix = ".declutter-part"
+)
+
+// ErrInterrupted is returned by Execute while the source folder has an
+// interrupted run, whose journal a new run would overwrite.
+var ErrInterrupted = errors.New("an interrupted run must be resumed or rolled back first")
+
+func isStateFile(name string) bool {
+	return strings.HasPrefix(name, statePrefix)
+}
+
+// journal records a run as it goes, so that if the process dies the run
+// can be resumed or rolled back. A nil journal records nothing.
+type journal struct {
+	dir string
+	log *os.File
+}
+
+type journalState struct {
+	Started time.Time     `json:"started"`
+	Plan    []PlannedMove `json:"plan"`
+}
+
+// startJournal records plan before it runs. The run goes ahead without a
+// journal if it can't be written.
+func (o *Organizer) startJournal(plan []PlannedMove) *journal {
+	j := &journal{dir: o.sourceDir}
+	var err error
+	j.log, err = os.OpenFile(filepath.Join(o.sourceDir, journalLog), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err == nil {
+		err = writeJournalPlan(o.sourceDir, journalState{Started: time.Now(), Plan: plan})
+	}
+	if err != nil {
+		o.log(fmt.Sprintf("Warning: Could not record the run for resuming: %v", err))
+		j.finish()
+		return nil
+	}
+	return j
+}
+
+func writeJournalPlan(dir string, state journalState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, journalPlan)
+	if err := os.WriteFile(path+partSuffix, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+partSuffix, path)
+}
+
+// reopenJournal carries on recording an interrupted run.
+func (o *Organizer) reopenJournal() *journal {
+	f, err := os.OpenFile(filepath.Join(o.sourceDir, journalLog), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		o.log(fmt.Sprintf("Warning: Could not record the run for resuming: %v", err))
+		return nil
+	}
+	return &journal{dir: o.sourceDir, log: f}
+}
+
+func (j *journal) record(index int, status Status) {
+	if j != nil && j.log != nil {
+		fmt.Fprintf(j.log, "%d %s\n", index, status)
+	}
+}
+
+// finish removes the journal once the run has ended, whether it completed
+// or was cancelled.
+func (j *journal) finish() {
+	if j == nil {
+		return
+	}
+	if j.log != nil {
+		j.log.Close()
+	}
+	removeJournal(j.dir)
+}
+
+func hasJournal(dir string) bool {
+	_, err := os.Lstat(filepath.Join(dir, journalPlan))
+	return err == nil
+}
+
+func removeJournal(dir string) {
+	os.Remove(filepath.Join(dir, journalPlan))
+	os.Remove(filepath.Join(dir, journalLog))
+}
+
+// InterruptedRun is a run that stopped without finishing, as found by
+// FindInterruptedRun.
+type InterruptedRun struct {
+	Started time.Time
+	Plan    []PlannedMove
+	// Done holds the outcome of each file handled before the run stopped,
+	// by its index in Plan.
+	Done map[int]Status
+}
+
+// Moved counts the files the run had moved before it stopped.
+func (r *InterruptedRun) Moved() int {
+	moved := 0
+	for _, status := range r.Done {
+		if status == StatusMoved {
+			moved++
+		}
+	}
+	return moved
+}
+
+// FindInterruptedRun returns the run that was in progress in sourceDir when
+// the process stopped, or nil if there is none.
+func FindInterruptedRun(sourceDir string) (*InterruptedRun, error) {
+	data, err := os.ReadFile(filepath.Join(sourceDir, journalPlan))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state journalState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("reading the interrupted run: %w", err)
+	}
+
+	run := &InterruptedRun{Started: state.Started, Plan: state.Plan, Done: make(map[int]Status)}
+	f, err := os.Open(filepath.Join(sourceDir, journalLog))
+	if errors.Is(err, fs.ErrNotExist) {
+		return run, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// The last line may be cut short; anything unreadable is ignored
+		// and the file checked again on resume.
+		index, status, _ := strings.Cut(scanner.Text(), " ")
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 || i >= len(run.Plan) {
+			continue
+		}
+		switch Status(status) {
+		case StatusMoved, StatusSkipped, StatusExcluded, StatusFailed:
+			run.Done[i] = Status(status)
+		}
+	}
+	return run, scanner.Err()
+}
+
+// Resume finishes an interrupted run. Files it had already handled are
+// reported again without being touched; for the rest, a move that
+// completed before it could be recorded is noticed rather than skipped,
+// and incomplete copies are removed first.
+func (o *Organizer) Resume(run *InterruptedRun) (int, int, error) {
+	l, err := o.lock(o.sourceDir)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer l.unlock()
+	o.removeParts(run.Plan)
+	return o.execute(run.Plan, o.reopenJournal(), run.Done)
+}
+
+// Rollback moves the files an interrupted run had moved back, like Undo,
+// and removes its incomplete copies and journal.
+func (o *Organizer) Rollback(run *InterruptedRun) (restored, failed int) {
+	l, err := o.lock(o.sourceDir)
+	if err != nil {
+		o.log(fmt.Sprintf("Error rolling back: %v", err))
+		return 0, run.Moved()
+	}
+	defer l.unlock()
+	o.removeParts(run.Plan)
+	var results []Result
+	for i, move := range run.Plan {
+		status, ok := run.Done[i]
+		if !ok && !move.Conflict && !move.Excluded {
+			switch interruptedState(move) {
+			case interruptedMoved:
+				status = StatusMoved
+			case interruptedCopied:
+				// The original is still in place, so the copy can go.
+				if err := os.Remove(move.Dest); err != nil {
+					o.log(fmt.Sprintf("Error removing the copy of %s: %v", move.File.Name, err))
+				}
+			}
+		}
+		if status == StatusMoved {
+			results = append(results, Result{Move: move, Status: StatusMoved})
+		}
+	}
+	restored, failed = o.undo(results)
+	removeJournal(o.sourceDir)
+	return restored, failed
+}
+
+// Discard forgets an interrupted run, leaving its files where they are.
+// Incomplete copies are still removed. Like Resume and Rollback it holds
+// the folder's lock, so it returns a LockedError rather than pull the
+// journal from under a run that is resuming it.
+func (o *Organizer) Discard(run *InterruptedRun) error {
+	l, err := o.lock(o.sourceDir)
+	if err != nil {
+		return err
+	}
+	defer l.unlock()
+	o.removeParts(run.Plan)
+	removeJournal(o.sourceDir)
+	return nil
+}
+
+type interrupted int
+
+const (
+	interruptedNone interrupted = iota
+	// interruptedMoved is a file at its destination and gone from the
+	// source.
+	interruptedMoved
+	// interruptedCopied is a file copied across file systems whose
+	// original was not removed yet.
+	interruptedCopied
+)
+
+// interruptedState works out from the disk what happened to a move the
+// journal has no record of.
+func interruptedState(move PlannedMove) interrupted {
+	dst, err := os.Lstat(move.Dest)
+	if err != nil {
+		return interruptedNone
+	}
+	src, err := os.Lstat(move.File.Path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return interruptedMoved
+	case err != nil:
+		return interruptedNone
+	}
+	// copyFile gives the copy the original's time; allow for file systems
+	// that store it at two-second resolution.
+	if src.Size() == dst.Size() && src.ModTime().Sub(dst.ModTime()).Abs() < 2*time.Second {
+		return interruptedCopied
+	}
+	return interruptedNone
+}
+
+// finishInterrupted completes a move the interrupted run had got part way
+// through, and reports whether the file is now in place.
+func (o *Organizer) finishInterrupted(move PlannedMove) bool {
+	switch interruptedState(move) {
+	case interruptedMoved:
+		return true
+	case interruptedCopied:
+		if err := os.Remove(move.File.Path); err != nil {
+			o.log(fmt.Sprintf("Error removing %s: %v", move.File.Name, err))
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// removeParts deletes the incomplete copies left in the plan's folders.
+func (o *Organizer) removeParts(plan []PlannedMove) {
+	folders := make(map[string]bool)
+	for _, move := range plan {
+		folders[filepath.Dir(move.Dest)] = true
+	}
+	for folder := range folders {
+		entries, _ := os.ReadDir(folder)
+		for _, entry := range entries {
+			if !strings.HasSuffix(entry.Name(), partSuffix) {
+				continue
+			}
+			part := filepath.Join(folder, entry.Name())
+			if err := os.Remove(part); err != nil {
+				o.log(fmt.Sprintf("Error removing incomplete copy %s: %v", filepath.Base(part), err))
+				continue
+			}
+			o.log(fmt.Sprintf("Removed incomplete copy: %s", filepath.Base(part)))
+		}
+	}
+}
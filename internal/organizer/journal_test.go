@@ -0,0 +1,183 @@
+package organizer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// interruptRun sets up a run that died part way: a.txt moved and recorded,
+// b.txt moved but not recorded, c.txt copied but its original not removed,
+// and d.txt not started, with an incomplete copy beside it
+func interruptRun(t *testing.T) (string, *Organizer) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	march := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	for _, name := range []string{"a.txt", "b.txt", "c.txt", "d.txt"} {
+		writeFileAt(t, filepath.Join(tmpDir, name), []byte(name), march)
+	}
+
+	org := New(tmpDir, nil)
+	files, err := org.GetFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan := org.Plan(files)
+	j := org.startJournal(plan)
+	month := filepath.Join(tmpDir, "2024", "03-March")
+	if err := os.MkdirAll(month, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for i, move := range plan {
+		switch move.File.Name {
+		case "a.txt", "b.txt":
+			if err := os.Rename(move.File.Path, move.Dest); err != nil {
+				t.Fatal(err)
+			}
+			if move.File.Name == "a.txt" {
+				j.record(i, StatusMoved)
+			}
+		case "c.txt":
+			if err := org.copyFile(move.File.Path, move.Dest); err != nil {
+				t.Fatal(err)
+			}
+		case "d.txt":
+			writeFileAt(t, move.Dest+partSuffix, []byte("d"), march)
+		}
+	}
+	j.log.Close()
+	return tmpDir, org
+}
+
+// TestExecuteRemovesJournal verifies a finished run leaves no journal and
+// that Declutter's own files are never organized
+func TestExecuteRemovesJournal(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileAt(t, filepath.Join(tmpDir, "a.txt"), []byte("a"), time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	writeFileAt(t, filepath.Join(tmpDir, ".declutter-notes"), []byte("x"), time.Now())
+
+	results := runAndCollect(t, New(tmpDir, nil))
+	if len(results) != 1 || results[0].Move.File.Name != "a.txt" {
+		t.Errorf("Expected only a.txt to be organized, got %+v", results)
+	}
+	for _, name := range []string{journalPlan, journalLog} {
+		if _, err := os.Stat(filepath.Join(tmpDir, name)); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed, got %v", name, err)
+		}
+	}
+	if run, err := FindInterruptedRun(tmpDir); run != nil || err != nil {
+		t.Errorf("Expected no interrupted run, got %+v, %v", run, err)
+	}
+}
+
+// TestResumeInterruptedRun verifies a resumed run finishes every file
+// whatever state the interruption left it in
+func TestResumeInterruptedRun(t *testing.T) {
+	tmpDir, org := interruptRun(t)
+
+	run, err := FindInterruptedRun(tmpDir)
+	if err != nil || run == nil {
+		t.Fatalf("Expected an interrupted run, got %v", err)
+	}
+	if len(run.Plan) != 4 || run.Moved() != 1 {
+		t.Fatalf("Expected 4 planned and 1 moved, got %d and %d", len(run.Plan), run.Moved())
+	}
+
+	var results []Result
+	org.SetResultCallback(func(r Result) { results = append(results, r) })
+	moved, skipped, err := org.Resume(run)
+	if err != nil || moved != 4 || skipped != 0 || len(results) != 4 {
+		t.Fatalf("Expected 4 moved, got %d moved, %d skipped, %d results, %v", moved, skipped, len(results), err)
+	}
+	month := filepath.Join(tmpDir, "2024", "03-March")
+	for _, name := range []string{"a.txt", "b.txt", "c.txt", "d.txt"} {
+		if _, err := os.Stat(filepath.Join(month, name)); err != nil {
+			t.Errorf("Expected %s to be organized: %v", name, err)
+		}
+		if _, err := os.Stat(filepath.Join(tmpDir, name)); !os.IsNotExist(err) {
+			t.Errorf("Expected the original %s to be gone, got %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(month, "d.txt"+partSuffix)); !os.IsNotExist(err) {
+		t.Errorf("Expected the incomplete copy to be removed, got %v", err)
+	}
+	if run, _ := FindInterruptedRun(tmpDir); run != nil {
+		t.Error("Expected the journal to be removed")
+	}
+}
+
+// TestExecuteKeepsInterruptedJournal verifies a new run refuses to start
+// over an interrupted one rather than overwrite its journal
+func TestExecuteKeepsInterruptedJournal(t *testing.T) {
+	tmpDir, org := interruptRun(t)
+	run, err := FindInterruptedRun(tmpDir)
+	if err != nil || run == nil {
+		t.Fatalf("Expected an interrupted run, got %v", err)
+	}
+
+	if _, _, err := org.Execute(run.Plan); !errors.Is(err, ErrInterrupted) {
+		t.Fatalf("Expected ErrInterrupted, got %v", err)
+	}
+	if run, _ := FindInterruptedRun(tmpDir); run == nil || run.Moved() != 1 {
+		t.Errorf("Expected the interrupted run to be kept, got %+v", run)
+	}
+}
+
+// TestRollbackInterruptedRun verifies a rollback puts every file back and
+// removes the copies and folders the run made
+func TestRollbackInterruptedRun(t *testing.T) {
+	tmpDir, org := interruptRun(t)
+
+	run, err := FindInterruptedRun(tmpDir)
+	if err != nil || run == nil {
+		t.Fatalf("Expected an interrupted run, got %v", err)
+	}
+	if restored, failed := org.Rollback(run); restored != 2 || failed != 0 {
+		t.Errorf("Expected 2 restored, got %d restored and %d failed", restored, failed)
+	}
+	for _, name := range []string{"a.txt", "b.txt", "c.txt", "d.txt"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, name)); err != nil {
+			t.Errorf("Expected %s back in place: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "2024")); !os.IsNotExist(err) {
+		t.Errorf("Expected the year folder to be removed, got %v", err)
+	}
+	if run, _ := FindInterruptedRun(tmpDir); run != nil {
+		t.Error("Expected the journal to be removed")
+	}
+}
+
+// TestDiscardInterruptedRun verifies discarding keeps the moved files and
+// drops the journal, but not while another run holds the folder
+func TestDiscardInterruptedRun(t *testing.T) {
+	tmpDir, org := interruptRun(t)
+	run, err := FindInterruptedRun(tmpDir)
+	if err != nil || run == nil {
+		t.Fatalf("Expected an interrupted run, got %v", err)
+	}
+
+	l, err := New(tmpDir, nil).lock(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := org.Discard(run); !errors.Is(err, ErrLocked) {
+		t.Errorf("Expected ErrLocked while the folder is locked, got %v", err)
+	}
+	if run, _ := FindInterruptedRun(tmpDir); run == nil {
+		t.Error("Expected the journal kept while the folder is locked")
+	}
+	l.unlock()
+
+	if err := org.Discard(run); err != nil {
+		t.Fatalf("Discard failed: %v", err)
+	}
+	if run, _ := FindInterruptedRun(tmpDir); run != nil {
+		t.Error("Expected the journal to be removed")
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "2024", "03-March", "a.txt")); err != nil {
+		t.Errorf("Expected a.txt left where it was moved: %v", err)
+	}
+}
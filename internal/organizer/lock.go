@@ -0,0 +1,235 @@
+package organizer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// lockName is the advisory lock a run holds on its folder, so that two
+// instances, on this machine or sharing the folder over a network, don't
+// organize it at the same time.
+const lockName = ".declutter-lock"
+
+const (
+	// lockRefresh is how often a running instance updates its lock.
+	lockRefresh = time.Minute
+	// lockStaleAfter is how long a lock may go without an update before
+	// it is taken to be left by a crash, even if a process with its ID
+	// runs on this machine, which may have reused the ID.
+	lockStaleAfter = 10 * time.Minute
+	// lockAttempts bounds how often a lock is taken over from a stale
+	// holder while other processes race for it.
+	lockAttempts = 3
+)
+
+// ErrLocked is matched by the LockedError a run returns when another run
+// holds its folder.
+var ErrLocked = errors.New("folder is locked by another run")
+
+// LockInfo says who holds a folder's lock.
+type LockInfo struct {
+	PID      int       `json:"pid"`
+	Hostname string    `json:"hostname"`
+	Started  time.Time `json:"started"`
+	Updated  time.Time `json:"updated"`
+}
+
+// LockedError reports the folder and holder of a lock that is in use. It
+// matches ErrLocked.
+type LockedError struct {
+	Dir   string
+	Owner LockInfo
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("%s is being organized by another run (process %d on %s, started %s); if that run is gone, unlock the folder",
+		e.Dir, e.Owner.PID, e.Owner.Hostname, e.Owner.Started.Local().Format(time.DateTime))
+}
+
+func (e *LockedError) Is(target error) bool {
+	return target == ErrLocked
+}
+
+// folderLock is a lock held by this process. A nil folderLock holds
+// nothing.
+type folderLock struct {
+	path string
+	info LockInfo
+	stop chan struct{}
+	done sync.WaitGroup
+}
+
+// lock takes the lock on dir, replacing a stale one. The stale lock is
+// renamed aside rather than removed, so that of two processes that both
+// find it stale, neither can remove a lock the other has just created.
+func (o *Organizer) lock(dir string) (*folderLock, error) {
+	path := filepath.Join(dir, lockName)
+	hostname, _ := os.Hostname()
+	now := time.Now().UTC()
+	l := &folderLock{path: path, info: LockInfo{PID: os.Getpid(), Hostname: hostname, Started: now, Updated: now}}
+
+	for attempt := 0; ; attempt++ {
+		err := l.create()
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("locking %s: %w", dir, err)
+		}
+		data, _ := os.ReadFile(path)
+		owner, stale := readLock(path)
+		if !stale || attempt >= lockAttempts {
+			return nil, &LockedError{Dir: dir, Owner: owner}
+		}
+		taken, err := takeOver(path, data)
+		if err != nil {
+			return nil, fmt.Errorf("locking %s: %w", dir, err)
+		}
+		if taken {
+			o.log(fmt.Sprintf("Removed stale lock of process %d on %s", owner.PID, owner.Hostname))
+		}
+	}
+
+	l.stop = make(chan struct{})
+	l.done.Add(1)
+	go l.refresh()
+	return l, nil
+}
+
+// takeOver moves the stale lock at path out of the way, provided it still
+// holds data. A lock that changed in the meantime, because another process
+// took it over first, is put back and reported as not taken.
+func takeOver(path string, data []byte) (bool, error) {
+	aside := fmt.Sprintf("%s.stale-%d-%d", path, os.Getpid(), time.Now().UnixNano())
+	if err := os.Rename(path, aside); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			// Someone else moved it; try again.
+			return false, nil
+		}
+		return false, err
+	}
+	moved, err := os.ReadFile(aside)
+	if err == nil && !bytes.Equal(moved, data) {
+		// A fresh lock: put it back without replacing any newer one.
+		if err := os.Link(aside, path); err != nil && !errors.Is(err, fs.ErrExist) {
+			os.Rename(aside, path)
+			return false, nil
+		}
+		os.Remove(aside)
+		return false, nil
+	}
+	os.Remove(aside)
+	return true, nil
+}
+
+func (l *folderLock) create() error {
+	data, err := json.Marshal(l.info)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(l.path)
+	}
+	return err
+}
+
+// refresh keeps the lock's time current so that other machines can tell
+// it is still in use.
+func (l *folderLock) refresh() {
+	defer l.done.Done()
+	ticker := time.NewTicker(lockRefresh)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+			if !l.held() {
+				return
+			}
+			l.info.Updated = time.Now().UTC()
+			if data, err := json.Marshal(l.info); err == nil {
+				os.WriteFile(l.path, data, 0644)
+			}
+		}
+	}
+}
+
+// held reports whether the lock file is still ours, rather than removed or
+// taken over after a forced unlock.
+func (l *folderLock) held() bool {
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		return false
+	}
+	var info LockInfo
+	return json.Unmarshal(data, &info) == nil &&
+		info.PID == l.info.PID && info.Hostname == l.info.Hostname && info.Started.Equal(l.info.Started)
+}
+
+func (l *folderLock) unlock() {
+	if l == nil {
+		return
+	}
+	close(l.stop)
+	l.done.Wait()
+	if l.held() {
+		os.Remove(l.path)
+	}
+}
+
+// readLock reads a lock file and works out whether its holder is gone: a
+// process on this machine that no longer runs, or any holder that stopped
+// updating the lock. An unreadable lock is stale once it is old.
+func readLock(path string) (LockInfo, bool) {
+	var info LockInfo
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return info, errors.Is(err, fs.ErrNotExist)
+	}
+	if err := json.Unmarshal(data, &info); err != nil {
+		stat, err := os.Stat(path)
+		return info, err == nil && time.Since(stat.ModTime()) > lockStaleAfter
+	}
+	if time.Since(info.Updated) > lockStaleAfter {
+		return info, true
+	}
+	hostname, _ := os.Hostname()
+	return info, info.Hostname == hostname && !processAlive(info.PID)
+}
+
+// LockOwner returns who holds the lock on dir, or nil if it is free or the
+// holder is gone.
+func LockOwner(dir string) *LockInfo {
+	info, stale := readLock(filepath.Join(dir, lockName))
+	if stale {
+		return nil
+	}
+	return &info
+}
+
+// ForceUnlock removes the lock on dir whoever holds it, for when a run is
+// known to be gone but its lock doesn't look stale, such as one on another
+// machine that crashed minutes ago.
+func ForceUnlock(dir string) error {
+	err := os.Remove(filepath.Join(dir, lockName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,134 @@
+package organizer
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeLock(t *testing.T, dir string, info LockInfo) {
+	t.Helper()
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, lockName), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestLockBlocksSecondRun verifies a run fails with the holder's details
+// while the folder is locked, and the lock is gone once released
+func TestLockBlocksSecondRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileAt(t, filepath.Join(tmpDir, "a.txt"), []byte("a"), time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+
+	org := New(tmpDir, nil)
+	l, err := org.lock(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, _ := org.GetFiles()
+	_, _, err = New(tmpDir, nil).Execute(org.Plan(files))
+	var locked *LockedError
+	if !errors.Is(err, ErrLocked) || !errors.As(err, &locked) || locked.Owner.PID != os.Getpid() {
+		t.Fatalf("Expected a LockedError naming this process, got %v", err)
+	}
+	if owner := LockOwner(tmpDir); owner == nil || owner.PID != os.Getpid() {
+		t.Errorf("Expected LockOwner to report this process, got %+v", owner)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "a.txt")); err != nil {
+		t.Errorf("Expected a.txt untouched: %v", err)
+	}
+
+	l.unlock()
+	if _, err := os.Stat(filepath.Join(tmpDir, lockName)); !os.IsNotExist(err) {
+		t.Errorf("Expected the lock to be removed, got %v", err)
+	}
+	if owner := LockOwner(tmpDir); owner != nil {
+		t.Errorf("Expected no owner, got %+v", owner)
+	}
+}
+
+// TestStaleLockReplaced verifies a lock left by a dead process on this
+// machine, or one any holder stopped updating, is taken over
+func TestStaleLockReplaced(t *testing.T) {
+	hostname, _ := os.Hostname()
+	old := time.Now().Add(-time.Hour)
+	for name, info := range map[string]LockInfo{
+		"dead process":   {PID: 1 << 30, Hostname: hostname, Started: old, Updated: old},
+		"quiet machine":  {PID: 42, Hostname: "elsewhere.invalid", Started: old, Updated: old},
+		"reused process": {PID: os.Getpid(), Hostname: hostname, Started: old, Updated: old},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			writeFileAt(t, filepath.Join(tmpDir, "a.txt"), []byte("a"), time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+			writeLock(t, tmpDir, info)
+
+			if owner := LockOwner(tmpDir); owner != nil {
+				t.Errorf("Expected the lock to be stale, got %+v", owner)
+			}
+			results := runAndCollect(t, New(tmpDir, nil))
+			if len(results) != 1 || results[0].Status != StatusMoved {
+				t.Errorf("Expected a.txt moved, got %+v", results)
+			}
+			if _, err := os.Stat(filepath.Join(tmpDir, lockName)); !os.IsNotExist(err) {
+				t.Errorf("Expected the lock to be removed, got %v", err)
+			}
+		})
+	}
+}
+
+// TestTakeOverKeepsFreshLock verifies a lock that changed after it was
+// found stale is left in place, as another process took it over first
+func TestTakeOverKeepsFreshLock(t *testing.T) {
+	tmpDir := t.TempDir()
+	old := time.Now().Add(-time.Hour)
+	stale, _ := json.Marshal(LockInfo{PID: 42, Hostname: "elsewhere.invalid", Started: old, Updated: old})
+	now := time.Now()
+	writeLock(t, tmpDir, LockInfo{PID: 43, Hostname: "elsewhere.invalid", Started: now, Updated: now})
+
+	taken, err := takeOver(filepath.Join(tmpDir, lockName), stale)
+	if err != nil || taken {
+		t.Fatalf("Expected the fresh lock not taken, got %v, %v", taken, err)
+	}
+	if owner := LockOwner(tmpDir); owner == nil || owner.PID != 43 {
+		t.Errorf("Expected the fresh lock back in place, got %+v", owner)
+	}
+	entries, _ := os.ReadDir(tmpDir)
+	if len(entries) != 1 {
+		t.Errorf("Expected only the lock file, got %v", entries)
+	}
+
+	taken, err = takeOver(filepath.Join(tmpDir, "missing"), stale)
+	if err != nil || taken {
+		t.Errorf("Expected a vanished lock to be retried, got %v, %v", taken, err)
+	}
+}
+
+// TestForceUnlock verifies a live lock from another machine blocks a run
+// until it is forced open
+func TestForceUnlock(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileAt(t, filepath.Join(tmpDir, "a.txt"), []byte("a"), time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	now := time.Now()
+	writeLock(t, tmpDir, LockInfo{PID: 42, Hostname: "elsewhere.invalid", Started: now, Updated: now})
+
+	org := New(tmpDir, nil)
+	files, _ := org.GetFiles()
+	if _, _, err := org.Execute(org.Plan(files)); !errors.Is(err, ErrLocked) {
+		t.Fatalf("Expected ErrLocked, got %v", err)
+	}
+	if err := ForceUnlock(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+	if moved, _, err := org.Execute(org.Plan(files)); err != nil || moved != 1 {
+		t.Errorf("Expected 1 moved, got %d, %v", moved, err)
+	}
+	if err := ForceUnlock(tmpDir); err != nil {
+		t.Errorf("Expected unlocking a free folder to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package organizer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dale-tomson/declutter/internal/i18n"
+)
+
+type MonthStyle string
+
+const (
+	// MonthStyleEnglish is the original "03-March" naming and the default.
+	MonthStyleEnglish     MonthStyle = "english"
+	MonthStyleLocalized   MonthStyle = "localized"
+	MonthStyleAbbreviated MonthStyle = "abbreviated"
+	MonthStyleNumeric     MonthStyle = "numeric"
+)
+
+// MonthFormat controls how month folders are named.
+type MonthFormat struct {
+	Style    MonthStyle
+	Language i18n.Language
+}
+
+func (f MonthFormat) FolderName(m time.Month) string {
+	switch f.Style {
+	case MonthStyleLocalized:
+		return fmt.Sprintf("%02d-%s", int(m), i18n.MonthName(f.Language, m))
+	case MonthStyleAbbreviated:
+		return fmt.Sprintf("%02d-%s", int(m), i18n.MonthAbbrev(f.Language, m))
+	case MonthStyleNumeric:
+		return fmt.Sprintf("%02d", int(m))
+	}
+	return fmt.Sprintf("%02d-%s", int(m), m.String())
+}
+
+// Path returns the Year/Month folder for t under baseDir.
+func (f MonthFormat) Path(baseDir string, t time.Time) string {
+	return filepath.Join(baseDir, fmt.Sprintf("%d", t.Year()), f.FolderName(t.Month()))
+}
+
+// IsYearFolder reports whether name looks like a year folder created by
+// OrganizeFiles.
+func IsYearFolder(name string) bool {
+	if len(name) != 4 {
+		return false
+	}
+	year, err := strconv.Atoi(name)
+	return err == nil && year >= 1000
+}
+
+// IsMonthFolder reports whether name is a month folder in any of the
+// supported styles and languages, and which month it holds.
+func IsMonthFolder(name string) (time.Month, bool) {
+	if len(name) < 2 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(name[:2])
+	if err != nil || n < 1 || n > 12 {
+		return 0, false
+	}
+	month := time.Month(n)
+
+	rest := name[2:]
+	if rest == "" {
+		return month, true
+	}
+	label, ok := strings.CutPrefix(rest, "-")
+	if !ok {
+		return 0, false
+	}
+	for _, known := range i18n.AllMonthNames(month) {
+		if strings.EqualFold(label, known) {
+			return month, true
+		}
+	}
+	return 0, false
+}
+
+// monthFolder returns the folder for t, reusing an existing folder for the
+// same month under another naming style so that switching the setting
+// doesn't split a month across "03-March" and "03-März".
+func (o *Organizer) monthFolder(t time.Time) string {
+	preferred := o.options.MonthFormat.Path(o.sourceDir, t)
+	if _, err := os.Stat(preferred); err == nil {
+		return preferred
+	}
+
+	yearFolder := filepath.Dir(preferred)
+	entries, err := os.ReadDir(yearFolder)
+	if err != nil {
+		return preferred
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if month, ok := IsMonthFolder(entry.Name()); ok && month == t.Month() {
+			return filepath.Join(yearFolder, entry.Name())
+		}
+	}
+	return preferred
+}
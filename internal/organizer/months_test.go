@@ -0,0 +1,109 @@
+package organizer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dale-tomson/declutter/internal/i18n"
+)
+
+// TestMonthFormatFolderName verifies each month naming style
+func TestMonthFormatFolderName(t *testing.T) {
+	tests := []struct {
+		format   MonthFormat
+		month    time.Month
+		expected string
+	}{
+		{MonthFormat{}, time.March, "03-March"},
+		{MonthFormat{Style: MonthStyleLocalized, Language: i18n.German}, time.March, "03-März"},
+		{MonthFormat{Style: MonthStyleLocalized, Language: i18n.Japanese}, time.December, "12-12月"},
+		{MonthFormat{Style: MonthStyleAbbreviated, Language: i18n.French}, time.February, "02-Févr"},
+		{MonthFormat{Style: MonthStyleAbbreviated, Language: i18n.English}, time.September, "09-Sep"},
+		{MonthFormat{Style: MonthStyleNumeric}, time.July, "07"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := tt.format.FolderName(tt.month); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestIsMonthFolder verifies month folders are recognised in any style
+func TestIsMonthFolder(t *testing.T) {
+	tests := []struct {
+		name  string
+		month time.Month
+		ok    bool
+	}{
+		{"03-March", time.March, true},
+		{"03-march", time.March, true},
+		{"03-März", time.March, true},
+		{"03-Mar", time.March, true},
+		{"03", time.March, true},
+		{"12-Diciembre", time.December, true},
+		{"03-April", 0, false},
+		{"13-Smarch", 0, false},
+		{"03-Notes", 0, false},
+		{"2024", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			month, ok := IsMonthFolder(tt.name)
+			if ok != tt.ok || month != tt.month {
+				t.Errorf("Expected (%v, %v), got (%v, %v)", tt.month, tt.ok, month, ok)
+			}
+		})
+	}
+}
+
+// TestOrganizeFilesReusesExistingMonthFolder verifies English folders from
+// earlier runs are kept when a localized style is configured
+func TestOrganizeFilesReusesExistingMonthFolder(t *testing.T) {
+	tmpDir := t.TempDir()
+	existing := filepath.Join(tmpDir, "2024", "03-March")
+	if err := os.MkdirAll(existing, 0755); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+
+	for name, modTime := range map[string]time.Time{
+		"march.txt": time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC),
+		"april.txt": time.Date(2024, 4, 15, 12, 0, 0, 0, time.UTC),
+	} {
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, []byte(name), 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("Failed to set mod time: %v", err)
+		}
+	}
+
+	org := NewWithOptions(tmpDir, Options{
+		MonthFormat: MonthFormat{Style: MonthStyleLocalized, Language: i18n.German},
+	}, nil)
+	files, err := org.GetFiles()
+	if err != nil {
+		t.Fatalf("GetFiles failed: %v", err)
+	}
+	if _, _, err := org.OrganizeFiles(files); err != nil {
+		t.Fatalf("OrganizeFiles failed: %v", err)
+	}
+
+	for _, path := range []string{
+		filepath.Join(existing, "march.txt"),
+		filepath.Join(tmpDir, "2024", "04-April", "april.txt"),
+	} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected file at %s: %v", path, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "2024", "03-März")); !os.IsNotExist(err) {
+		t.Error("Expected no separate 03-März folder")
+	}
+}
@@ -0,0 +1,154 @@
+package organizer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeOptions choose how file names are cleaned up as they are moved,
+// for folders shared between macOS, Windows and Linux.
+type NormalizeOptions struct {
+	// NFC composes accented characters, so that a name written decomposed,
+	// as macOS does, matches the same name written elsewhere.
+	NFC bool
+	// ForbiddenChars replaces the characters the destination file system
+	// doesn't allow with an underscore, such as <>:"\|?* on NTFS, FAT and
+	// SMB shares, and fixes names Windows can't use.
+	ForbiddenChars bool
+	// Whitespace trims spaces from the start and end of the name and before
+	// its extension.
+	Whitespace bool
+	// CaseInsensitive treats names that differ only in case as the same
+	// file, as Windows and macOS do, so they are skipped as conflicts
+	// rather than moved beside each other.
+	CaseInsensitive bool
+}
+
+func (n NormalizeOptions) enabled() bool {
+	return n.NFC || n.ForbiddenChars || n.Whitespace || n.CaseInsensitive
+}
+
+// ParseNormalizeOptions reads a comma-separated list of "nfc", "chars",
+// "space" and "case", or "all" for every one of them.
+func ParseNormalizeOptions(s string) (NormalizeOptions, error) {
+	var n NormalizeOptions
+	for _, part := range strings.Split(s, ",") {
+		switch strings.ToLower(strings.TrimSpace(part)) {
+		case "":
+		case "all":
+			n = NormalizeOptions{NFC: true, ForbiddenChars: true, Whitespace: true, CaseInsensitive: true}
+		case "nfc":
+			n.NFC = true
+		case "chars":
+			n.ForbiddenChars = true
+		case "space":
+			n.Whitespace = true
+		case "case":
+			n.CaseInsensitive = true
+		default:
+			return n, fmt.Errorf("unknown name normalization %q", strings.TrimSpace(part))
+		}
+	}
+	return n, nil
+}
+
+// normalizeName applies the normalizations to a file name, using rules for
+// the characters the destination allows.
+func (n NormalizeOptions) normalizeName(name string, rules namingRules) string {
+	if n.NFC {
+		name = norm.NFC.String(name)
+	}
+	if n.Whitespace {
+		name = strings.TrimSpace(name)
+		if ext := filepath.Ext(name); ext != name {
+			name = strings.TrimRightFunc(strings.TrimSuffix(name, ext), unicode.IsSpace) + ext
+		}
+	}
+	if n.ForbiddenChars && rules.windows {
+		name = strings.Map(func(c rune) rune {
+			if forbiddenOnWindows(c) {
+				return '_'
+			}
+			return c
+		}, name)
+		name = strings.TrimRight(name, ". ")
+		base, rest, _ := strings.Cut(name, ".")
+		if windowsReserved[strings.ToUpper(strings.TrimRight(base, " "))] {
+			name = base + "_"
+			if rest != "" {
+				name += "." + rest
+			}
+		}
+	}
+	if name == "" {
+		name = "_"
+	}
+	return name
+}
+
+// nameKey is what two names have in common when they would collide at the
+// destination.
+func (n NormalizeOptions) nameKey(name string) string {
+	if n.NFC {
+		name = norm.NFC.String(name)
+	}
+	if n.CaseInsensitive {
+		name = strings.ToLower(name)
+	}
+	return name
+}
+
+// destinationRules returns the naming rules of the file system the source
+// folder is on, which its Year/Month folders normally share.
+func (o *Organizer) destinationRules() namingRules {
+	v, err := statVolume(existingAncestor(o.sourceDir))
+	if err != nil {
+		return namingRulesFor("")
+	}
+	return namingRulesFor(v.fsType)
+}
+
+// takenNames tells whether a destination path is taken, by a file already
+// in its folder or one claimed earlier in the run. Names are compared by
+// their nameKey, so a file whose name differs only in case or composition
+// collides too when the normalizer is set to match those.
+type takenNames struct {
+	normalize NormalizeOptions
+	folders   map[string]map[string]bool
+}
+
+func (o *Organizer) newTakenNames() *takenNames {
+	return &takenNames{normalize: o.options.Normalize, folders: make(map[string]map[string]bool)}
+}
+
+func (t *takenNames) folder(dir string) map[string]bool {
+	names, ok := t.folders[dir]
+	if ok {
+		return names
+	}
+	names = make(map[string]bool)
+	if t.normalize.NFC || t.normalize.CaseInsensitive {
+		entries, _ := os.ReadDir(dir)
+		for _, entry := range entries {
+			names[t.normalize.nameKey(entry.Name())] = true
+		}
+	}
+	t.folders[dir] = names
+	return names
+}
+
+func (t *takenNames) taken(path string) bool {
+	if _, err := os.Lstat(path); err == nil {
+		return true
+	}
+	return t.folder(filepath.Dir(path))[t.normalize.nameKey(filepath.Base(path))]
+}
+
+func (t *takenNames) claim(path string) {
+	t.folder(filepath.Dir(path))[t.normalize.nameKey(filepath.Base(path))] = true
+}
@@ -0,0 +1,95 @@
+package organizer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestParseNormalizeOptions verifies the normalization list is parsed and
+// unknown entries are rejected
+func TestParseNormalizeOptions(t *testing.T) {
+	n, err := ParseNormalizeOptions("nfc, space")
+	if err != nil || n != (NormalizeOptions{NFC: true, Whitespace: true}) {
+		t.Errorf("Expected NFC and Whitespace, got %+v, %v", n, err)
+	}
+	n, err = ParseNormalizeOptions("all")
+	if err != nil || !n.NFC || !n.ForbiddenChars || !n.Whitespace || !n.CaseInsensitive {
+		t.Errorf("Expected every normalization, got %+v, %v", n, err)
+	}
+	if n, err := ParseNormalizeOptions(""); err != nil || n.enabled() {
+		t.Errorf("Expected nothing for an empty list, got %+v, %v", n, err)
+	}
+	if _, err := ParseNormalizeOptions("nfc,upper"); err == nil {
+		t.Error("Expected an error for an unknown normalization")
+	}
+}
+
+// TestNormalizeName verifies each normalization, with forbidden characters
+// only replaced for file systems that forbid them
+func TestNormalizeName(t *testing.T) {
+	all := NormalizeOptions{NFC: true, ForbiddenChars: true, Whitespace: true, CaseInsensitive: true}
+	windows := namingRules{windows: true, maxName: 255, maxPath: 259}
+	unix := namingRules{maxName: 255, maxPath: 4096}
+	tests := []struct {
+		name  string
+		rules namingRules
+		want  string
+	}{
+		{"Cafe\u0301.jpg", unix, "Café.jpg"},
+		{"  holiday  .jpg ", unix, "holiday.jpg"},
+		{"what?.jpg", unix, "what?.jpg"},
+		{"what?.jpg", windows, "what_.jpg"},
+		{"a:b|c.txt", windows, "a_b_c.txt"},
+		{"notes.", windows, "notes"},
+		{"CON.txt", windows, "CON_.txt"},
+		{"nul", windows, "nul_"},
+		{"???", windows, "___"},
+		{" ", unix, "_"},
+	}
+	for _, tt := range tests {
+		if got := all.normalizeName(tt.name, tt.rules); got != tt.want {
+			t.Errorf("normalizeName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestPlanNormalizesNames verifies names are normalized at the destination
+// and names differing only in case or composition are treated as conflicts
+func TestPlanNormalizesNames(t *testing.T) {
+	tmpDir := t.TempDir()
+	march := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	writeFileAt(t, filepath.Join(tmpDir, "Cafe\u0301 .txt"), []byte("a"), march)
+	writeFileAt(t, filepath.Join(tmpDir, "Café.txt"), []byte("b"), march.Add(time.Hour))
+	writeFileAt(t, filepath.Join(tmpDir, "img.jpg"), []byte("c"), march)
+	if err := os.MkdirAll(filepath.Join(tmpDir, "2024", "03-March"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFileAt(t, filepath.Join(tmpDir, "2024", "03-March", "IMG.JPG"), []byte("d"), march)
+
+	org := NewWithOptions(tmpDir, Options{Normalize: NormalizeOptions{NFC: true, Whitespace: true, CaseInsensitive: true}}, nil)
+	files, err := org.GetFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	moves := make(map[string]PlannedMove)
+	for _, move := range org.Plan(files) {
+		moves[move.File.Name] = move
+	}
+
+	if m := moves["Cafe\u0301 .txt"]; m.Name != "Café.txt" || m.Conflict {
+		t.Errorf("Expected the first café to be renamed and moved, got %+v", m)
+	}
+	if m := moves["Café.txt"]; !m.Conflict {
+		t.Errorf("Expected the second café to conflict, got %+v", m)
+	}
+	if m := moves["img.jpg"]; !m.Conflict {
+		t.Errorf("Expected img.jpg to conflict with IMG.JPG, got %+v", m)
+	}
+
+	plan := org.Plan(files)
+	if moved, skipped, err := org.Execute(plan); err != nil || moved != 1 || skipped != 2 {
+		t.Errorf("Expected 1 moved and 2 skipped, got %d, %d, %v", moved, skipped, err)
+	}
+}
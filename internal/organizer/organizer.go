@@ -1,28 +1,110 @@
 package organizer
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
-	"sort"
+	"sync/atomic"
 	"time"
+
+	"github.com/dale-tomson/declutter/internal/metadata"
+	"github.com/dale-tomson/declutter/internal/trash"
 )
 
 type FileInfo struct {
 	Path    string
 	ModTime time.Time
 	Name    string
+	Size    int64
+
+	// MIMEType and SuggestedExt are only filled in when content detection
+	// is enabled. SuggestedExt is empty when the extension matches the content.
+	MIMEType     string
+	SuggestedExt string
+
+	// Date is the resolved date used to place the file and DateSource
+	// where it came from. DateFloating marks a wall-clock time without a
+	// zone, stored in UTC. DateZoned marks a date whose source recorded
+	// its UTC offset, rather than one taken or assumed to be UTC.
+	Date         time.Time
+	DateSource   DateSource
+	DateFloating bool
+	DateZoned    bool
+}
+
+// ExtensionMismatch reports whether the file's extension is missing or does
+// not match its detected content.
+func (f FileInfo) ExtensionMismatch() bool {
+	return f.SuggestedExt != ""
+}
+
+type Options struct {
+	// DetectContent sniffs each file's magic number to find its real type.
+	DetectContent bool
+	// FixExtensions renames mislabeled or extensionless files during the
+	// move. It implies DetectContent.
+	FixExtensions bool
+
+	// DateSources lists where to look for each file's date, in priority
+	// order. The modification time is always the final fallback.
+	DateSources []DateSource
+	// DateSourcesByExt overrides DateSources for specific extensions,
+	// keyed in lower case with the leading dot (".pdf").
+	DateSourcesByExt map[string][]DateSource
+	// FilenameMatcher recognises dates in file names for DateSourceFilename.
+	// The built-in patterns are used when it is nil.
+	FilenameMatcher *metadata.FilenameMatcher
+	// MediaTimesAreLocal treats the UTC timestamps in MP4 and Matroska
+	// files as local wall-clock time, for cameras that write them that way.
+	MediaTimesAreLocal bool
+	// TimeZone decides which day, and so which folder, an instant falls
+	// on. The zero value uses the machine's local time.
+	TimeZone TimeZonePolicy
+	// MonthFormat names new month folders. Existing folders for the same
+	// month are reused whatever style they were created with.
+	MonthFormat MonthFormat
+	// Trash receives what the cleanup removes and restored archives. It
+	// is nil to delete them outright, which is also done where a volume
+	// has no trash. The original of a move copied across file systems and
+	// an archived month folder are always deleted, since the copy or the
+	// archive replaces them.
+	Trash trash.Trash
+	// Cleanup removes empty folders, empty files and junk from the source
+	// folder; see PlanCleanup.
+	Cleanup CleanupOptions
+	// Checksums records the SHA-256 of every moved file in its month
+	// folder's SHA256SUMS file; see VerifyChecksums.
+	Checksums bool
+	// Normalize cleans up file names as they are moved. Each rename shows
+	// in the log line of its move.
+	Normalize NormalizeOptions
+	// RenameTemplate renames files as they are moved. Undo still puts them
+	// back under their original names.
+	RenameTemplate RenameTemplate
 }
 
 type Organizer struct {
-	sourceDir   string
-	logCallback func(string)
+	sourceDir      string
+	options        Options
+	logCallback    func(string)
+	resultCallback func(Result)
+	cancelled      atomic.Bool
+	trashed        []trash.Item
 }
 
 func New(sourceDir string, logCallback func(string)) *Organizer {
+	return NewWithOptions(sourceDir, Options{}, logCallback)
+}
+
+func NewWithOptions(sourceDir string, options Options, logCallback func(string)) *Organizer {
+	if options.FixExtensions {
+		options.DetectContent = true
+	}
 	return &Organizer{
 		sourceDir:   sourceDir,
+		options:     options,
 		logCallback: logCallback,
 	}
 }
@@ -31,6 +113,18 @@ func (o *Organizer) SourceDir() string {
 	return o.sourceDir
 }
 
+// SetResultCallback registers a function called with the outcome of every
+// file handled by Execute.
+func (o *Organizer) SetResultCallback(callback func(Result)) {
+	o.resultCallback = callback
+}
+
+// Cancel asks a running Execute to stop before the next file. It is safe to
+// call from any goroutine.
+func (o *Organizer) Cancel() {
+	o.cancelled.Store(true)
+}
+
 func (o *Organizer) log(message string) {
 	if o.logCallback != nil {
 		o.logCallback(message)
@@ -46,7 +140,7 @@ func (o *Organizer) GetFiles() ([]FileInfo, error) {
 	}
 
 	for _, entry := range entries {
-		if entry.IsDir() {
+		if entry.IsDir() || isStateFile(entry.Name()) {
 			continue
 		}
 
@@ -56,66 +150,45 @@ func (o *Organizer) GetFiles() ([]FileInfo, error) {
 			continue
 		}
 
-		files = append(files, FileInfo{
+		file := FileInfo{
 			Path:    filepath.Join(o.sourceDir, entry.Name()),
 			ModTime: info.ModTime(),
 			Name:    entry.Name(),
-		})
+			Size:    info.Size(),
+		}
+		if o.options.DetectContent {
+			o.detectContent(&file)
+		}
+		o.resolveDate(&file)
+		files = append(files, file)
 	}
 
 	return files, nil
 }
 
-func (o *Organizer) OrganizeFiles(files []FileInfo) (int, int, error) {
-	movedCount := 0
-	skippedCount := 0
-	createdFolders := make(map[string]bool)
-
-	sort.Slice(files, func(i, j int) bool {
-		return files[i].ModTime.Before(files[j].ModTime)
-	})
-
-	for _, file := range files {
-		year := file.ModTime.Year()
-		month := file.ModTime.Month()
-
-		yearFolder := filepath.Join(o.sourceDir, fmt.Sprintf("%d", year))
-		monthFolder := filepath.Join(yearFolder, fmt.Sprintf("%02d-%s", int(month), month.String()))
-
-		if !createdFolders[yearFolder] {
-			if err := o.ensureDir(yearFolder); err != nil {
-				o.log(fmt.Sprintf("Error creating year folder %s: %v", yearFolder, err))
-				continue
-			}
-			createdFolders[yearFolder] = true
-		}
-
-		if !createdFolders[monthFolder] {
-			if err := o.ensureDir(monthFolder); err != nil {
-				o.log(fmt.Sprintf("Error creating month folder %s: %v", monthFolder, err))
-				continue
-			}
-			createdFolders[monthFolder] = true
-		}
-
-		destPath := filepath.Join(monthFolder, file.Name)
-
-		if _, err := os.Stat(destPath); err == nil {
-			o.log(fmt.Sprintf("Skipped (already exists): %s", file.Name))
-			skippedCount++
-			continue
-		}
-
-		if err := o.moveFile(file.Path, destPath); err != nil {
-			o.log(fmt.Sprintf("Error moving %s: %v", file.Name, err))
-			continue
-		}
+func (o *Organizer) detectContent(file *FileInfo) {
+	mime, err := DetectContentType(file.Path)
+	if err != nil {
+		o.log(fmt.Sprintf("Warning: Could not detect type of %s: %v", file.Name, err))
+		return
+	}
+	file.MIMEType = mime
+	file.SuggestedExt = suggestedExtension(file.Name, mime)
 
-		o.log(fmt.Sprintf("Moved: %s → %d/%02d-%s/", file.Name, year, int(month), month.String()))
-		movedCount++
+	if !file.ExtensionMismatch() {
+		return
+	}
+	if filepath.Ext(file.Name) == "" {
+		o.log(fmt.Sprintf("Missing extension: %s looks like %s (%s)", file.Name, mime, file.SuggestedExt))
+	} else {
+		o.log(fmt.Sprintf("Extension mismatch: %s looks like %s (%s)", file.Name, mime, file.SuggestedExt))
 	}
+}
 
-	return movedCount, skippedCount, nil
+// OrganizeFiles moves every file into its Year/Month folder. It is
+// Execute(Plan(files)) for callers that don't need to review the plan.
+func (o *Organizer) OrganizeFiles(files []FileInfo) (int, int, error) {
+	return o.Execute(o.Plan(files))
 }
 
 func (o *Organizer) ensureDir(path string) error {
@@ -135,7 +208,52 @@ func (o *Organizer) moveFile(src, dst string) error {
 		return err
 	}
 
-	return os.Remove(src)
+	if err := os.Remove(src); err != nil {
+		// Don't leave the file in two places.
+		os.Remove(dst)
+		return err
+	}
+	return nil
+}
+
+// remove moves path to the trash when one is configured, or deletes it
+// when there is none or its volume has none.
+func (o *Organizer) remove(path string) error {
+	if o.options.Trash == nil {
+		return os.Remove(path)
+	}
+	item, err := o.options.Trash.Move(path)
+	if errors.Is(err, trash.ErrUnavailable) {
+		o.log(fmt.Sprintf("Warning: Deleting %s instead of moving it to the trash: %v", filepath.Base(path), err))
+		return os.Remove(path)
+	}
+	if err != nil {
+		return fmt.Errorf("moving to the trash: %w", err)
+	}
+	o.trashed = append(o.trashed, item)
+	return nil
+}
+
+// Trashed returns what this organizer has moved to the trash so far.
+func (o *Organizer) Trashed() []trash.Item {
+	return o.trashed
+}
+
+// RestoreTrashed moves items back out of the trash, newest first.
+func (o *Organizer) RestoreTrashed(items []trash.Item) (restored, failed int) {
+	if o.options.Trash == nil {
+		return 0, len(items)
+	}
+	for i := len(items) - 1; i >= 0; i-- {
+		if err := o.options.Trash.Restore(items[i]); err != nil {
+			o.log(fmt.Sprintf("Error restoring %s from the trash: %v", filepath.Base(items[i].OriginalPath), err))
+			failed++
+			continue
+		}
+		o.log(fmt.Sprintf("Restored from the trash: %s", filepath.Base(items[i].OriginalPath)))
+		restored++
+	}
+	return restored, failed
 }
 
 func (o *Organizer) copyFile(src, dst string) error {
@@ -145,27 +263,34 @@ func (o *Organizer) copyFile(src, dst string) error {
 	}
 	defer sourceFile.Close()
 
-	destFile, err := os.Create(dst)
+	// Write to a temporary name first, so that an interrupted copy is
+	// never mistaken for a complete one.
+	part := dst + partSuffix
+	destFile, err := os.Create(part)
 	if err != nil {
 		return err
 	}
-	defer destFile.Close()
-
-	if _, err := io.Copy(destFile, sourceFile); err != nil {
-		return err
+	_, err = io.Copy(destFile, sourceFile)
+	if err == nil {
+		err = destFile.Sync()
+	}
+	if closeErr := destFile.Close(); err == nil {
+		err = closeErr
 	}
-
-	srcInfo, err := os.Stat(src)
 	if err == nil {
-		os.Chtimes(dst, srcInfo.ModTime(), srcInfo.ModTime())
+		if srcInfo, statErr := os.Stat(src); statErr == nil {
+			os.Chtimes(part, srcInfo.ModTime(), srcInfo.ModTime())
+		}
+		err = os.Rename(part, dst)
 	}
-
-	return destFile.Sync()
+	if err != nil {
+		os.Remove(part)
+	}
+	return err
 }
 
+// GetYearMonthPath returns the Year/Month folder for t using the calendar of
+// t's own location; apply a TimeZonePolicy first to choose that location.
 func GetYearMonthPath(baseDir string, t time.Time) string {
-	year := t.Year()
-	month := t.Month()
-	yearFolder := filepath.Join(baseDir, fmt.Sprintf("%d", year))
-	return filepath.Join(yearFolder, fmt.Sprintf("%02d-%s", int(month), month.String()))
+	return MonthFormat{}.Path(baseDir, t)
 }
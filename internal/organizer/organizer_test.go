@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/dale-tomson/declutter/internal/trash"
 )
 
 // TestNew verifies that New creates an Organizer with the correct source directory
@@ -407,3 +410,83 @@ func TestOrganizeFilesPreservesModTime(t *testing.T) {
 		t.Errorf("Expected content '%s', got '%s'", content, string(dstContent))
 	}
 }
+
+// dirTrash is a trash that keeps items in a folder, for tests.
+type dirTrash struct {
+	dir string
+}
+
+func (d dirTrash) Move(path string) (trash.Item, error) {
+	trashed := filepath.Join(d.dir, filepath.Base(path))
+	if err := os.Rename(path, trashed); err != nil {
+		return trash.Item{}, err
+	}
+	return trash.Item{OriginalPath: path, TrashedPath: trashed, DeletedAt: time.Now()}, nil
+}
+
+func (d dirTrash) Restore(item trash.Item) error {
+	return os.Rename(item.TrashedPath, item.OriginalPath)
+}
+
+// TestRemoveUsesTrash verifies removals go to the configured trash and can
+// be restored
+func TestRemoveUsesTrash(t *testing.T) {
+	tmpDir := t.TempDir()
+	trashDir := t.TempDir()
+	path := filepath.Join(tmpDir, "a.txt")
+	if err := os.WriteFile(path, []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	org := NewWithOptions(tmpDir, Options{Trash: dirTrash{trashDir}}, nil)
+	if err := org.remove(path); err != nil {
+		t.Fatalf("remove failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(trashDir, "a.txt")); err != nil {
+		t.Errorf("Expected the file in the trash: %v", err)
+	}
+	if len(org.Trashed()) != 1 {
+		t.Fatalf("Expected one trashed item, got %d", len(org.Trashed()))
+	}
+
+	restored, failed := org.RestoreTrashed(org.Trashed())
+	if restored != 1 || failed != 0 {
+		t.Errorf("Expected 1 restored, got %d restored and %d failed", restored, failed)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("Expected the file back: %v", err)
+	}
+}
+
+// noTrash is a trash that is never available, like one on a network drive.
+type noTrash struct{}
+
+func (noTrash) Move(path string) (trash.Item, error) {
+	return trash.Item{}, trash.ErrUnavailable
+}
+
+func (noTrash) Restore(trash.Item) error {
+	return trash.ErrUnsupported
+}
+
+// TestRemoveWithoutTrashDeletes verifies a file is deleted, with a warning,
+// where its volume has no trash
+func TestRemoveWithoutTrashDeletes(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "a.txt")
+	if err := os.WriteFile(path, []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var logs []string
+	org := NewWithOptions(tmpDir, Options{Trash: noTrash{}}, func(msg string) { logs = append(logs, msg) })
+	if err := org.remove(path); err != nil {
+		t.Fatalf("remove failed: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected the file to be deleted, got %v", err)
+	}
+	if len(org.Trashed()) != 0 || len(logs) != 1 || !strings.HasPrefix(logs[0], "Warning: Deleting a.txt") {
+		t.Errorf("Expected a warning and nothing trashed, got %q and %d items", logs, len(org.Trashed()))
+	}
+}
@@ -0,0 +1,241 @@
+package organizer
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+// PlannedMove is where a single file will go when the plan is executed.
+type PlannedMove struct {
+	File FileInfo
+	// Folder is the Year/Month folder relative to the source directory,
+	// with forward slashes ("2024/03-March").
+	Folder string
+	// Name is the file name at the destination. It differs from File.Name
+	// when the extension is being fixed or the name normalized.
+	Name string
+	// Dest is the full destination path.
+	Dest string
+
+	// Conflict marks a destination that already exists, or that an
+	// earlier move in the same plan claims. Conflicting files are skipped.
+	Conflict bool
+	// Excluded files are left where they are. Plan never sets it; it is
+	// for callers that let the user untick files.
+	Excluded bool
+}
+
+type Status string
+
+const (
+	StatusMoved    Status = "moved"
+	StatusSkipped  Status = "skipped"
+	StatusExcluded Status = "excluded"
+	StatusFailed   Status = "failed"
+)
+
+// ErrCancelled is returned by Execute when Cancel stopped it early. Files
+// not reached yet are left in place and not reported.
+var ErrCancelled = errors.New("run cancelled")
+
+// Result is the outcome of one planned move, reported through the result
+// callback as Execute works through the plan.
+type Result struct {
+	Move   PlannedMove
+	Status Status
+	Err    error
+}
+
+// Plan works out the destination of every file without touching the disk
+// beyond looking up existing folders and files, and reading the files
+// when a rename template needs their camera model or hash. The files are
+// sorted by date, as they will be moved.
+func (o *Organizer) Plan(files []FileInfo) []PlannedMove {
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].EffectiveDate().Before(files[j].EffectiveDate())
+	})
+
+	plan := make([]PlannedMove, 0, len(files))
+	monthFolders := make(map[string]string)
+	names := o.newTakenNames()
+	var rules namingRules
+	if o.options.Normalize.ForbiddenChars {
+		rules = o.destinationRules()
+	}
+
+	for _, file := range files {
+		if o.cleanupKind(file.Name, file.Size) != "" {
+			// Removed by the cleanup pass instead.
+			continue
+		}
+		folderTime := o.folderTime(file)
+		monthKey := folderTime.Format("2006-01")
+		monthFolder, ok := monthFolders[monthKey]
+		if !ok {
+			monthFolder = o.monthFolder(folderTime)
+			monthFolders[monthKey] = monthFolder
+		}
+
+		base := file.Name
+		if o.options.FixExtensions && file.ExtensionMismatch() {
+			base = replaceExtension(file.Name, file.SuggestedExt)
+		}
+		nameFor := func(int) string { return base }
+		if template := o.options.RenameTemplate; template != "" {
+			fields := o.templateFields(file, base, folderTime)
+			nameFor = func(counter int) string { return template.render(fields, counter) }
+		}
+		if o.options.Normalize.enabled() {
+			named := nameFor
+			nameFor = func(counter int) string { return o.options.Normalize.normalizeName(named(counter), rules) }
+		}
+
+		counter := o.options.RenameTemplate.firstCounter()
+		name := nameFor(counter)
+		if o.options.RenameTemplate != "" {
+			// A renamed file takes the next free name rather than being
+			// skipped.
+			for names.taken(filepath.Join(monthFolder, name)) && counter < maxCounter {
+				counter++
+				name = nameFor(counter)
+			}
+		}
+		move := PlannedMove{
+			File:   file,
+			Folder: filepath.ToSlash(filepath.Join(filepath.Base(filepath.Dir(monthFolder)), filepath.Base(monthFolder))),
+			Name:   name,
+			Dest:   filepath.Join(monthFolder, name),
+		}
+
+		if names.taken(move.Dest) {
+			move.Conflict = true
+		}
+		names.claim(move.Dest)
+		plan = append(plan, move)
+	}
+
+	return plan
+}
+
+// Execute carries out a plan, creating folders as needed. It returns the
+// number of files moved and the number skipped because the destination
+// was taken. Excluded files count as neither. The run is journaled in the
+// source folder until it ends; see FindInterruptedRun. It fails with a
+// LockedError while another run holds the folder, and with ErrInterrupted
+// while an interrupted run is waiting to be resumed or rolled back.
+func (o *Organizer) Execute(plan []PlannedMove) (int, int, error) {
+	l, err := o.lock(o.sourceDir)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer l.unlock()
+	if hasJournal(o.sourceDir) {
+		return 0, 0, ErrInterrupted
+	}
+	return o.execute(plan, o.startJournal(plan), nil)
+}
+
+// execute runs plan, skipping the files in done, which an interrupted run
+// already handled.
+func (o *Organizer) execute(plan []PlannedMove, j *journal, done map[int]Status) (int, int, error) {
+	defer j.finish()
+	movedCount := 0
+	skippedCount := 0
+	createdFolders := make(map[string]bool)
+	names := o.newTakenNames()
+	sums := make(map[string]map[string]string)
+	if o.options.Checksums {
+		defer o.writeChecksums(sums)
+	}
+
+	for i, move := range plan {
+		if status, ok := done[i]; ok {
+			o.report(move, status, nil)
+			switch status {
+			case StatusMoved:
+				if o.options.Checksums {
+					o.recordChecksum(sums, move.Dest)
+				}
+				movedCount++
+			case StatusSkipped:
+				skippedCount++
+			}
+			continue
+		}
+		finish := func(status Status, err error) {
+			o.report(move, status, err)
+			j.record(i, status)
+		}
+
+		if o.cancelled.Load() {
+			o.log("Cancelled")
+			return movedCount, skippedCount, ErrCancelled
+		}
+		if move.Excluded {
+			o.log(fmt.Sprintf("Excluded: %s", move.File.Name))
+			finish(StatusExcluded, nil)
+			continue
+		}
+
+		monthFolder := filepath.Dir(move.Dest)
+		yearFolder := filepath.Dir(monthFolder)
+
+		if !createdFolders[yearFolder] {
+			if err := o.ensureDir(yearFolder); err != nil {
+				o.log(fmt.Sprintf("Error creating year folder %s: %v", yearFolder, err))
+				finish(StatusFailed, err)
+				continue
+			}
+			createdFolders[yearFolder] = true
+		}
+
+		if !createdFolders[monthFolder] {
+			if err := o.ensureDir(monthFolder); err != nil {
+				o.log(fmt.Sprintf("Error creating month folder %s: %v", monthFolder, err))
+				finish(StatusFailed, err)
+				continue
+			}
+			createdFolders[monthFolder] = true
+		}
+
+		if done != nil && !move.Conflict && o.finishInterrupted(move) {
+			o.log(fmt.Sprintf("Moved before the interruption: %s → %s/", move.File.Name, move.Folder))
+		} else {
+			// The plan may be stale by the time it runs, so check again.
+			if names.taken(move.Dest) {
+				o.log(fmt.Sprintf("Skipped (already exists): %s", move.Name))
+				finish(StatusSkipped, nil)
+				skippedCount++
+				continue
+			}
+
+			if err := o.moveFile(move.File.Path, move.Dest); err != nil {
+				o.log(fmt.Sprintf("Error moving %s: %v", move.File.Name, err))
+				finish(StatusFailed, err)
+				continue
+			}
+
+			if move.Name != move.File.Name {
+				o.log(fmt.Sprintf("Moved: %s → %s/%s", move.File.Name, move.Folder, move.Name))
+			} else {
+				o.log(fmt.Sprintf("Moved: %s → %s/", move.File.Name, move.Folder))
+			}
+		}
+		names.claim(move.Dest)
+		if o.options.Checksums {
+			o.recordChecksum(sums, move.Dest)
+		}
+		finish(StatusMoved, nil)
+		movedCount++
+	}
+
+	return movedCount, skippedCount, nil
+}
+
+func (o *Organizer) report(move PlannedMove, status Status, err error) {
+	if o.resultCallback != nil {
+		o.resultCallback(Result{Move: move, Status: status, Err: err})
+	}
+}
@@ -0,0 +1,206 @@
+package organizer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeFileAt(t *testing.T, path string, data []byte, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("Failed to create %s: %v", path, err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set mod time: %v", err)
+	}
+}
+
+// TestPlanDoesNotTouchDisk verifies planning creates no folders and moves
+// no files
+func TestPlanDoesNotTouchDisk(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileAt(t, filepath.Join(tmpDir, "a.txt"), []byte("a"), time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	writeFileAt(t, filepath.Join(tmpDir, "b.txt"), []byte("b"), time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC))
+
+	org := New(tmpDir, nil)
+	files, err := org.GetFiles()
+	if err != nil {
+		t.Fatalf("GetFiles failed: %v", err)
+	}
+	plan := org.Plan(files)
+
+	if len(plan) != 2 {
+		t.Fatalf("Expected 2 planned moves, got %d", len(plan))
+	}
+	if plan[0].File.Name != "b.txt" || plan[0].Folder != "2023/07-July" {
+		t.Errorf("Expected b.txt → 2023/07-July first, got %s → %s", plan[0].File.Name, plan[0].Folder)
+	}
+	if want := filepath.Join(tmpDir, "2024", "03-March", "a.txt"); plan[1].Dest != want {
+		t.Errorf("Dest = %s, want %s", plan[1].Dest, want)
+	}
+
+	entries, _ := os.ReadDir(tmpDir)
+	if len(entries) != 2 {
+		t.Errorf("Expected the source folder to be untouched, found %d entries", len(entries))
+	}
+}
+
+// TestPlanMarksConflicts verifies existing destinations and two files
+// planned for the same name are flagged
+func TestPlanMarksConflicts(t *testing.T) {
+	tmpDir := t.TempDir()
+	modTime := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	destFolder := filepath.Join(tmpDir, "2024", "01-January")
+	if err := os.MkdirAll(destFolder, 0755); err != nil {
+		t.Fatalf("Failed to create dest folder: %v", err)
+	}
+	writeFileAt(t, filepath.Join(destFolder, "existing.txt"), []byte("old"), modTime)
+	writeFileAt(t, filepath.Join(tmpDir, "existing.txt"), []byte("new"), modTime)
+	writeFileAt(t, filepath.Join(tmpDir, "photo"), pngHeader, modTime)
+	writeFileAt(t, filepath.Join(tmpDir, "photo.png"), pngHeader, modTime)
+	writeFileAt(t, filepath.Join(tmpDir, "unique.txt"), []byte("u"), modTime)
+
+	org := NewWithOptions(tmpDir, Options{FixExtensions: true}, nil)
+	files, err := org.GetFiles()
+	if err != nil {
+		t.Fatalf("GetFiles failed: %v", err)
+	}
+
+	conflicts := make(map[string]bool)
+	photos := 0
+	for _, move := range org.Plan(files) {
+		if move.Name == "photo.png" {
+			photos++
+			if move.Conflict {
+				conflicts["photo.png"] = true
+			}
+			continue
+		}
+		conflicts[move.Name] = move.Conflict
+	}
+
+	if photos != 2 || !conflicts["photo.png"] {
+		t.Error("Expected the second photo.png to conflict with the first")
+	}
+	if !conflicts["existing.txt"] {
+		t.Error("Expected existing.txt to conflict with the file already in place")
+	}
+	if conflicts["unique.txt"] {
+		t.Error("Expected unique.txt not to conflict")
+	}
+}
+
+// TestExecuteSkipsExcluded verifies unticked files stay in place and their
+// folders are not created
+func TestExecuteSkipsExcluded(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileAt(t, filepath.Join(tmpDir, "keep.txt"), []byte("k"), time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	writeFileAt(t, filepath.Join(tmpDir, "move.txt"), []byte("m"), time.Date(2024, 4, 15, 12, 0, 0, 0, time.UTC))
+
+	org := New(tmpDir, nil)
+	files, err := org.GetFiles()
+	if err != nil {
+		t.Fatalf("GetFiles failed: %v", err)
+	}
+	plan := org.Plan(files)
+	for i := range plan {
+		plan[i].Excluded = plan[i].File.Name == "keep.txt"
+	}
+
+	moved, skipped, err := org.Execute(plan)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if moved != 1 || skipped != 0 {
+		t.Errorf("Expected 1 moved and 0 skipped, got %d and %d", moved, skipped)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "keep.txt")); err != nil {
+		t.Errorf("Expected keep.txt to stay in place: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "2024", "03-March")); !os.IsNotExist(err) {
+		t.Error("Expected no folder for the excluded month")
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "2024", "04-April", "move.txt")); err != nil {
+		t.Errorf("Expected move.txt to be moved: %v", err)
+	}
+}
+
+// TestExecuteReportsResults verifies every planned file gets a result with
+// its outcome
+func TestExecuteReportsResults(t *testing.T) {
+	tmpDir := t.TempDir()
+	existing := filepath.Join(tmpDir, "2023", "05-May")
+	if err := os.MkdirAll(existing, 0755); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	may := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	writeFileAt(t, filepath.Join(existing, "taken.txt"), []byte("old"), may)
+	writeFileAt(t, filepath.Join(tmpDir, "taken.txt"), []byte("new"), may)
+	writeFileAt(t, filepath.Join(tmpDir, "moved.txt"), []byte("m"), may)
+	writeFileAt(t, filepath.Join(tmpDir, "excluded.txt"), []byte("e"), may)
+	writeFileAt(t, filepath.Join(tmpDir, "failed.txt"), []byte("f"), time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC))
+
+	results := make(map[string]Result)
+	org := New(tmpDir, nil)
+	org.SetResultCallback(func(r Result) {
+		results[r.Move.File.Name] = r
+	})
+	files, err := org.GetFiles()
+	if err != nil {
+		t.Fatalf("GetFiles failed: %v", err)
+	}
+	plan := org.Plan(files)
+	for i := range plan {
+		plan[i].Excluded = plan[i].File.Name == "excluded.txt"
+	}
+
+	// A file where the 2024 folder should go makes its month folder fail.
+	if err := os.WriteFile(filepath.Join(tmpDir, "2024"), nil, 0644); err != nil {
+		t.Fatalf("Failed to create blocking file: %v", err)
+	}
+	if _, _, err := org.Execute(plan); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	want := map[string]Status{
+		"taken.txt":    StatusSkipped,
+		"moved.txt":    StatusMoved,
+		"excluded.txt": StatusExcluded,
+		"failed.txt":   StatusFailed,
+	}
+	for name, status := range want {
+		if results[name].Status != status {
+			t.Errorf("%s: status = %q, want %q", name, results[name].Status, status)
+		}
+	}
+	if results["failed.txt"].Err == nil {
+		t.Error("Expected an error for failed.txt")
+	}
+}
+
+// TestExecuteCancel verifies a cancelled run stops before the next file
+func TestExecuteCancel(t *testing.T) {
+	tmpDir := t.TempDir()
+	modTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	writeFileAt(t, filepath.Join(tmpDir, "a.txt"), []byte("a"), modTime)
+	writeFileAt(t, filepath.Join(tmpDir, "b.txt"), []byte("b"), modTime)
+
+	org := New(tmpDir, nil)
+	files, _ := org.GetFiles()
+	plan := org.Plan(files)
+
+	var reported int
+	org.SetResultCallback(func(Result) {
+		reported++
+		org.Cancel()
+	})
+	moved, _, err := org.Execute(plan)
+	if err != ErrCancelled {
+		t.Fatalf("Expected ErrCancelled, got %v", err)
+	}
+	if moved != 1 || reported != 1 {
+		t.Errorf("Expected one file handled before stopping, got %d moved and %d reported", moved, reported)
+	}
+}
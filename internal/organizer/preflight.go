@@ -0,0 +1,313 @@
+package organizer
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"unicode/utf16"
+)
+
+type PreflightKind string
+
+const (
+	// PreflightSpace is a volume without room for the files that have to be
+	// copied to it.
+	PreflightSpace PreflightKind = "space"
+	// PreflightUnwritable is a folder that files can't be moved into or
+	// out of.
+	PreflightUnwritable PreflightKind = "unwritable"
+	// PreflightUnreadable is a file that has to be copied but can't be read.
+	PreflightUnreadable PreflightKind = "unreadable"
+	// PreflightReadOnly is a read-only file that has to be copied, whose
+	// original Windows won't delete afterwards.
+	PreflightReadOnly PreflightKind = "read-only"
+	// PreflightPathLength is a destination path or name too long for its
+	// file system.
+	PreflightPathLength PreflightKind = "path-length"
+	// PreflightInvalidName is a destination name its file system doesn't
+	// allow.
+	PreflightInvalidName PreflightKind = "invalid-name"
+)
+
+// PreflightIssue is a problem Preflight found before anything is moved.
+// A blocking issue would make the whole run fail; the others affect single
+// files or folders, which fail when the run reaches them.
+type PreflightIssue struct {
+	Kind     PreflightKind
+	Blocking bool
+	// Rel is the file or folder concerned, relative to the source directory
+	// with forward slashes. It is empty for a whole volume.
+	Rel string
+	// Need and Free are the bytes to copy and the bytes available, for
+	// PreflightSpace.
+	Need, Free int64
+	// Detail says what is wrong, in English.
+	Detail string
+}
+
+func (i PreflightIssue) String() string {
+	if i.Rel == "" {
+		return i.Detail
+	}
+	return i.Rel + ": " + i.Detail
+}
+
+// HasBlocking reports whether any of the issues would stop the run.
+func HasBlocking(issues []PreflightIssue) bool {
+	for _, issue := range issues {
+		if issue.Blocking {
+			return true
+		}
+	}
+	return false
+}
+
+// volume is what the checks need to know about a file system.
+type volume struct {
+	// id tells volumes apart. It is empty when unknown.
+	id string
+	// free is the space available to this user, or -1 when unknown.
+	free int64
+	// fsType is the file system's name in lower case, such as "ext4" or
+	// "ntfs", or empty when unknown.
+	fsType string
+}
+
+// windowsFSTypes are the file systems that follow Windows naming rules
+// whatever the platform, including SMB shares.
+var windowsFSTypes = map[string]bool{
+	"ntfs": true, "ntfs3": true, "vfat": true, "msdos": true, "msdosfs": true,
+	"fat": true, "fat32": true, "exfat": true,
+	"cifs": true, "smb": true, "smb2": true, "smbfs": true,
+}
+
+// namingRules are the limits a file system puts on names and paths.
+type namingRules struct {
+	// windows forbids the characters <>:"\|?* and control characters,
+	// names ending in a dot or space, and device names such as CON. Lengths
+	// are counted in UTF-16 units rather than bytes.
+	windows bool
+	maxName int
+	maxPath int
+}
+
+func namingRulesFor(fsType string) namingRules {
+	maxPath := 4096
+	if runtime.GOOS == "darwin" {
+		maxPath = 1024
+	}
+	switch {
+	case runtime.GOOS == "windows":
+		// MAX_PATH, less the terminating null, which Explorer and many
+		// other programs still keep to.
+		return namingRules{windows: true, maxName: 255, maxPath: 259}
+	case windowsFSTypes[fsType]:
+		return namingRules{windows: true, maxName: 255, maxPath: maxPath}
+	}
+	return namingRules{maxName: 255, maxPath: maxPath}
+}
+
+func (r namingRules) length(s string) int {
+	if r.windows {
+		return len(utf16.Encode([]rune(s)))
+	}
+	return len(s)
+}
+
+// windowsReserved are the device names Windows doesn't allow as file
+// names, with or without an extension.
+var windowsReserved = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+func forbiddenOnWindows(c rune) bool {
+	return c < 0x20 || strings.ContainsRune(`<>:"/\|?*`, c)
+}
+
+// invalid says why name can't be used, or returns "" if it can.
+func (r namingRules) invalid(name string) string {
+	if n := r.length(name); n > r.maxName {
+		return fmt.Sprintf("name is %d characters long, over the limit of %d", n, r.maxName)
+	}
+	if !r.windows {
+		return ""
+	}
+	if i := strings.IndexFunc(name, forbiddenOnWindows); i >= 0 {
+		return fmt.Sprintf("name contains %q", name[i:i+1])
+	}
+	if strings.HasSuffix(name, ".") || strings.HasSuffix(name, " ") {
+		return "name ends in a dot or space"
+	}
+	base, _, _ := strings.Cut(name, ".")
+	if windowsReserved[strings.ToUpper(strings.TrimRight(base, " "))] {
+		return fmt.Sprintf("%s is a reserved device name", base)
+	}
+	return ""
+}
+
+// Preflight checks a plan against the disk before it runs: that the
+// folders involved can be written, that each destination volume has room
+// for the files that have to be copied to it, and that the destination
+// names and paths suit its file system. Excluded and conflicting moves are
+// not checked.
+func (o *Organizer) Preflight(plan []PlannedMove) []PreflightIssue {
+	var issues []PreflightIssue
+	add := func(issue PreflightIssue) {
+		issues = append(issues, issue)
+	}
+
+	volumes := make(map[string]*volume)
+	statDir := func(dir string) *volume {
+		if v, ok := volumes[dir]; ok {
+			return v
+		}
+		v, err := statVolume(dir)
+		if err != nil {
+			volumes[dir] = nil
+			return nil
+		}
+		volumes[dir] = &v
+		return &v
+	}
+	writable := make(map[string]bool)
+	checkWritable := func(dir string) bool {
+		if ok, probed := writable[dir]; probed {
+			return ok
+		}
+		err := probeWrite(dir)
+		writable[dir] = err == nil
+		if err != nil {
+			add(PreflightIssue{Kind: PreflightUnwritable, Blocking: dir == o.sourceDir, Rel: o.rel(dir), Detail: fmt.Sprintf("folder is not writable: %v", err)})
+		}
+		return err == nil
+	}
+	checkedNames := make(map[string]bool)
+	checkName := func(rules namingRules, rel, name string) {
+		if checkedNames[rel] {
+			return
+		}
+		checkedNames[rel] = true
+		if reason := rules.invalid(name); reason != "" {
+			kind := PreflightInvalidName
+			if rules.length(name) > rules.maxName {
+				kind = PreflightPathLength
+			}
+			add(PreflightIssue{Kind: kind, Rel: rel, Detail: reason})
+		}
+	}
+	need := make(map[string]int64)
+	free := make(map[string]int64)
+	volumeDirs := make(map[string]string)
+
+	for _, move := range plan {
+		if move.Excluded || move.Conflict {
+			continue
+		}
+		rel := move.Folder + "/" + move.Name
+		srcDir := filepath.Dir(move.File.Path)
+		existing := existingAncestor(filepath.Dir(move.Dest))
+		if !checkWritable(srcDir) || !checkWritable(existing) {
+			continue
+		}
+
+		dst := statDir(existing)
+		var rules namingRules
+		if dst != nil {
+			rules = namingRulesFor(dst.fsType)
+		} else {
+			rules = namingRulesFor("")
+		}
+		folder := ""
+		for _, part := range strings.Split(move.Folder, "/") {
+			folder = strings.TrimPrefix(folder+"/"+part, "/")
+			checkName(rules, folder, part)
+		}
+		checkName(rules, rel, move.Name)
+		if abs, err := filepath.Abs(move.Dest); err == nil && rules.length(abs) > rules.maxPath {
+			add(PreflightIssue{Kind: PreflightPathLength, Rel: rel,
+				Detail: fmt.Sprintf("path is %d characters long, over the limit of %d", rules.length(abs), rules.maxPath)})
+		}
+
+		src := statDir(srcDir)
+		if src == nil || dst == nil || src.id == "" || src.id == dst.id {
+			continue
+		}
+		// The file is copied to another volume and its original removed.
+		need[dst.id] += move.File.Size
+		free[dst.id] = dst.free
+		volumeDirs[dst.id] = existing
+		f, err := os.Open(move.File.Path)
+		if err != nil {
+			add(PreflightIssue{Kind: PreflightUnreadable, Rel: move.File.Name, Detail: fmt.Sprintf("file can't be read for copying: %v", err)})
+			continue
+		}
+		f.Close()
+		if info, err := os.Stat(move.File.Path); err == nil && runtime.GOOS == "windows" && info.Mode().Perm()&0200 == 0 {
+			add(PreflightIssue{Kind: PreflightReadOnly, Rel: move.File.Name, Detail: "file is read-only, so its original can't be removed after copying"})
+		}
+	}
+
+	for id, n := range need {
+		if f := free[id]; f >= 0 && n > f {
+			add(PreflightIssue{Kind: PreflightSpace, Blocking: true, Need: n, Free: f,
+				Detail: fmt.Sprintf("not enough free space on the volume of %s: %s to copy, %s available", volumeDirs[id], FormatSize(n), FormatSize(f))})
+		}
+	}
+	return issues
+}
+
+// FormatSize writes a number of bytes the way file managers do, such as
+// "1.5 GB".
+func FormatSize(n int64) string {
+	const unit = 1000
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "kMGTPE"[exp])
+}
+
+// rel is path relative to the source directory with forward slashes, or ""
+// for the source directory itself.
+func (o *Organizer) rel(path string) string {
+	rel, err := filepath.Rel(o.sourceDir, path)
+	if err != nil || rel == "." {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
+
+// existingAncestor returns dir, or its closest parent that exists.
+func existingAncestor(dir string) string {
+	for {
+		if _, err := os.Stat(dir); !errors.Is(err, fs.ErrNotExist) {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		dir = parent
+	}
+}
+
+// probeWrite creates and removes a file in dir to check it can be written.
+func probeWrite(dir string) error {
+	f, err := os.CreateTemp(dir, statePrefix+"probe-*")
+	if err != nil {
+		return err
+	}
+	name := f.Name()
+	f.Close()
+	return os.Remove(name)
+}
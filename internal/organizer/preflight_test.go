@@ -0,0 +1,91 @@
+package organizer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestNamingRules verifies Windows file systems reject forbidden
+// characters, trailing dots and device names, and all reject overlong names
+func TestNamingRules(t *testing.T) {
+	windows := namingRules{windows: true, maxName: 255, maxPath: 259}
+	unix := namingRules{maxName: 255, maxPath: 4096}
+	for _, tc := range []struct {
+		name          string
+		windows, unix bool
+	}{
+		{"photo.jpg", true, true},
+		{"what?.jpg", false, true},
+		{"a:b.txt", false, true},
+		{"trailing. ", false, true},
+		{"con.txt", false, true},
+		{"console.txt", true, true},
+		{strings.Repeat("é", 200), true, false},
+	} {
+		if ok := windows.invalid(tc.name) == ""; ok != tc.windows {
+			t.Errorf("Windows rules for %q: expected valid %v, got %v", tc.name, tc.windows, ok)
+		}
+		if ok := unix.invalid(tc.name) == ""; ok != tc.unix {
+			t.Errorf("Unix rules for %q: expected valid %v, got %v", tc.name, tc.unix, ok)
+		}
+	}
+}
+
+// TestPreflightPathLength verifies a destination name too long for the
+// file system is reported without blocking the run
+func TestPreflightPathLength(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileAt(t, filepath.Join(tmpDir, "a.txt"), []byte("a"), time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	org := New(tmpDir, nil)
+	files, err := org.GetFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan := org.Plan(files)
+	if issues := org.Preflight(plan); len(issues) != 0 {
+		t.Fatalf("Expected no issues, got %v", issues)
+	}
+
+	plan[0].Name = strings.Repeat("a", 300) + ".txt"
+	plan[0].Dest = filepath.Join(filepath.Dir(plan[0].Dest), plan[0].Name)
+	issues := org.Preflight(plan)
+	if len(issues) != 1 || issues[0].Kind != PreflightPathLength || issues[0].Blocking {
+		t.Errorf("Expected one non-blocking path length issue, got %+v", issues)
+	}
+}
+
+// TestPreflightUnwritable verifies a source folder that can't be written
+// blocks the run
+func TestPreflightUnwritable(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("permissions don't apply to root")
+	}
+	tmpDir := t.TempDir()
+	writeFileAt(t, filepath.Join(tmpDir, "a.txt"), []byte("a"), time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	org := New(tmpDir, nil)
+	files, err := org.GetFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(tmpDir, 0555); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chmod(tmpDir, 0755)
+
+	issues := org.Preflight(org.Plan(files))
+	if len(issues) != 1 || issues[0].Kind != PreflightUnwritable || !HasBlocking(issues) {
+		t.Errorf("Expected one blocking unwritable issue, got %+v", issues)
+	}
+}
+
+// TestFormatSize verifies sizes are written in decimal units
+func TestFormatSize(t *testing.T) {
+	for n, want := range map[int64]string{999: "999 B", 1500: "1.5 kB", 2_000_000_000: "2.0 GB"} {
+		if got := FormatSize(n); got != want {
+			t.Errorf("FormatSize(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !unix && !windows
+
+package organizer
+
+// processAlive can't tell on this platform, so a lock from this machine is
+// only replaced by a forced unlock.
+func processAlive(pid int) bool {
+	return pid > 0
+}
@@ -0,0 +1,18 @@
+//go:build unix
+
+package organizer
+
+import (
+	"errors"
+	"syscall"
+)
+
+// processAlive reports whether a process with the given ID is running on
+// this machine.
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,169 @@
+package organizer
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dale-tomson/declutter/internal/metadata"
+)
+
+// RenameTemplate names files as they are moved, replacing these tokens:
+//
+//	{date}        the file's date, 2024-03-12
+//	{time}        its time of day, 101500
+//	{year} {month} {day} {hour} {minute} {second}
+//	{name}        the original name without its extension
+//	{ext}         the extension, with its dot
+//	{camera}      the camera model from a photo's EXIF data, or "unknown"
+//	{hash}        the first 8 hex digits of the content's SHA-256
+//	{counter}     a number that makes the name unique, from 1; {counter:3}
+//	              pads it to 3 digits
+//
+// The extension is added when the template has no {ext}. A name that is
+// taken without a {counter} gets "-2", "-3" and so on before its
+// extension. The empty template keeps names as they are, and so does one
+// that renders to no name at all.
+type RenameTemplate string
+
+// DefaultRenameTemplate gives names like 2024-03-12_101500_IMG_1234.jpg.
+const DefaultRenameTemplate RenameTemplate = "{date}_{time}_{name}{ext}"
+
+// maxCounter bounds the search for a free name.
+const maxCounter = 9999
+
+var templateToken = regexp.MustCompile(`\{([a-z]+)(?::(\d+))?\}`)
+
+// ParseRenameTemplate checks a template's tokens.
+func ParseRenameTemplate(s string) (RenameTemplate, error) {
+	s = strings.TrimSpace(s)
+	for _, m := range templateToken.FindAllStringSubmatch(s, -1) {
+		switch m[1] {
+		case "date", "time", "year", "month", "day", "hour", "minute", "second",
+			"name", "ext", "camera", "hash":
+			if m[2] != "" {
+				return "", fmt.Errorf("only {counter} takes a width, not {%s}", m[1])
+			}
+		case "counter":
+		default:
+			return "", fmt.Errorf("unknown token {%s} in the rename template", m[1])
+		}
+	}
+	literal := templateToken.ReplaceAllString(s, "")
+	if strings.ContainsAny(literal, "{}") {
+		return "", errors.New("unmatched brace in the rename template")
+	}
+	if strings.ContainsAny(literal, `/\`) {
+		return "", errors.New("the rename template can't contain a folder separator")
+	}
+	return RenameTemplate(s), nil
+}
+
+func (t RenameTemplate) uses(token string) bool {
+	return strings.Contains(string(t), "{"+token+"}") || strings.Contains(string(t), "{"+token+":")
+}
+
+// templateFields are the values a file's tokens are replaced with.
+type templateFields struct {
+	date         time.Time
+	name, ext    string
+	camera, hash string
+}
+
+// templateFields reads what the template needs about a file that will be
+// moved under name.
+func (o *Organizer) templateFields(file FileInfo, name string, date time.Time) templateFields {
+	ext := filepath.Ext(name)
+	f := templateFields{date: date, name: strings.TrimSuffix(name, ext), ext: ext}
+	if o.options.RenameTemplate.uses("camera") {
+		f.camera = "unknown"
+		if model, err := metadata.CameraModel(file.Path); err == nil && strings.TrimSpace(model) != "" {
+			f.camera = model
+		}
+	}
+	if o.options.RenameTemplate.uses("hash") {
+		if sum, err := hashFile(file.Path); err == nil {
+			f.hash = sum[:8]
+		} else {
+			o.log(fmt.Sprintf("Warning: Could not hash %s: %v", file.Name, err))
+		}
+	}
+	return f
+}
+
+// firstCounter is the counter tried first: 1 for a template with {counter},
+// otherwise 0 for no suffix.
+func (t RenameTemplate) firstCounter() int {
+	if t.uses("counter") {
+		return 1
+	}
+	return 0
+}
+
+// render builds the name for one counter value. A template that leaves
+// nothing but dots and spaces before the extension, such as "{ext}", keeps
+// the original name instead.
+func (t RenameTemplate) render(f templateFields, counter int) string {
+	hasExt := false
+	name := templateToken.ReplaceAllStringFunc(string(t), func(token string) string {
+		m := templateToken.FindStringSubmatch(token)
+		switch m[1] {
+		case "date":
+			return f.date.Format("2006-01-02")
+		case "time":
+			return f.date.Format("150405")
+		case "year":
+			return f.date.Format("2006")
+		case "month":
+			return f.date.Format("01")
+		case "day":
+			return f.date.Format("02")
+		case "hour":
+			return f.date.Format("15")
+		case "minute":
+			return f.date.Format("04")
+		case "second":
+			return f.date.Format("05")
+		case "name":
+			return f.name
+		case "ext":
+			hasExt = true
+			return f.ext
+		case "camera":
+			// A model never names a folder, nor breaks the name on
+			// Windows and SMB shares.
+			return strings.Map(func(c rune) rune {
+				if forbiddenOnWindows(c) {
+					return '-'
+				}
+				return c
+			}, f.camera)
+		case "hash":
+			return f.hash
+		case "counter":
+			width, _ := strconv.Atoi(m[2])
+			return fmt.Sprintf("%0*d", width, counter)
+		}
+		return token
+	})
+	stem, ext := name, f.ext
+	if hasExt {
+		// The counter goes before the extension if the template ends in it.
+		if strings.HasSuffix(name, f.ext) {
+			stem = strings.TrimSuffix(name, f.ext)
+		} else {
+			ext = ""
+		}
+	}
+	if strings.Trim(stem, ". ") == "" {
+		stem = f.name
+	}
+	if !t.uses("counter") && counter > 1 {
+		stem += "-" + strconv.Itoa(counter)
+	}
+	return stem + ext
+}
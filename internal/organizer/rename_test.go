@@ -0,0 +1,101 @@
+package organizer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestParseRenameTemplate verifies templates with unknown tokens, stray
+// braces or folder separators are rejected
+func TestParseRenameTemplate(t *testing.T) {
+	for _, s := range []string{"", "{date}_{time}_{name}{ext}", "{camera}-{hash}-{counter:4}", "photo {counter}"} {
+		if _, err := ParseRenameTemplate(s); err != nil {
+			t.Errorf("ParseRenameTemplate(%q) failed: %v", s, err)
+		}
+	}
+	for _, s := range []string{"{when}", "{date:4}", "{date", "{year}/{name}", `{year}\{name}`} {
+		if _, err := ParseRenameTemplate(s); err == nil {
+			t.Errorf("ParseRenameTemplate(%q) succeeded, want an error", s)
+		}
+	}
+}
+
+// TestRenameTemplateRender verifies each token and where the counter goes
+func TestRenameTemplateRender(t *testing.T) {
+	fields := templateFields{
+		date: time.Date(2024, 3, 12, 10, 15, 0, 0, time.UTC),
+		name: "IMG_1234", ext: ".jpg",
+		camera: "EOS R5/II", hash: "0123abcd",
+	}
+	tests := []struct {
+		template RenameTemplate
+		counter  int
+		want     string
+	}{
+		{DefaultRenameTemplate, 0, "2024-03-12_101500_IMG_1234.jpg"},
+		{DefaultRenameTemplate, 2, "2024-03-12_101500_IMG_1234-2.jpg"},
+		{"{date}_{name}", 0, "2024-03-12_IMG_1234.jpg"},
+		{"{date}_{name}", 3, "2024-03-12_IMG_1234-3.jpg"},
+		{"{year}{month}{day}-{counter:3}{ext}", 7, "20240312-007.jpg"},
+		{"{hour}{minute}{second} {camera} {hash}", 0, "101500 EOS R5-II 0123abcd.jpg"},
+		{"{ext}", 0, "IMG_1234.jpg"},
+		{"{ext}", 2, "IMG_1234-2.jpg"},
+		{". {ext}", 0, "IMG_1234.jpg"},
+	}
+	for _, tt := range tests {
+		if got := tt.template.render(fields, tt.counter); got != tt.want {
+			t.Errorf("%q with counter %d = %q, want %q", tt.template, tt.counter, got, tt.want)
+		}
+	}
+}
+
+// TestRenameTemplateRenderSafe verifies a camera model can't put characters
+// Windows forbids into a name, and an empty model falls back to the
+// original name
+func TestRenameTemplateRenderSafe(t *testing.T) {
+	fields := templateFields{name: "IMG_1234", ext: ".jpg", camera: `Cam<1>: "A|B"?*\x`}
+	if got := RenameTemplate("{camera}").render(fields, 0); got != "Cam-1-- -A-B----x.jpg" {
+		t.Errorf("Expected forbidden characters replaced, got %q", got)
+	}
+	fields.camera = ""
+	if got := RenameTemplate("{camera}{ext}").render(fields, 0); got != "IMG_1234.jpg" {
+		t.Errorf("Expected the original name for an empty model, got %q", got)
+	}
+}
+
+// TestPlanRenameTemplate verifies files are renamed without colliding
+// with each other or existing files, and undo restores the original names
+func TestPlanRenameTemplate(t *testing.T) {
+	tmpDir := t.TempDir()
+	day := time.Date(2024, 3, 12, 10, 15, 0, 0, time.UTC)
+	writeFileAt(t, filepath.Join(tmpDir, "a.txt"), []byte("a"), day)
+	writeFileAt(t, filepath.Join(tmpDir, "b.txt"), []byte("b"), day.Add(time.Hour))
+	writeFileAt(t, filepath.Join(tmpDir, "c.txt"), []byte("c"), day.Add(2*time.Hour))
+	if err := os.MkdirAll(filepath.Join(tmpDir, "2024", "03-March"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFileAt(t, filepath.Join(tmpDir, "2024", "03-March", "2024-03-12-2.txt"), []byte("old"), day)
+
+	org := NewWithOptions(tmpDir, Options{RenameTemplate: "{date}{ext}", TimeZone: TimeZonePolicy{Mode: TimeZoneUTC}}, nil)
+	results := runAndCollect(t, org)
+	want := []string{"2024-03-12.txt", "2024-03-12-3.txt", "2024-03-12-4.txt"}
+	if len(results) != len(want) {
+		t.Fatalf("Expected %d results, got %+v", len(want), results)
+	}
+	for i, r := range results {
+		if r.Status != StatusMoved || r.Move.Name != want[i] {
+			t.Errorf("Expected %s moved as %s, got %s as %s", r.Move.File.Name, want[i], r.Status, r.Move.Name)
+		}
+	}
+
+	if restored, failed := org.Undo(results); restored != 3 || failed != 0 {
+		t.Fatalf("Expected 3 restored, got %d restored, %d failed", restored, failed)
+	}
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, name)); err != nil {
+			t.Errorf("Expected %s back under its original name: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,320 @@
+package organizer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const sniffLen = 512
+
+type signature struct {
+	mime  string
+	exts  []string
+	match func(head []byte) bool
+}
+
+func prefix(magic ...byte) func([]byte) bool {
+	return func(head []byte) bool {
+		return bytes.HasPrefix(head, magic)
+	}
+}
+
+func ftypBrand(brands ...string) func([]byte) bool {
+	return func(head []byte) bool {
+		if len(head) < 12 || string(head[4:8]) != "ftyp" {
+			return false
+		}
+		brand := strings.TrimSpace(string(head[8:12]))
+		for _, b := range brands {
+			if brand == b {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func riff(format string) func([]byte) bool {
+	return func(head []byte) bool {
+		return len(head) >= 12 && string(head[0:4]) == "RIFF" && string(head[8:12]) == format
+	}
+}
+
+// isBMP checks the bitmap file header as well as its "BM" magic: a file
+// size that holds both headers, pixel data after them and a known DIB
+// header size, so text starting with "BM" is not taken for an image.
+func isBMP(h []byte) bool {
+	if len(h) < 18 || h[0] != 'B' || h[1] != 'M' {
+		return false
+	}
+	size := binary.LittleEndian.Uint32(h[2:])
+	offset := binary.LittleEndian.Uint32(h[10:])
+	dib := binary.LittleEndian.Uint32(h[14:])
+	switch dib {
+	case 12, 40, 52, 56, 108, 124:
+	default:
+		return false
+	}
+	return size >= 14+dib && offset >= 14+dib && offset <= size
+}
+
+// isPE checks that an "MZ" header's e_lfanew field points at a "PE\0\0"
+// signature within head.
+func isPE(h []byte) bool {
+	if len(h) < 0x40 || h[0] != 'M' || h[1] != 'Z' {
+		return false
+	}
+	pe := binary.LittleEndian.Uint32(h[0x3C:])
+	return pe <= uint32(len(h)-4) && string(h[pe:pe+4]) == "PE\x00\x00"
+}
+
+// isMP3 accepts an ID3 tag or an MPEG audio frame header with a valid
+// version, layer, bitrate and sample rate, not just the frame sync bits.
+func isMP3(h []byte) bool {
+	if bytes.HasPrefix(h, []byte("ID3")) {
+		return true
+	}
+	if len(h) < 3 || h[0] != 0xFF || h[1]&0xE0 != 0xE0 {
+		return false
+	}
+	version := h[1] >> 3 & 0x03
+	layer := h[1] >> 1 & 0x03
+	bitrate := h[2] >> 4
+	sampleRate := h[2] >> 2 & 0x03
+	return version != 1 && layer != 0 && bitrate != 0 && bitrate != 0x0F && sampleRate != 0x03
+}
+
+// signatures is checked in order, so more specific entries come first.
+var signatures = []signature{
+	{"image/jpeg", []string{".jpg", ".jpeg", ".jpe", ".jfif"}, prefix(0xFF, 0xD8, 0xFF)},
+	{"image/png", []string{".png"}, prefix(0x89, 'P', 'N', 'G', 0x0D, 0x0A, 0x1A, 0x0A)},
+	{"image/gif", []string{".gif"}, func(h []byte) bool {
+		return bytes.HasPrefix(h, []byte("GIF87a")) || bytes.HasPrefix(h, []byte("GIF89a"))
+	}},
+	{"image/webp", []string{".webp"}, riff("WEBP")},
+	{"image/bmp", []string{".bmp"}, isBMP},
+	{"image/tiff", []string{".tif", ".tiff", ".dng", ".nef", ".cr2", ".arw"}, func(h []byte) bool {
+		return bytes.HasPrefix(h, []byte{'I', 'I', 0x2A, 0x00}) || bytes.HasPrefix(h, []byte{'M', 'M', 0x00, 0x2A})
+	}},
+	{"image/heic", []string{".heic", ".heif"}, ftypBrand("heic", "heix", "hevc", "heim", "heis", "mif1", "msf1")},
+	{"image/avif", []string{".avif"}, ftypBrand("avif", "avis")},
+	{"video/quicktime", []string{".mov", ".qt"}, ftypBrand("qt")},
+	{"video/3gpp", []string{".3gp", ".3g2"}, ftypBrand("3gp4", "3gp5", "3gp6", "3g2a")},
+	{"audio/mp4", []string{".m4a", ".m4b"}, ftypBrand("M4A", "M4B")},
+	{"video/mp4", []string{".mp4", ".m4v"}, ftypBrand("isom", "iso2", "iso4", "iso5", "iso6", "mp41", "mp42", "avc1", "M4V", "dash")},
+	{"video/x-matroska", []string{".mkv", ".mka", ".webm"}, prefix(0x1A, 0x45, 0xDF, 0xA3)},
+	{"video/x-msvideo", []string{".avi"}, riff("AVI ")},
+	{"audio/wav", []string{".wav"}, riff("WAVE")},
+	{"audio/flac", []string{".flac"}, prefix('f', 'L', 'a', 'C')},
+	{"audio/ogg", []string{".ogg", ".oga", ".opus", ".ogv"}, prefix('O', 'g', 'g', 'S')},
+	{"audio/mpeg", []string{".mp3"}, isMP3},
+	{"application/pdf", []string{".pdf", ".ai"}, prefix('%', 'P', 'D', 'F', '-')},
+	{"application/zip", []string{".zip"}, func(h []byte) bool {
+		return bytes.HasPrefix(h, []byte{'P', 'K', 0x03, 0x04}) || bytes.HasPrefix(h, []byte{'P', 'K', 0x05, 0x06})
+	}},
+	{"application/gzip", []string{".gz", ".tgz"}, prefix(0x1F, 0x8B)},
+	{"application/x-7z-compressed", []string{".7z"}, prefix('7', 'z', 0xBC, 0xAF, 0x27, 0x1C)},
+	{"application/vnd.rar", []string{".rar"}, prefix('R', 'a', 'r', '!', 0x1A, 0x07)},
+	{"application/zstd", []string{".zst"}, prefix(0x28, 0xB5, 0x2F, 0xFD)},
+	{"application/x-msdownload", []string{".exe", ".dll", ".sys", ".scr"}, isPE},
+	{"application/x-elf", []string{"", ".so", ".bin", ".elf"}, prefix(0x7F, 'E', 'L', 'F')},
+	{"application/x-ole-storage", []string{".doc", ".xls", ".ppt", ".msg", ".msi"}, prefix(0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1)},
+}
+
+// zipFormats are ZIP-based formats, recognised by refineZip from their
+// "mimetype" entry or from a member only that format contains.
+var zipFormats = []signature{
+	{"application/vnd.openxmlformats-officedocument.wordprocessingml.document", []string{".docx", ".docm", ".dotx"}, nil},
+	{"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", []string{".xlsx", ".xlsm", ".xltx"}, nil},
+	{"application/vnd.openxmlformats-officedocument.presentationml.presentation", []string{".pptx", ".pptm", ".potx"}, nil},
+	{"application/vnd.oasis.opendocument.text", []string{".odt"}, nil},
+	{"application/vnd.oasis.opendocument.spreadsheet", []string{".ods"}, nil},
+	{"application/vnd.oasis.opendocument.presentation", []string{".odp"}, nil},
+	{"application/epub+zip", []string{".epub"}, nil},
+	{"application/java-archive", []string{".jar", ".apk", ".aar"}, nil},
+}
+
+var zipMembers = map[string]string{
+	"word/document.xml":    "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	"xl/workbook.xml":      "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"ppt/presentation.xml": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	"META-INF/MANIFEST.MF": "application/java-archive",
+	"AndroidManifest.xml":  "application/java-archive",
+}
+
+// Text content is never reported as a mismatch, since plain text is stored
+// under far too many extensions to judge.
+var textMIMEPrefixes = []string{"text/", "application/json", "application/xml"}
+
+// DetectContentType reads the start of the file at path and returns its MIME
+// type based on magic numbers, falling back to net/http's sniffer.
+func DetectContentType(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", err
+	}
+	head = head[:n]
+
+	for _, sig := range signatures {
+		if !sig.match(head) {
+			continue
+		}
+		if sig.mime == "application/zip" {
+			return refineZip(f, sig.mime), nil
+		}
+		return sig.mime, nil
+	}
+
+	if n == 0 {
+		return "application/octet-stream", nil
+	}
+	mime := http.DetectContentType(head)
+	if i := strings.IndexByte(mime, ';'); i >= 0 {
+		mime = mime[:i]
+	}
+	if ExtensionsFor(mime) != nil {
+		// net/http only checks the magic of types such as BMP, which the
+		// stricter signatures above already rejected.
+		if hasBinaryBytes(head) {
+			return "application/octet-stream", nil
+		}
+		return "text/plain", nil
+	}
+	return mime, nil
+}
+
+// hasBinaryBytes reports whether head holds control bytes that plain text
+// does not, the same ones net/http's sniffer looks for.
+func hasBinaryBytes(head []byte) bool {
+	for _, b := range head {
+		if b <= 0x08 || b == 0x0B || 0x0E <= b && b <= 0x1A || 0x1C <= b && b <= 0x1F {
+			return true
+		}
+	}
+	return false
+}
+
+func refineZip(f *os.File, fallback string) string {
+	info, err := f.Stat()
+	if err != nil {
+		return fallback
+	}
+	zr, err := zip.NewReader(f, info.Size())
+	if err != nil {
+		return fallback
+	}
+	for _, zf := range zr.File {
+		if zf.Name == "mimetype" {
+			rc, err := zf.Open()
+			if err != nil {
+				continue
+			}
+			data, _ := io.ReadAll(io.LimitReader(rc, 128))
+			rc.Close()
+			if mime := strings.TrimSpace(string(data)); ExtensionsFor(mime) != nil {
+				return mime
+			}
+		}
+		if mime, ok := zipMembers[zf.Name]; ok {
+			return mime
+		}
+	}
+	return fallback
+}
+
+// ExtensionsFor returns the known extensions for a MIME type, preferred first.
+func ExtensionsFor(mime string) []string {
+	for _, list := range [][]signature{signatures, zipFormats} {
+		for _, sig := range list {
+			if sig.mime == mime {
+				return sig.exts
+			}
+		}
+	}
+	return nil
+}
+
+func isTextMIME(mime string) bool {
+	for _, p := range textMIMEPrefixes {
+		if strings.HasPrefix(mime, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// suggestedExtension returns the extension a file with the given name and
+// content type should carry, or "" when the current one is acceptable or the
+// type is too generic to judge.
+func suggestedExtension(name, mime string) string {
+	if mime == "" || mime == "application/octet-stream" || isTextMIME(mime) {
+		return ""
+	}
+
+	exts := ExtensionsFor(mime)
+	if len(exts) == 0 {
+		return ""
+	}
+
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, e := range exts {
+		if e == ext {
+			return ""
+		}
+	}
+	// Office and ODF documents, EPUBs and JARs are ZIP files, so a plain
+	// ZIP carrying one of those extensions is not a mismatch.
+	if mime == "application/zip" {
+		for _, zf := range zipFormats {
+			for _, e := range zf.exts {
+				if e == ext {
+					return ""
+				}
+			}
+		}
+	}
+	return exts[0]
+}
+
+func replaceExtension(name, ext string) string {
+	if ext == "" {
+		return name
+	}
+	current := filepath.Ext(name)
+	if looksLikeExtension(current) {
+		return strings.TrimSuffix(name, current) + ext
+	}
+	return name + ext
+}
+
+// looksLikeExtension rejects suffixes such as ".2023" or ". final" so that
+// "scan.2023" becomes "scan.2023.pdf" rather than "scan.pdf".
+func looksLikeExtension(ext string) bool {
+	if len(ext) < 2 || len(ext) > 6 {
+		return false
+	}
+	hasLetter := false
+	for _, r := range ext[1:] {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+			hasLetter = true
+		case r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+	return hasLetter
+}
@@ -0,0 +1,215 @@
+package organizer
+
+import (
+	"archive/zip"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var pngHeader = []byte{0x89, 'P', 'N', 'G', 0x0D, 0x0A, 0x1A, 0x0A, 0, 0, 0, 0x0D, 'I', 'H', 'D', 'R'}
+
+// bmpHeader is a bitmap file header and BITMAPINFOHEADER for 4 bytes of
+// pixel data.
+func bmpHeader() []byte {
+	h := make([]byte, 58)
+	copy(h, "BM")
+	binary.LittleEndian.PutUint32(h[2:], 58)
+	binary.LittleEndian.PutUint32(h[10:], 54)
+	binary.LittleEndian.PutUint32(h[14:], 40)
+	return h
+}
+
+// peHeader is an MZ stub whose e_lfanew points at a PE signature.
+func peHeader() []byte {
+	h := make([]byte, 0x48)
+	copy(h, "MZ")
+	binary.LittleEndian.PutUint32(h[0x3C:], 0x40)
+	copy(h[0x40:], "PE\x00\x00")
+	return h
+}
+
+func writeZip(t *testing.T, path string, members ...string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create zip: %v", err)
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for _, m := range members {
+		w, err := zw.Create(m)
+		if err != nil {
+			t.Fatalf("Failed to add %s: %v", m, err)
+		}
+		w.Write([]byte("<xml/>"))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to close zip: %v", err)
+	}
+}
+
+// TestDetectContentType verifies magic-number detection for common formats
+func TestDetectContentType(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string][]byte{
+		"image.bin":  pngHeader,
+		"scan":       []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n"),
+		"photo.jpg":  {0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x10},
+		"video.dat":  append([]byte{0, 0, 0, 0x18}, []byte("ftypmp42\x00\x00\x00\x00")...),
+		"notes.txt":  []byte("hello world\n"),
+		"picture":    bmpHeader(),
+		"setup":      peHeader(),
+		"song":       {0xFF, 0xFB, 0x90, 0x44, 0, 0, 0, 0},
+		"bmw.txt":    []byte("BMW service history\n"),
+		"mz.txt":     []byte("MZ-80 notes and more text\n"),
+		"sync.bin":   {0xFF, 0xFF, 0x00, 0x00},
+		"empty.file": {},
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), data, 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+	writeZip(t, filepath.Join(tmpDir, "report.zip"), "[Content_Types].xml", "word/document.xml")
+	writeZip(t, filepath.Join(tmpDir, "plain.zip"), "a.txt")
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"image.bin", "image/png"},
+		{"scan", "application/pdf"},
+		{"photo.jpg", "image/jpeg"},
+		{"video.dat", "video/mp4"},
+		{"notes.txt", "text/plain"},
+		{"picture", "image/bmp"},
+		{"setup", "application/x-msdownload"},
+		{"song", "audio/mpeg"},
+		{"bmw.txt", "text/plain"},
+		{"mz.txt", "text/plain"},
+		{"sync.bin", "application/octet-stream"},
+		{"empty.file", "application/octet-stream"},
+		{"report.zip", "application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+		{"plain.zip", "application/zip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mime, err := DetectContentType(filepath.Join(tmpDir, tt.name))
+			if err != nil {
+				t.Fatalf("DetectContentType failed: %v", err)
+			}
+			if mime != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, mime)
+			}
+		})
+	}
+}
+
+// TestSuggestedExtension verifies which names are reported as mismatched
+func TestSuggestedExtension(t *testing.T) {
+	tests := []struct {
+		name     string
+		mime     string
+		expected string
+	}{
+		{"photo.jpg", "image/png", ".png"},
+		{"photo.JPEG", "image/jpeg", ""},
+		{"scan", "application/pdf", ".pdf"},
+		{"report.docx", "application/zip", ""},
+		{"report.pdf", "application/vnd.openxmlformats-officedocument.wordprocessingml.document", ".docx"},
+		{"README", "text/plain", ""},
+		{"data.bin", "application/octet-stream", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suggestedExtension(tt.name, tt.mime); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestReplaceExtension verifies extensions are replaced or appended
+func TestReplaceExtension(t *testing.T) {
+	tests := []struct {
+		name     string
+		ext      string
+		expected string
+	}{
+		{"photo.jpg", ".png", "photo.png"},
+		{"scan", ".pdf", "scan.pdf"},
+		{"scan.2023", ".pdf", "scan.2023.pdf"},
+		{"archive.tar", "", "archive.tar"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replaceExtension(tt.name, tt.ext); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestOrganizeFilesFixExtensions verifies mislabeled files are renamed on move
+func TestOrganizeFilesFixExtensions(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	modTime := time.Date(2024, 2, 10, 9, 0, 0, 0, time.UTC)
+	for name, data := range map[string][]byte{
+		"picture.jpg": pngHeader,
+		"invoice":     []byte("%PDF-1.4\n"),
+		"notes.md":    []byte("# notes\n"),
+		"BMW":         []byte("BMW service history\n"),
+		"MZ notes":    []byte("MZ-80 notes and more text\n"),
+	} {
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("Failed to set mod time: %v", err)
+		}
+	}
+
+	var logMessages []string
+	org := NewWithOptions(tmpDir, Options{FixExtensions: true}, func(msg string) {
+		logMessages = append(logMessages, msg)
+	})
+	files, err := org.GetFiles()
+	if err != nil {
+		t.Fatalf("GetFiles failed: %v", err)
+	}
+
+	mismatches := 0
+	for _, f := range files {
+		if f.ExtensionMismatch() {
+			mismatches++
+		}
+	}
+	if mismatches != 2 {
+		t.Errorf("Expected 2 mismatches, got %d", mismatches)
+	}
+
+	if _, _, err := org.OrganizeFiles(files); err != nil {
+		t.Fatalf("OrganizeFiles failed: %v", err)
+	}
+
+	monthFolder := filepath.Join(tmpDir, "2024", "02-February")
+	for _, name := range []string{"picture.png", "invoice.pdf", "notes.md", "BMW", "MZ notes"} {
+		if _, err := os.Stat(filepath.Join(monthFolder, name)); err != nil {
+			t.Errorf("Expected %s in month folder: %v", name, err)
+		}
+	}
+
+	if len(logMessages) == 0 {
+		t.Error("Expected mismatches to be logged")
+	}
+}
@@ -0,0 +1,102 @@
+package organizer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	// Embedded so named zones resolve on systems without a zoneinfo
+	// database, which includes most Windows installs.
+	_ "time/tzdata"
+)
+
+type TimeZoneMode string
+
+const (
+	// TimeZoneLocal places files by the machine's local time. This is the
+	// default and matches how the modification time was always used.
+	TimeZoneLocal TimeZoneMode = "local"
+	TimeZoneUTC   TimeZoneMode = "utc"
+	// TimeZoneFixed places files by the time in TimeZonePolicy.Location.
+	TimeZoneFixed TimeZoneMode = "fixed"
+	// TimeZoneMetadata keeps the offset recorded in the file's metadata,
+	// so a photo taken abroad lands on the day it was taken there. Dates
+	// without an offset fall back to local time.
+	TimeZoneMetadata TimeZoneMode = "metadata"
+)
+
+// TimeZonePolicy decides which calendar day, and therefore which Year/Month
+// folder, an instant belongs to. Floating dates (wall-clock times without a
+// zone, such as those in file names) are never converted, whatever the mode.
+type TimeZonePolicy struct {
+	Mode     TimeZoneMode
+	Location *time.Location
+}
+
+// ParseTimeZonePolicy accepts "local", "utc", "metadata", a fixed offset
+// such as "+02:00" or an IANA zone name such as "Europe/Berlin".
+func ParseTimeZonePolicy(s string) (TimeZonePolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", string(TimeZoneLocal):
+		return TimeZonePolicy{Mode: TimeZoneLocal}, nil
+	case string(TimeZoneUTC), "z":
+		return TimeZonePolicy{Mode: TimeZoneUTC}, nil
+	case string(TimeZoneMetadata):
+		return TimeZonePolicy{Mode: TimeZoneMetadata}, nil
+	}
+
+	s = strings.TrimSpace(s)
+	if s[0] == '+' || s[0] == '-' {
+		for _, layout := range []string{"-07:00", "-0700", "-07"} {
+			if t, err := time.Parse(layout, s); err == nil {
+				_, offset := t.Zone()
+				return TimeZonePolicy{Mode: TimeZoneFixed, Location: time.FixedZone("UTC"+s, offset)}, nil
+			}
+		}
+		return TimeZonePolicy{}, fmt.Errorf("invalid time zone offset %q", s)
+	}
+
+	loc, err := time.LoadLocation(s)
+	if err != nil {
+		return TimeZonePolicy{}, fmt.Errorf("unknown time zone %q: %w", s, err)
+	}
+	return TimeZonePolicy{Mode: TimeZoneFixed, Location: loc}, nil
+}
+
+func (p TimeZonePolicy) String() string {
+	if p.Mode == TimeZoneFixed && p.Location != nil {
+		return p.Location.String()
+	}
+	if p.Mode == "" {
+		return string(TimeZoneLocal)
+	}
+	return string(p.Mode)
+}
+
+// Apply returns t in the location whose calendar decides its folder. zoned
+// reports whether t carries an offset recorded by its source, which only
+// TimeZoneMetadata keeps.
+func (p TimeZonePolicy) Apply(t time.Time, floating, zoned bool) time.Time {
+	if floating {
+		return t
+	}
+	switch p.Mode {
+	case TimeZoneUTC:
+		return t.UTC()
+	case TimeZoneFixed:
+		if p.Location != nil {
+			return t.In(p.Location)
+		}
+	case TimeZoneMetadata:
+		if zoned {
+			return t
+		}
+	}
+	return t.In(time.Local)
+}
+
+// folderTime is the time, in the policy's location, used to pick the file's
+// Year/Month folder.
+func (o *Organizer) folderTime(file FileInfo) time.Time {
+	return o.options.TimeZone.Apply(file.EffectiveDate(), file.DateFloating, file.DateZoned)
+}
@@ -0,0 +1,200 @@
+package organizer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("Failed to load %s: %v", name, err)
+	}
+	return loc
+}
+
+// TestParseTimeZonePolicy verifies the accepted policy spellings
+func TestParseTimeZonePolicy(t *testing.T) {
+	tests := []struct {
+		input    string
+		mode     TimeZoneMode
+		expected string
+	}{
+		{"", TimeZoneLocal, "local"},
+		{"Local", TimeZoneLocal, "local"},
+		{"UTC", TimeZoneUTC, "utc"},
+		{"metadata", TimeZoneMetadata, "metadata"},
+		{"+02:00", TimeZoneFixed, "UTC+02:00"},
+		{"-0530", TimeZoneFixed, "UTC-0530"},
+		{"Europe/Berlin", TimeZoneFixed, "Europe/Berlin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			p, err := ParseTimeZonePolicy(tt.input)
+			if err != nil {
+				t.Fatalf("ParseTimeZonePolicy failed: %v", err)
+			}
+			if p.Mode != tt.mode {
+				t.Errorf("Expected mode %s, got %s", tt.mode, p.Mode)
+			}
+			if p.String() != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, p.String())
+			}
+		})
+	}
+
+	for _, bad := range []string{"+25:00", "Mars/Olympus_Mons"} {
+		if _, err := ParseTimeZonePolicy(bad); err == nil {
+			t.Errorf("Expected error for %q", bad)
+		}
+	}
+}
+
+// TestTimeZonePolicyBoundaries verifies folder assignment around midnight,
+// month and year ends and DST transitions
+func TestTimeZonePolicyBoundaries(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	newYork := mustLoadLocation(t, "America/New_York")
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+
+	tests := []struct {
+		name     string
+		policy   TimeZonePolicy
+		instant  time.Time
+		floating bool
+		zoned    bool
+		expected string
+	}{
+		{
+			name:     "new year's eve in UTC stays in December",
+			policy:   TimeZonePolicy{Mode: TimeZoneUTC},
+			instant:  time.Date(2023, 12, 31, 23, 30, 0, 0, time.UTC),
+			expected: filepath.Join("2023", "12-December"),
+		},
+		{
+			name:     "new year's eve in UTC is January in Tokyo",
+			policy:   TimeZonePolicy{Mode: TimeZoneFixed, Location: tokyo},
+			instant:  time.Date(2023, 12, 31, 23, 30, 0, 0, time.UTC),
+			expected: filepath.Join("2024", "01-January"),
+		},
+		{
+			name:     "new year's eve in New York converted to UTC",
+			policy:   TimeZonePolicy{Mode: TimeZoneUTC},
+			instant:  time.Date(2023, 12, 31, 23, 30, 0, 0, newYork),
+			expected: filepath.Join("2024", "01-January"),
+		},
+		{
+			name:     "metadata mode keeps the recorded offset",
+			policy:   TimeZonePolicy{Mode: TimeZoneMetadata},
+			instant:  time.Date(2023, 12, 31, 23, 30, 0, 0, newYork),
+			zoned:    true,
+			expected: filepath.Join("2023", "12-December"),
+		},
+		{
+			name:     "month end after DST starts in Berlin (UTC+2)",
+			policy:   TimeZonePolicy{Mode: TimeZoneFixed, Location: berlin},
+			instant:  time.Date(2024, 3, 31, 22, 30, 0, 0, time.UTC),
+			expected: filepath.Join("2024", "04-April"),
+		},
+		{
+			name:     "month end after DST ends in Berlin (UTC+1)",
+			policy:   TimeZonePolicy{Mode: TimeZoneFixed, Location: berlin},
+			instant:  time.Date(2024, 10, 31, 22, 30, 0, 0, time.UTC),
+			expected: filepath.Join("2024", "10-October"),
+		},
+		{
+			name:     "fixed offset just before midnight",
+			policy:   TimeZonePolicy{Mode: TimeZoneFixed, Location: time.FixedZone("", -5*3600)},
+			instant:  time.Date(2024, 3, 1, 4, 59, 0, 0, time.UTC),
+			expected: filepath.Join("2024", "02-February"),
+		},
+		{
+			name:     "floating times are never converted",
+			policy:   TimeZonePolicy{Mode: TimeZoneFixed, Location: tokyo},
+			instant:  time.Date(2023, 12, 31, 23, 30, 0, 0, time.UTC),
+			floating: true,
+			expected: filepath.Join("2023", "12-December"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetYearMonthPath("", tt.policy.Apply(tt.instant, tt.floating, tt.zoned))
+			if got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestTimeZoneMetadataWithoutOffset verifies metadata mode places dates
+// whose source recorded no offset by local time, around midnight and the
+// ends of the month and year
+func TestTimeZoneMetadataWithoutOffset(t *testing.T) {
+	defer func(local *time.Location) { time.Local = local }(time.Local)
+	time.Local = mustLoadLocation(t, "Asia/Tokyo")
+	policy := TimeZonePolicy{Mode: TimeZoneMetadata}
+
+	// 20:30 UTC on new year's eve is 05:30 on new year's day in Tokyo.
+	newYear := time.Date(2023, 12, 31, 20, 30, 0, 0, time.UTC)
+	if got := GetYearMonthPath("", policy.Apply(newYear, false, false)); got != filepath.Join("2024", "01-January") {
+		t.Errorf("Expected a date without an offset in January, got %s", got)
+	}
+	if got := GetYearMonthPath("", policy.Apply(newYear, false, true)); got != filepath.Join("2023", "12-December") {
+		t.Errorf("Expected a recorded UTC date to stay in December, got %s", got)
+	}
+
+	// An MP4's mvhd date is UTC without a recorded offset.
+	tmpDir := t.TempDir()
+	monthEnd := time.Date(2024, 2, 29, 15, 30, 0, 0, time.UTC)
+	writeMP4(t, filepath.Join(tmpDir, "clip.mp4"), monthEnd, monthEnd)
+	org := NewWithOptions(tmpDir, Options{DateSources: []DateSource{DateSourceMedia}, TimeZone: policy}, nil)
+	files, err := org.GetFiles()
+	if err != nil || len(files) != 1 {
+		t.Fatalf("GetFiles returned %v, %v", files, err)
+	}
+	if files[0].DateSource != DateSourceMedia || files[0].DateZoned {
+		t.Errorf("Expected a media date without an offset, got %+v", files[0])
+	}
+	if _, _, err := org.OrganizeFiles(files); err != nil {
+		t.Fatalf("OrganizeFiles failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "2024", "03-March", "clip.mp4")); err != nil {
+		t.Errorf("Expected the clip in March: %v", err)
+	}
+}
+
+// TestOrganizeFilesTimeZone verifies OrganizeFiles honours the policy
+func TestOrganizeFilesTimeZone(t *testing.T) {
+	tmpDir := t.TempDir()
+	modTime := time.Date(2023, 12, 31, 23, 30, 0, 0, time.UTC)
+	path := filepath.Join(tmpDir, "party.jpg")
+	if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set mod time: %v", err)
+	}
+
+	policy, err := ParseTimeZonePolicy("+01:00")
+	if err != nil {
+		t.Fatalf("ParseTimeZonePolicy failed: %v", err)
+	}
+	org := NewWithOptions(tmpDir, Options{TimeZone: policy}, nil)
+	files, err := org.GetFiles()
+	if err != nil {
+		t.Fatalf("GetFiles failed: %v", err)
+	}
+	if _, _, err := org.OrganizeFiles(files); err != nil {
+		t.Fatalf("OrganizeFiles failed: %v", err)
+	}
+
+	expected := filepath.Join(tmpDir, "2024", "01-January", "party.jpg")
+	if _, err := os.Stat(expected); err != nil {
+		t.Errorf("Expected file at %s: %v", expected, err)
+	}
+}
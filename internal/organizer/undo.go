@@ -0,0 +1,90 @@
+package organizer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Undo moves the files a run moved back to where they came from, under
+// their original names, newest move first. A file is left in place when it
+// is no longer at its destination or its original path has been taken
+// since. Restored files are dropped from their folder's checksum file, and
+// year and month folders left empty afterwards are removed. Nothing is
+// restored while another run holds the folder.
+func (o *Organizer) Undo(results []Result) (restored, failed int) {
+	l, err := o.lock(o.sourceDir)
+	if err != nil {
+		o.log(fmt.Sprintf("Error undoing the run: %v", err))
+		for _, r := range results {
+			if r.Status == StatusMoved {
+				failed++
+			}
+		}
+		return 0, failed
+	}
+	defer l.unlock()
+	return o.undo(results)
+}
+
+func (o *Organizer) undo(results []Result) (restored, failed int) {
+	folders := make(map[string]bool)
+	dropped := make(map[string][]string)
+	for i := len(results) - 1; i >= 0; i-- {
+		r := results[i]
+		if r.Status != StatusMoved {
+			continue
+		}
+		move := r.Move
+		folders[filepath.Dir(move.Dest)] = true
+
+		if _, err := os.Lstat(move.File.Path); err == nil {
+			o.log(fmt.Sprintf("Not restored (original path taken): %s", move.File.Name))
+			failed++
+			continue
+		}
+		if err := o.moveFile(move.Dest, move.File.Path); err != nil {
+			o.log(fmt.Sprintf("Error restoring %s: %v", move.File.Name, err))
+			failed++
+			continue
+		}
+		o.log(fmt.Sprintf("Restored: %s/%s → %s", move.Folder, move.Name, move.File.Name))
+		dropped[filepath.Dir(move.Dest)] = append(dropped[filepath.Dir(move.Dest)], filepath.Base(move.Dest))
+		restored++
+	}
+
+	for folder, names := range dropped {
+		if err := updateChecksums(folder, nil, names); err != nil {
+			o.log(fmt.Sprintf("Error updating %s in %s: %v", ChecksumsName, folder, err))
+		}
+	}
+
+	o.removeEmptyFolders(folders)
+	return restored, failed
+}
+
+// removeEmptyFolders removes the given month folders, then their year
+// folders, where they are empty. Anything else is left alone.
+func (o *Organizer) removeEmptyFolders(monthFolders map[string]bool) {
+	months := make([]string, 0, len(monthFolders))
+	for folder := range monthFolders {
+		months = append(months, folder)
+	}
+	sort.Strings(months)
+
+	years := make(map[string]bool)
+	for _, month := range months {
+		if _, ok := IsMonthFolder(filepath.Base(month)); !ok {
+			continue
+		}
+		if os.Remove(month) == nil {
+			years[filepath.Dir(month)] = true
+		}
+	}
+	for year := range years {
+		if IsYearFolder(filepath.Base(year)) {
+			os.Remove(year)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package organizer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func runAndCollect(t *testing.T, org *Organizer) []Result {
+	t.Helper()
+	files, err := org.GetFiles()
+	if err != nil {
+		t.Fatalf("GetFiles failed: %v", err)
+	}
+	var results []Result
+	org.SetResultCallback(func(r Result) { results = append(results, r) })
+	if _, _, err := org.Execute(org.Plan(files)); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	return results
+}
+
+// TestUndoRestoresFiles verifies undo moves files back under their
+// original names and removes the folders the run left empty
+func TestUndoRestoresFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFileAt(t, filepath.Join(tmpDir, "photo"), pngHeader, time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	writeFileAt(t, filepath.Join(tmpDir, "b.txt"), []byte("b"), time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC))
+	if err := os.MkdirAll(filepath.Join(tmpDir, "2023", "01-January"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFileAt(t, filepath.Join(tmpDir, "2023", "01-January", "keep.txt"), []byte("k"), time.Now())
+
+	org := NewWithOptions(tmpDir, Options{FixExtensions: true}, nil)
+	results := runAndCollect(t, org)
+	if _, err := os.Stat(filepath.Join(tmpDir, "2024", "03-March", "photo.png")); err != nil {
+		t.Fatalf("Expected the renamed file to be moved: %v", err)
+	}
+
+	restored, failed := org.Undo(results)
+	if restored != 2 || failed != 0 {
+		t.Errorf("Expected 2 restored and 0 failed, got %d and %d", restored, failed)
+	}
+	for _, name := range []string{"photo", "b.txt"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, name)); err != nil {
+			t.Errorf("Expected %s back in place: %v", name, err)
+		}
+	}
+	for _, folder := range []string{"2024", filepath.Join("2023", "07-July")} {
+		if _, err := os.Stat(filepath.Join(tmpDir, folder)); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed, got %v", folder, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "2023", "01-January", "keep.txt")); err != nil {
+		t.Errorf("Expected the existing folder to be kept: %v", err)
+	}
+}
+
+// TestUndoKeepsTakenPaths verifies a file is not restored over one that
+// has since taken its original path
+func TestUndoKeepsTakenPaths(t *testing.T) {
+	tmpDir := t.TempDir()
+	modTime := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	writeFileAt(t, filepath.Join(tmpDir, "a.txt"), []byte("old"), modTime)
+
+	org := New(tmpDir, nil)
+	results := runAndCollect(t, org)
+	writeFileAt(t, filepath.Join(tmpDir, "a.txt"), []byte("new"), modTime)
+
+	restored, failed := org.Undo(results)
+	if restored != 0 || failed != 1 {
+		t.Errorf("Expected 0 restored and 1 failed, got %d and %d", restored, failed)
+	}
+	if data, _ := os.ReadFile(filepath.Join(tmpDir, "a.txt")); string(data) != "new" {
+		t.Errorf("Expected the new file to be kept, got %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "2024", "03-March", "a.txt")); err != nil {
+		t.Errorf("Expected the moved file to stay: %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+//go:build darwin || freebsd || dragonfly
+
+package organizer
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+)
+
+func statVolume(path string) (volume, error) {
+	var st syscall.Stat_t
+	if err := syscall.Stat(path, &st); err != nil {
+		return volume{}, err
+	}
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(path, &fs); err != nil {
+		return volume{}, err
+	}
+	var name strings.Builder
+	for _, c := range fs.Fstypename {
+		if c == 0 {
+			break
+		}
+		name.WriteByte(byte(c))
+	}
+	return volume{
+		id:     fmt.Sprint(st.Dev),
+		free:   int64(fs.Bavail) * int64(fs.Bsize),
+		fsType: name.String(),
+	}, nil
+}
@@ -0,0 +1,36 @@
+//go:build linux
+
+package organizer
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// linuxFSTypes names the file systems, by their statfs magic number, whose
+// naming rules differ from the Unix ones.
+var linuxFSTypes = map[uint32]string{
+	0x4d44:     "vfat",
+	0x2011bab0: "exfat",
+	0x5346544e: "ntfs",
+	0x7366746e: "ntfs3",
+	0xff534d42: "cifs",
+	0xfe534d42: "smb2",
+	0x517b:     "smb",
+}
+
+func statVolume(path string) (volume, error) {
+	var st syscall.Stat_t
+	if err := syscall.Stat(path, &st); err != nil {
+		return volume{}, err
+	}
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(path, &fs); err != nil {
+		return volume{}, err
+	}
+	return volume{
+		id:     fmt.Sprint(st.Dev),
+		free:   int64(fs.Bavail) * int64(fs.Bsize),
+		fsType: linuxFSTypes[uint32(fs.Type)],
+	}, nil
+}
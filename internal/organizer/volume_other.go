@@ -0,0 +1,9 @@
+//go:build !(linux || darwin || freebsd || dragonfly || windows)
+
+package organizer
+
+// statVolume can't look volumes up on this platform, so free space and
+// moves between volumes aren't checked.
+func statVolume(path string) (volume, error) {
+	return volume{free: -1}, nil
+}
@@ -0,0 +1,39 @@
+//go:build windows
+
+package organizer
+
+import (
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	kernel32                 = syscall.NewLazyDLL("kernel32.dll")
+	procGetDiskFreeSpaceExW  = kernel32.NewProc("GetDiskFreeSpaceExW")
+	procGetVolumePathNameW   = kernel32.NewProc("GetVolumePathNameW")
+	procGetVolumeInformation = kernel32.NewProc("GetVolumeInformationW")
+)
+
+func statVolume(path string) (volume, error) {
+	p, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return volume{}, err
+	}
+	root := make([]uint16, syscall.MAX_PATH+1)
+	if r, _, err := procGetVolumePathNameW.Call(uintptr(unsafe.Pointer(p)), uintptr(unsafe.Pointer(&root[0])), uintptr(len(root))); r == 0 {
+		return volume{}, err
+	}
+	v := volume{id: strings.ToLower(syscall.UTF16ToString(root)), free: -1}
+
+	var free uint64
+	if r, _, _ := procGetDiskFreeSpaceExW.Call(uintptr(unsafe.Pointer(&root[0])), uintptr(unsafe.Pointer(&free)), 0, 0); r != 0 {
+		v.free = int64(free)
+	}
+	fsName := make([]uint16, syscall.MAX_PATH+1)
+	if r, _, _ := procGetVolumeInformation.Call(uintptr(unsafe.Pointer(&root[0])), 0, 0, 0, 0, 0,
+		uintptr(unsafe.Pointer(&fsName[0])), uintptr(len(fsName))); r != 0 {
+		v.fsType = strings.ToLower(syscall.UTF16ToString(fsName))
+	}
+	return v, nil
+}
@@ -0,0 +1,81 @@
+package report
+
+import (
+	"html/template"
+	"io"
+	"path"
+	"time"
+
+	"github.com/dale-tomson/declutter/internal/organizer"
+	"github.com/dale-tomson/declutter/internal/version"
+)
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"base": path.Base,
+	"time": func(t time.Time) string { return t.Format("2006-01-02 15:04:05") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Declutter report – {{.Report.SourceDir}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2em; color: #222; }
+h1 { font-size: 1.4em; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { padding: 0.3em 0.8em; border-bottom: 1px solid #ddd; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+tr.year td { font-weight: bold; background: #f3f3f3; }
+tr.month td:first-child { padding-left: 2em; }
+.failed { color: #b00020; }
+footer { margin-top: 2em; color: #777; font-size: 0.85em; }
+</style>
+</head>
+<body>
+<h1>Declutter report</h1>
+<p>Folder: <code>{{.Report.SourceDir}}</code><br>
+Run: {{time .Report.Started}} – {{time .Report.Finished}}</p>
+
+<table>
+<tr><th></th><th>Moved</th><th>Skipped</th><th>Excluded</th><th>Failed</th>{{if .Overall.Planned}}<th>Planned</th>{{end}}<th>Total</th></tr>
+{{- range .Years}}
+<tr class="year"><td>{{.Year}}</td><td>{{.Moved}}</td><td>{{.Skipped}}</td><td>{{.Excluded}}</td><td>{{.Failed}}</td>{{if $.Overall.Planned}}<td>{{.Planned}}</td>{{end}}<td>{{.Total}}</td></tr>
+{{- range .Months}}
+<tr class="month"><td>{{base .Folder}}</td><td>{{.Moved}}</td><td>{{.Skipped}}</td><td>{{.Excluded}}</td><td>{{.Failed}}</td>{{if $.Overall.Planned}}<td>{{.Planned}}</td>{{end}}<td>{{.Total}}</td></tr>
+{{- end}}
+{{- end}}
+<tr class="year"><td>Total</td><td>{{.Overall.Moved}}</td><td>{{.Overall.Skipped}}</td><td>{{.Overall.Excluded}}</td><td>{{.Overall.Failed}}</td>{{if .Overall.Planned}}<td>{{.Overall.Planned}}</td>{{end}}<td>{{.Overall.Total}}</td></tr>
+</table>
+{{- if .Failed}}
+
+<h2>Failed files</h2>
+<table>
+<tr><th>File</th><th>Error</th></tr>
+{{- range .Failed}}
+<tr class="failed"><td>{{.Source}}</td><td>{{.Error}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+
+<footer>Generated by Declutter v{{.Version}}</footer>
+</body>
+</html>
+`))
+
+// WriteHTML writes a standalone page with totals per year and month, and
+// the files that failed.
+func (r *Report) WriteHTML(w io.Writer) error {
+	overall, years := r.Summary()
+	var failed []Entry
+	for _, e := range r.Files {
+		if e.Outcome == string(organizer.StatusFailed) {
+			failed = append(failed, e)
+		}
+	}
+	return htmlTemplate.Execute(w, struct {
+		Report  *Report
+		Overall Totals
+		Years   []YearTotals
+		Failed  []Entry
+		Version string
+	}{r, overall, years, failed, version.Version})
+}
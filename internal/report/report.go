@@ -0,0 +1,206 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dale-tomson/declutter/internal/organizer"
+)
+
+// OutcomePlanned marks entries of a dry run, which were planned but not
+// executed. The other outcomes are the organizer.Status values.
+const OutcomePlanned = "planned"
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+	FormatHTML Format = "html"
+)
+
+// FormatFor picks the format from a file name's extension.
+func FormatFor(name string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".json":
+		return FormatJSON, nil
+	case ".html", ".htm":
+		return FormatHTML, nil
+	}
+	return "", fmt.Errorf("unknown report format for %q (use .csv, .json or .html)", name)
+}
+
+// Entry is one file of a run.
+type Entry struct {
+	Source      string    `json:"source"`
+	Destination string    `json:"destination"`
+	Folder      string    `json:"folder"`
+	Date        time.Time `json:"date"`
+	DateSource  string    `json:"dateSource"`
+	Outcome     string    `json:"outcome"`
+	Error       string    `json:"error,omitempty"`
+}
+
+type Report struct {
+	SourceDir string    `json:"sourceDir"`
+	Started   time.Time `json:"started"`
+	Finished  time.Time `json:"finished"`
+	Files     []Entry   `json:"files"`
+}
+
+func entryFor(move organizer.PlannedMove, outcome string, err error) Entry {
+	e := Entry{
+		Source:      move.File.Path,
+		Destination: move.Dest,
+		Folder:      move.Folder,
+		Date:        move.File.EffectiveDate(),
+		DateSource:  string(move.File.DateSource),
+		Outcome:     outcome,
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	return e
+}
+
+// FromResults builds the report of an executed run.
+func FromResults(sourceDir string, started, finished time.Time, results []organizer.Result) *Report {
+	r := &Report{SourceDir: sourceDir, Started: started, Finished: finished}
+	for _, result := range results {
+		r.Files = append(r.Files, entryFor(result.Move, string(result.Status), result.Err))
+	}
+	return r
+}
+
+// FromPlan builds the report of a dry run.
+func FromPlan(sourceDir string, started time.Time, plan []organizer.PlannedMove) *Report {
+	r := &Report{SourceDir: sourceDir, Started: started, Finished: started}
+	for _, move := range plan {
+		outcome := OutcomePlanned
+		if move.Excluded {
+			outcome = string(organizer.StatusExcluded)
+		}
+		r.Files = append(r.Files, entryFor(move, outcome, nil))
+	}
+	return r
+}
+
+func (r *Report) Write(w io.Writer, format Format) error {
+	switch format {
+	case FormatCSV:
+		return r.WriteCSV(w)
+	case FormatJSON:
+		return r.WriteJSON(w)
+	case FormatHTML:
+		return r.WriteHTML(w)
+	}
+	return fmt.Errorf("unknown report format %q", format)
+}
+
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"source", "destination", "folder", "date", "date_source", "outcome", "error"})
+	for _, e := range r.Files {
+		cw.Write([]string{
+			e.Source,
+			e.Destination,
+			e.Folder,
+			e.Date.Format(time.RFC3339),
+			e.DateSource,
+			e.Outcome,
+			e.Error,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// Totals counts files by outcome.
+type Totals struct {
+	Moved    int
+	Skipped  int
+	Excluded int
+	Failed   int
+	Planned  int
+}
+
+func (t *Totals) add(outcome string) {
+	switch outcome {
+	case string(organizer.StatusMoved):
+		t.Moved++
+	case string(organizer.StatusSkipped):
+		t.Skipped++
+	case string(organizer.StatusExcluded):
+		t.Excluded++
+	case string(organizer.StatusFailed):
+		t.Failed++
+	case OutcomePlanned:
+		t.Planned++
+	}
+}
+
+func (t Totals) Total() int {
+	return t.Moved + t.Skipped + t.Excluded + t.Failed + t.Planned
+}
+
+type MonthTotals struct {
+	Folder string
+	Totals
+}
+
+type YearTotals struct {
+	Year string
+	Totals
+	Months []MonthTotals
+}
+
+// Summary totals the report overall and per Year/Month folder, in folder
+// order.
+func (r *Report) Summary() (Totals, []YearTotals) {
+	var overall Totals
+	months := make(map[string]*Totals)
+	for _, e := range r.Files {
+		overall.add(e.Outcome)
+		if months[e.Folder] == nil {
+			months[e.Folder] = &Totals{}
+		}
+		months[e.Folder].add(e.Outcome)
+	}
+
+	folders := make([]string, 0, len(months))
+	for folder := range months {
+		folders = append(folders, folder)
+	}
+	sort.Strings(folders)
+
+	var years []YearTotals
+	for _, folder := range folders {
+		year, _, _ := strings.Cut(folder, "/")
+		if len(years) == 0 || years[len(years)-1].Year != year {
+			years = append(years, YearTotals{Year: year})
+		}
+		y := &years[len(years)-1]
+		m := *months[folder]
+		y.Months = append(y.Months, MonthTotals{Folder: folder, Totals: m})
+		y.Moved += m.Moved
+		y.Skipped += m.Skipped
+		y.Excluded += m.Excluded
+		y.Failed += m.Failed
+		y.Planned += m.Planned
+	}
+	return overall, years
+}
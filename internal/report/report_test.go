@@ -0,0 +1,138 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dale-tomson/declutter/internal/organizer"
+)
+
+func testReport() *Report {
+	move := func(name, folder string) organizer.PlannedMove {
+		return organizer.PlannedMove{
+			File: organizer.FileInfo{
+				Path:       "/photos/" + name,
+				Name:       name,
+				Date:       time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC),
+				DateSource: organizer.DateSourceMedia,
+			},
+			Folder: folder,
+			Name:   name,
+			Dest:   "/photos/" + folder + "/" + name,
+		}
+	}
+	started := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	return FromResults("/photos", started, started.Add(time.Minute), []organizer.Result{
+		{Move: move("a.jpg", "2024/03-March"), Status: organizer.StatusMoved},
+		{Move: move("b.jpg", "2024/03-March"), Status: organizer.StatusSkipped},
+		{Move: move("c.jpg", "2024/01-January"), Status: organizer.StatusMoved},
+		{Move: move("<d>.jpg", "2023/12-December"), Status: organizer.StatusFailed, Err: errors.New("permission denied")},
+	})
+}
+
+// TestFormatFor verifies formats are picked from the file extension
+func TestFormatFor(t *testing.T) {
+	tests := map[string]Format{
+		"report.csv":  FormatCSV,
+		"REPORT.JSON": FormatJSON,
+		"report.htm":  FormatHTML,
+		"report.html": FormatHTML,
+	}
+	for name, want := range tests {
+		if got, err := FormatFor(name); err != nil || got != want {
+			t.Errorf("FormatFor(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := FormatFor("report.txt"); err == nil {
+		t.Error("Expected an error for .txt")
+	}
+}
+
+// TestWriteCSV verifies one row per file after the header
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testReport().WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+	if len(rows) != 5 {
+		t.Fatalf("Expected 5 rows, got %d", len(rows))
+	}
+	want := []string{"/photos/<d>.jpg", "/photos/2023/12-December/<d>.jpg", "2023/12-December",
+		"2024-03-05T10:00:00Z", "media", "failed", "permission denied"}
+	if strings.Join(rows[4], "|") != strings.Join(want, "|") {
+		t.Errorf("Unexpected row %v", rows[4])
+	}
+}
+
+// TestWriteJSON verifies the JSON report round-trips
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testReport().WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+	if decoded.SourceDir != "/photos" || len(decoded.Files) != 4 {
+		t.Fatalf("Unexpected report %+v", decoded)
+	}
+	if decoded.Files[1].Outcome != "skipped" || decoded.Files[3].Error != "permission denied" {
+		t.Errorf("Unexpected entries %+v", decoded.Files)
+	}
+}
+
+// TestSummary verifies totals per year and month in folder order
+func TestSummary(t *testing.T) {
+	overall, years := testReport().Summary()
+	if overall.Moved != 2 || overall.Skipped != 1 || overall.Failed != 1 || overall.Total() != 4 {
+		t.Errorf("Unexpected overall totals %+v", overall)
+	}
+	if len(years) != 2 || years[0].Year != "2023" || years[1].Year != "2024" {
+		t.Fatalf("Unexpected years %+v", years)
+	}
+	if len(years[1].Months) != 2 || years[1].Months[0].Folder != "2024/01-January" {
+		t.Fatalf("Unexpected months %+v", years[1].Months)
+	}
+	if years[1].Moved != 2 || years[1].Months[1].Skipped != 1 {
+		t.Errorf("Unexpected 2024 totals %+v", years[1])
+	}
+}
+
+// TestWriteHTML verifies the summary lists months and escapes file names
+func TestWriteHTML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testReport().WriteHTML(&buf); err != nil {
+		t.Fatalf("WriteHTML failed: %v", err)
+	}
+	page := buf.String()
+	for _, want := range []string{"<td>03-March</td>", "<td>2024</td>", "&lt;d&gt;.jpg", "permission denied"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Expected HTML to contain %q", want)
+		}
+	}
+	if strings.Contains(page, "<d>.jpg") {
+		t.Error("Expected file names to be escaped")
+	}
+}
+
+// TestFromPlan verifies dry-run entries are planned unless excluded
+func TestFromPlan(t *testing.T) {
+	plan := []organizer.PlannedMove{
+		{File: organizer.FileInfo{Name: "a.jpg"}, Folder: "2024/03-March"},
+		{File: organizer.FileInfo{Name: "b.jpg"}, Folder: "2024/03-March", Excluded: true},
+	}
+	overall, _ := FromPlan("/photos", time.Now(), plan).Summary()
+	if overall.Planned != 1 || overall.Excluded != 1 {
+		t.Errorf("Unexpected totals %+v", overall)
+	}
+}
@@ -0,0 +1,211 @@
+package theme
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"github.com/BurntSushi/toml"
+)
+
+// colorNames are the Fyne colour names a theme file may set.
+var colorNames = map[fyne.ThemeColorName]bool{
+	theme.ColorNameBackground: true, theme.ColorNameButton: true, theme.ColorNameDisabledButton: true,
+	theme.ColorNameDisabled: true, theme.ColorNameError: true, theme.ColorNameFocus: true,
+	theme.ColorNameForeground: true, theme.ColorNameForegroundOnError: true, theme.ColorNameForegroundOnPrimary: true,
+	theme.ColorNameForegroundOnSuccess: true, theme.ColorNameForegroundOnWarning: true, theme.ColorNameHeaderBackground: true,
+	theme.ColorNameHover: true, theme.ColorNameHyperlink: true, theme.ColorNameInputBackground: true,
+	theme.ColorNameInputBorder: true, theme.ColorNameMenuBackground: true, theme.ColorNameOverlayBackground: true,
+	theme.ColorNamePlaceHolder: true, theme.ColorNamePressed: true, theme.ColorNamePrimary: true,
+	theme.ColorNameScrollBar: true, theme.ColorNameScrollBarBackground: true, theme.ColorNameSelection: true,
+	theme.ColorNameSeparator: true, theme.ColorNameShadow: true, theme.ColorNameSuccess: true,
+	theme.ColorNameWarning: true,
+}
+
+// sizeNames are the Fyne size names a theme file may set.
+var sizeNames = map[fyne.ThemeSizeName]bool{
+	theme.SizeNameCaptionText: true, theme.SizeNameInlineIcon: true, theme.SizeNameInnerPadding: true,
+	theme.SizeNameLineSpacing: true, theme.SizeNamePadding: true, theme.SizeNameScrollBar: true,
+	theme.SizeNameScrollBarSmall: true, theme.SizeNameSeparatorThickness: true, theme.SizeNameText: true,
+	theme.SizeNameHeadingText: true, theme.SizeNameSubHeadingText: true, theme.SizeNameInputBorder: true,
+	theme.SizeNameInputRadius: true, theme.SizeNameSelectionRadius: true, theme.SizeNameScrollBarRadius: true,
+	theme.SizeNameWindowButtonHeight: true, theme.SizeNameWindowButtonRadius: true, theme.SizeNameWindowButtonIcon: true,
+	theme.SizeNameWindowTitleBarHeight: true,
+}
+
+// Definition is a user-defined theme read from a JSON or TOML file. Colours
+// are keyed by Fyne colour name ("primary", "background", …) and apply to
+// every mode; anything the file leaves out comes from the built-in theme.
+// Font paths are relative to the file.
+type Definition struct {
+	Colors        map[string]string  `json:"colors" toml:"colors"`
+	Sizes         map[string]float32 `json:"sizes" toml:"sizes"`
+	Font          string             `json:"font" toml:"font"`
+	MonospaceFont string             `json:"monospaceFont" toml:"monospaceFont"`
+
+	colors    map[fyne.ThemeColorName]color.Color
+	sizes     map[fyne.ThemeSizeName]float32
+	font      fyne.Resource
+	monospace fyne.Resource
+}
+
+// LoadDefinition reads and validates a theme file. The format is picked by
+// the .json or .toml extension.
+func LoadDefinition(path string) (*Definition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var d Definition
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&d); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	case ".toml":
+		meta, err := toml.Decode(string(data), &d)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return nil, fmt.Errorf("%s: unknown key %q", path, undecoded[0].String())
+		}
+	default:
+		return nil, fmt.Errorf("%s: theme files must end in .json or .toml", path)
+	}
+
+	if err := d.validate(filepath.Dir(path)); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &d, nil
+}
+
+// validate parses the colours, sizes and fonts, reporting every problem at
+// once.
+func (d *Definition) validate(dir string) error {
+	var errs []error
+	d.colors = make(map[fyne.ThemeColorName]color.Color, len(d.Colors))
+	for _, name := range sortedKeys(d.Colors) {
+		if !colorNames[fyne.ThemeColorName(name)] {
+			errs = append(errs, fmt.Errorf("colors: unknown colour name %q", name))
+			continue
+		}
+		c, err := ParseHexColor(d.Colors[name])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("colors.%s: %w", name, err))
+			continue
+		}
+		d.colors[fyne.ThemeColorName(name)] = c
+	}
+
+	d.sizes = make(map[fyne.ThemeSizeName]float32, len(d.Sizes))
+	for _, name := range sortedKeys(d.Sizes) {
+		size := d.Sizes[name]
+		switch {
+		case !sizeNames[fyne.ThemeSizeName(name)]:
+			errs = append(errs, fmt.Errorf("sizes: unknown size name %q", name))
+		case size < 0 || size > 200:
+			errs = append(errs, fmt.Errorf("sizes.%s: %v is out of range 0–200", name, size))
+		default:
+			d.sizes[fyne.ThemeSizeName(name)] = size
+		}
+	}
+
+	var err error
+	if d.font, err = loadFont(dir, d.Font); err != nil {
+		errs = append(errs, fmt.Errorf("font: %w", err))
+	}
+	if d.monospace, err = loadFont(dir, d.MonospaceFont); err != nil {
+		errs = append(errs, fmt.Errorf("monospaceFont: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ParseHexColor parses #rgb, #rrggbb or #rrggbbaa.
+func ParseHexColor(s string) (color.NRGBA, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if len(hex) != 8 || !strings.HasPrefix(strings.TrimSpace(s), "#") || err != nil {
+		return color.NRGBA{}, fmt.Errorf("invalid colour %q, want #rgb, #rrggbb or #rrggbbaa", s)
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
+
+// loadFont reads a TrueType or OpenType font. An empty path keeps the
+// built-in font.
+func loadFont(dir, path string) (fyne.Resource, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 4 {
+		return nil, fmt.Errorf("%s is not a TrueType or OpenType font", path)
+	}
+	switch string(data[:4]) {
+	case "\x00\x01\x00\x00", "OTTO", "true", "ttcf":
+	default:
+		return nil, fmt.Errorf("%s is not a TrueType or OpenType font", path)
+	}
+	return fyne.NewStaticResource(filepath.Base(path), data), nil
+}
+
+func (d *Definition) color(name fyne.ThemeColorName) (color.Color, bool) {
+	if d == nil {
+		return nil, false
+	}
+	c, ok := d.colors[name]
+	return c, ok
+}
+
+func (d *Definition) size(name fyne.ThemeSizeName) (float32, bool) {
+	if d == nil {
+		return 0, false
+	}
+	s, ok := d.sizes[name]
+	return s, ok
+}
+
+// fontFor returns the file's font for a text style, or nil to use the
+// built-in one. The regular font is used for bold and italic text too.
+func (d *Definition) fontFor(style fyne.TextStyle) fyne.Resource {
+	if d == nil || style.Symbol {
+		return nil
+	}
+	if style.Monospace {
+		return d.monospace
+	}
+	return d.font
+}
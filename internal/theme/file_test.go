@@ -0,0 +1,121 @@
+package theme
+
+import (
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+func writeThemeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestLoadDefinitionJSON verifies a JSON theme overrides the colours and
+// sizes it names and falls back to the built-in theme for the rest
+func TestLoadDefinitionJSON(t *testing.T) {
+	path := writeThemeFile(t, "brand.json", `{
+		"colors": {"primary": "#ff6600", "background": "#123"},
+		"sizes": {"text": 16}
+	}`)
+	d, err := LoadDefinition(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	th := NewWithOptions(Options{Mode: ModeDark, Definition: d})
+
+	if c := th.Color(theme.ColorNamePrimary, theme.VariantDark); c != (color.NRGBA{R: 0xff, G: 0x66, A: 0xff}) {
+		t.Errorf("Expected the file's primary colour, got %v", c)
+	}
+	if c := th.Color(theme.ColorNameBackground, theme.VariantDark); c != (color.NRGBA{R: 0x11, G: 0x22, B: 0x33, A: 0xff}) {
+		t.Errorf("Expected the short hex background, got %v", c)
+	}
+	if c := th.Color(theme.ColorNameForeground, theme.VariantDark); c != DarkPalette.Foreground {
+		t.Errorf("Expected the built-in foreground, got %v", c)
+	}
+	if s := th.Size(theme.SizeNameText); s != 16 {
+		t.Errorf("Expected text size 16, got %v", s)
+	}
+}
+
+// TestLoadDefinitionTOML verifies TOML files and fonts relative to the file
+func TestLoadDefinitionTOML(t *testing.T) {
+	path := writeThemeFile(t, "brand.toml", `
+font = "Brand.ttf"
+
+[colors]
+hyperlink = "#00aaff80"
+`)
+	if err := os.WriteFile(filepath.Join(filepath.Dir(path), "Brand.ttf"), []byte("\x00\x01\x00\x00font"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	d, err := LoadDefinition(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	th := NewWithOptions(Options{Definition: d})
+
+	if c := th.Color(theme.ColorNameHyperlink, theme.VariantLight); c != (color.NRGBA{G: 0xaa, B: 0xff, A: 0x80}) {
+		t.Errorf("Expected the file's hyperlink colour, got %v", c)
+	}
+	if f := th.Font(fyne.TextStyle{Bold: true}); f == nil || f.Name() != "Brand.ttf" {
+		t.Errorf("Expected the file's font, got %v", f)
+	}
+}
+
+// TestLoadDefinitionErrors verifies every problem in a file is reported
+func TestLoadDefinitionErrors(t *testing.T) {
+	path := writeThemeFile(t, "broken.json", `{
+		"colors": {"primary": "#12", "shiny": "#fff", "background": "blue"},
+		"sizes": {"text": -1},
+		"font": "missing.ttf"
+	}`)
+	_, err := LoadDefinition(path)
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	for _, want := range []string{`colors.primary: invalid colour "#12"`, `unknown colour name "shiny"`, `colors.background: invalid colour "blue"`, "sizes.text", "font:"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in %v", want, err)
+		}
+	}
+
+	for name, content := range map[string]string{
+		"unknown.json": `{"colours": {}}`,
+		"unknown.toml": `colours = 1`,
+		"theme.yaml":   `colors: {}`,
+		"syntax.json":  `{`,
+	} {
+		if _, err := LoadDefinition(writeThemeFile(t, name, content)); err == nil {
+			t.Errorf("Expected an error for %s", name)
+		}
+	}
+}
+
+// TestParseHexColor verifies the accepted hex forms
+func TestParseHexColor(t *testing.T) {
+	tests := map[string]color.NRGBA{
+		"#fff":      {R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		"#42b883":   PrimaryGreen,
+		"#20304064": ShadowColor,
+	}
+	for s, want := range tests {
+		if got, err := ParseHexColor(s); err != nil || got != want {
+			t.Errorf("ParseHexColor(%q) = %v, %v, want %v", s, got, err, want)
+		}
+	}
+	for _, s := range []string{"", "fff", "#ffff", "#gggggg", "#1234567890"} {
+		if _, err := ParseHexColor(s); err == nil {
+			t.Errorf("Expected an error for %q", s)
+		}
+	}
+}
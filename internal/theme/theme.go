@@ -14,56 +14,241 @@ var (
 	BackgroundLight = color.NRGBA{R: 0x3d, G: 0x54, B: 0x6b, A: 255}
 	DialogBg        = color.NRGBA{R: 0x2d, G: 0x3e, B: 0x50, A: 255}
 	HighlightCoral  = color.NRGBA{R: 0xff, G: 0x7e, B: 0x67, A: 255}
-	HoverGreen      = color.NRGBA{R: 0x4a, G: 0xc9, B: 0x92, A: 255}
+	HoverGreen      = color.NRGBA{R: 0x24, G: 0x62, B: 0x4b, A: 255}
 	TextWhite       = color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 255}
-	PlaceholderText = color.NRGBA{R: 0xaa, G: 0xbb, B: 0xcc, A: 255}
+	PlaceholderText = color.NRGBA{R: 0xc2, G: 0xcf, B: 0xdc, A: 255}
 	DisabledButton  = color.NRGBA{R: 0x50, G: 0x65, B: 0x78, A: 255}
 	ShadowColor     = color.NRGBA{R: 0x20, G: 0x30, B: 0x40, A: 100}
 )
 
+// Palette holds the colours of one theme variant. The On* colours are for
+// text drawn on top of the colour they are named after.
+type Palette struct {
+	Primary         color.NRGBA
+	OnPrimary       color.NRGBA
+	Button          color.NRGBA
+	Secondary       color.NRGBA
+	Border          color.NRGBA
+	Background      color.NRGBA
+	InputBackground color.NRGBA
+	Dialog          color.NRGBA
+	Highlight       color.NRGBA
+	OnHighlight     color.NRGBA
+	Link            color.NRGBA
+	Hover           color.NRGBA
+	Foreground      color.NRGBA
+	Placeholder     color.NRGBA
+	DisabledButton  color.NRGBA
+	Shadow          color.NRGBA
+}
+
+// DarkPalette is the original Declutter look. Text on the bright green and
+// coral is dark, and plain buttons use a deeper green, to keep white text
+// readable.
+var DarkPalette = Palette{
+	Primary:         PrimaryGreen,
+	OnPrimary:       color.NRGBA{R: 0x14, G: 0x20, B: 0x2b, A: 255},
+	Button:          color.NRGBA{R: 0x2a, G: 0x70, B: 0x52, A: 255},
+	Secondary:       SecondaryTeal,
+	Border:          SecondaryTeal,
+	Background:      BackgroundDark,
+	InputBackground: BackgroundLight,
+	Dialog:          DialogBg,
+	Highlight:       HighlightCoral,
+	OnHighlight:     color.NRGBA{R: 0x14, G: 0x20, B: 0x2b, A: 255},
+	Link:            color.NRGBA{R: 0x8f, G: 0xe0, B: 0xbb, A: 255},
+	Hover:           HoverGreen,
+	Foreground:      TextWhite,
+	Placeholder:     PlaceholderText,
+	DisabledButton:  DisabledButton,
+	Shadow:          ShadowColor,
+}
+
+// LightPalette uses a darker green so white button text stays readable.
+var LightPalette = Palette{
+	Primary:         color.NRGBA{R: 0x27, G: 0x7a, B: 0x56, A: 255},
+	OnPrimary:       color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 255},
+	Button:          color.NRGBA{R: 0xdd, G: 0xe8, B: 0xe3, A: 255},
+	Secondary:       color.NRGBA{R: 0xcd, G: 0xe6, B: 0xe6, A: 255},
+	Border:          color.NRGBA{R: 0x8a, G: 0x9a, B: 0xa8, A: 255},
+	Background:      color.NRGBA{R: 0xf4, G: 0xf6, B: 0xf8, A: 255},
+	InputBackground: color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 255},
+	Dialog:          color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 255},
+	Highlight:       color.NRGBA{R: 0xc0, G: 0x39, B: 0x2b, A: 255},
+	OnHighlight:     color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 255},
+	Link:            color.NRGBA{R: 0x27, G: 0x7a, B: 0x56, A: 255},
+	Hover:           color.NRGBA{R: 0xd9, G: 0xef, B: 0xe5, A: 255},
+	Foreground:      color.NRGBA{R: 0x1e, G: 0x2b, B: 0x37, A: 255},
+	Placeholder:     color.NRGBA{R: 0x5f, G: 0x6f, B: 0x7f, A: 255},
+	DisabledButton:  color.NRGBA{R: 0xd5, G: 0xdd, B: 0xe5, A: 255},
+	Shadow:          color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 60},
+}
+
+// HighContrastPalette keeps every text pair above 7:1 (WCAG AAA).
+var HighContrastPalette = Palette{
+	Primary:         color.NRGBA{R: 0xff, G: 0xd4, B: 0x00, A: 255},
+	OnPrimary:       color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 255},
+	Button:          color.NRGBA{R: 0x1a, G: 0x1a, B: 0x1a, A: 255},
+	Secondary:       color.NRGBA{R: 0x00, G: 0x3f, B: 0x8a, A: 255},
+	Border:          color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 255},
+	Background:      color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 255},
+	InputBackground: color.NRGBA{R: 0x0b, G: 0x0b, B: 0x0b, A: 255},
+	Dialog:          color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 255},
+	Highlight:       color.NRGBA{R: 0xff, G: 0x6b, B: 0x6b, A: 255},
+	OnHighlight:     color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 255},
+	Link:            color.NRGBA{R: 0x7f, G: 0xd4, B: 0xff, A: 255},
+	Hover:           color.NRGBA{R: 0x33, G: 0x33, B: 0x33, A: 255},
+	Foreground:      color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 255},
+	Placeholder:     color.NRGBA{R: 0xd0, G: 0xd0, B: 0xd0, A: 255},
+	DisabledButton:  color.NRGBA{R: 0x33, G: 0x33, B: 0x33, A: 255},
+	Shadow:          color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0},
+}
+
+// Mode picks the palette. ModeSystem follows the variant the desktop asks
+// for.
+type Mode string
+
+const (
+	ModeSystem       Mode = "system"
+	ModeLight        Mode = "light"
+	ModeDark         Mode = "dark"
+	ModeHighContrast Mode = "high-contrast"
+)
+
+// largeTextScale is how much the large text option enlarges text and
+// inline icons.
+const largeTextScale = 1.25
+
+// Options configures the theme. Definition, when set, overrides the
+// built-in colours, sizes and fonts it names.
+type Options struct {
+	Mode       Mode
+	LargeText  bool
+	Definition *Definition
+}
+
 type CustomTheme struct {
 	fyne.Theme
+	Mode       Mode
+	LargeText  bool
+	Definition *Definition
 }
 
 func New() fyne.Theme {
-	return &CustomTheme{Theme: theme.DefaultTheme()}
+	return NewWithMode(ModeSystem)
+}
+
+func NewWithMode(mode Mode) fyne.Theme {
+	return NewWithOptions(Options{Mode: mode})
+}
+
+func NewWithOptions(options Options) fyne.Theme {
+	return &CustomTheme{
+		Theme:      theme.DefaultTheme(),
+		Mode:       options.Mode,
+		LargeText:  options.LargeText,
+		Definition: options.Definition,
+	}
+}
+
+// variant resolves the variant to draw, honouring a forced mode.
+func (c *CustomTheme) variant(requested fyne.ThemeVariant) fyne.ThemeVariant {
+	switch c.Mode {
+	case ModeLight:
+		return theme.VariantLight
+	case ModeDark, ModeHighContrast:
+		return theme.VariantDark
+	}
+	return requested
+}
+
+func (c *CustomTheme) palette(variant fyne.ThemeVariant) Palette {
+	if c.Mode == ModeHighContrast {
+		return HighContrastPalette
+	}
+	if c.variant(variant) == theme.VariantLight {
+		return LightPalette
+	}
+	return DarkPalette
 }
 
 func (c *CustomTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+	if c, ok := c.Definition.color(name); ok {
+		return c
+	}
+	p := c.palette(variant)
+
 	switch name {
-	case theme.ColorNamePrimary, theme.ColorNameButton, theme.ColorNameFocus, theme.ColorNameSuccess, theme.ColorNameHyperlink:
-		return PrimaryGreen
+	case theme.ColorNamePrimary, theme.ColorNameFocus, theme.ColorNameSuccess:
+		return p.Primary
+
+	case theme.ColorNameForegroundOnPrimary, theme.ColorNameForegroundOnSuccess:
+		return p.OnPrimary
+
+	case theme.ColorNameButton:
+		return p.Button
+
+	case theme.ColorNameHyperlink:
+		return p.Link
 
 	case theme.ColorNameHover:
-		return HoverGreen
+		return p.Hover
+
+	case theme.ColorNamePressed, theme.ColorNameSelection, theme.ColorNameHeaderBackground:
+		return p.Secondary
 
-	case theme.ColorNamePressed, theme.ColorNameSelection, theme.ColorNameScrollBar, theme.ColorNameInputBorder, theme.ColorNameSeparator, theme.ColorNameHeaderBackground:
-		return SecondaryTeal
+	case theme.ColorNameScrollBar, theme.ColorNameInputBorder, theme.ColorNameSeparator:
+		return p.Border
 
 	case theme.ColorNameBackground:
-		return BackgroundDark
+		return p.Background
 
 	case theme.ColorNameInputBackground:
-		return BackgroundLight
+		return p.InputBackground
 
 	case theme.ColorNameMenuBackground, theme.ColorNameOverlayBackground:
-		return DialogBg
+		return p.Dialog
 
 	case theme.ColorNameForeground:
-		return TextWhite
+		return p.Foreground
 
 	case theme.ColorNameDisabled, theme.ColorNamePlaceHolder:
-		return PlaceholderText
+		return p.Placeholder
 
 	case theme.ColorNameDisabledButton:
-		return DisabledButton
+		return p.DisabledButton
 
 	case theme.ColorNameError, theme.ColorNameWarning:
-		return HighlightCoral
+		return p.Highlight
+
+	case theme.ColorNameForegroundOnError, theme.ColorNameForegroundOnWarning:
+		return p.OnHighlight
 
 	case theme.ColorNameShadow:
-		return ShadowColor
+		return p.Shadow
+	}
+
+	return c.Theme.Color(name, c.variant(variant))
+}
+
+func (c *CustomTheme) Size(name fyne.ThemeSizeName) float32 {
+	size, ok := c.Definition.size(name)
+	if !ok {
+		size = c.Theme.Size(name)
 	}
+	if !c.LargeText {
+		return size
+	}
+	switch name {
+	case theme.SizeNameText, theme.SizeNameCaptionText, theme.SizeNameHeadingText, theme.SizeNameSubHeadingText, theme.SizeNameInlineIcon:
+		return size * largeTextScale
+	}
+	return size
+}
 
-	return c.Theme.Color(name, theme.VariantDark)
+func (c *CustomTheme) Font(style fyne.TextStyle) fyne.Resource {
+	if font := c.Definition.fontFor(style); font != nil {
+		return font
+	}
+	return c.Theme.Font(style)
 }
@@ -2,6 +2,7 @@ package theme
 
 import (
 	"image/color"
+	"math"
 	"testing"
 
 	"fyne.io/fyne/v2"
@@ -138,3 +139,140 @@ func TestExportedColors(t *testing.T) {
 		})
 	}
 }
+
+// contrastRatio is the WCAG 2 contrast ratio between two opaque colours.
+func contrastRatio(a, b color.Color) float64 {
+	luminance := func(c color.Color) float64 {
+		r, g, b, _ := c.RGBA()
+		channel := func(v uint32) float64 {
+			s := float64(v) / 0xffff
+			if s <= 0.03928 {
+				return s / 12.92
+			}
+			return math.Pow((s+0.055)/1.055, 2.4)
+		}
+		return 0.2126*channel(r) + 0.7152*channel(g) + 0.0722*channel(b)
+	}
+	la, lb := luminance(a), luminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// TestColorFollowsVariant verifies the system mode picks the palette from
+// the requested variant
+func TestColorFollowsVariant(t *testing.T) {
+	th := New()
+
+	if c := th.Color(theme.ColorNameBackground, theme.VariantLight); c != LightPalette.Background {
+		t.Errorf("Expected light background, got %v", c)
+	}
+	if c := th.Color(theme.ColorNameBackground, theme.VariantDark); c != DarkPalette.Background {
+		t.Errorf("Expected dark background, got %v", c)
+	}
+}
+
+// TestColorForcedMode verifies light and dark modes ignore the requested
+// variant
+func TestColorForcedMode(t *testing.T) {
+	light := NewWithMode(ModeLight)
+	dark := NewWithMode(ModeDark)
+
+	if c := light.Color(theme.ColorNameForeground, theme.VariantDark); c != LightPalette.Foreground {
+		t.Errorf("Expected light foreground in light mode, got %v", c)
+	}
+	if c := dark.Color(theme.ColorNameForeground, theme.VariantLight); c != DarkPalette.Foreground {
+		t.Errorf("Expected dark foreground in dark mode, got %v", c)
+	}
+}
+
+// contrastPair is a colour drawn on top of another one.
+type contrastPair struct {
+	fg, bg fyne.ThemeColorName
+}
+
+// textPairs are the text colours the theme exposes, on the backgrounds
+// they are drawn on. WCAG AA asks for 4.5:1.
+var textPairs = []contrastPair{
+	{theme.ColorNameForeground, theme.ColorNameBackground},
+	{theme.ColorNameForeground, theme.ColorNameInputBackground},
+	{theme.ColorNameForeground, theme.ColorNameOverlayBackground},
+	{theme.ColorNameForeground, theme.ColorNameMenuBackground},
+	{theme.ColorNameForeground, theme.ColorNameButton},
+	{theme.ColorNameForeground, theme.ColorNameHover},
+	{theme.ColorNameForeground, theme.ColorNameSelection},
+	{theme.ColorNameForeground, theme.ColorNameHeaderBackground},
+	{theme.ColorNamePlaceHolder, theme.ColorNameInputBackground},
+	{theme.ColorNameHyperlink, theme.ColorNameBackground},
+	{theme.ColorNameHyperlink, theme.ColorNameInputBackground},
+	{theme.ColorNameHyperlink, theme.ColorNameOverlayBackground},
+	{theme.ColorNameForegroundOnPrimary, theme.ColorNamePrimary},
+	{theme.ColorNameForegroundOnSuccess, theme.ColorNameSuccess},
+	{theme.ColorNameForegroundOnError, theme.ColorNameError},
+	{theme.ColorNameForegroundOnWarning, theme.ColorNameWarning},
+}
+
+// accentPairs are accent colours used for focus rings, progress bars and
+// status text. They are checked against the 3:1 AA level for non-text and
+// bold text, which lets the dark palette keep its brand green and coral.
+var accentPairs = []contrastPair{
+	{theme.ColorNamePrimary, theme.ColorNameBackground},
+	{theme.ColorNameFocus, theme.ColorNameInputBackground},
+	{theme.ColorNameSuccess, theme.ColorNameBackground},
+	{theme.ColorNameError, theme.ColorNameBackground},
+	{theme.ColorNameError, theme.ColorNameInputBackground},
+	{theme.ColorNameWarning, theme.ColorNameBackground},
+}
+
+func checkContrast(t *testing.T, th fyne.Theme, pairs []contrastPair, min float64) {
+	t.Helper()
+	for _, pair := range pairs {
+		fg := th.Color(pair.fg, theme.VariantDark)
+		bg := th.Color(pair.bg, theme.VariantDark)
+		if r := contrastRatio(fg, bg); r < min {
+			t.Errorf("%s on %s has contrast %.2f, want at least %.1f", pair.fg, pair.bg, r, min)
+		}
+	}
+}
+
+// TestPaletteContrast verifies every text and accent colour meets WCAG AA
+// on the backgrounds it is drawn on, in every mode
+func TestPaletteContrast(t *testing.T) {
+	for _, mode := range []Mode{ModeDark, ModeLight, ModeHighContrast} {
+		t.Run(string(mode), func(t *testing.T) {
+			th := NewWithMode(mode)
+			checkContrast(t, th, textPairs, 4.5)
+			checkContrast(t, th, accentPairs, 3)
+		})
+	}
+}
+
+// TestHighContrastPalette verifies the high-contrast mode reaches WCAG AAA
+// for text and accents alike, whatever variant is requested
+func TestHighContrastPalette(t *testing.T) {
+	th := NewWithMode(ModeHighContrast)
+	checkContrast(t, th, textPairs, 7)
+	checkContrast(t, th, accentPairs, 7)
+	checkContrast(t, th, []contrastPair{{theme.ColorNameInputBorder, theme.ColorNameInputBackground}}, 3)
+
+	if c := th.Color(theme.ColorNameBackground, theme.VariantLight); c != HighContrastPalette.Background {
+		t.Errorf("Expected the high-contrast background, got %v", c)
+	}
+}
+
+// TestLargeText verifies the large text option scales text sizes and
+// leaves the rest of the layout alone
+func TestLargeText(t *testing.T) {
+	normal := NewWithMode(ModeDark)
+	large := NewWithOptions(Options{Mode: ModeDark, LargeText: true})
+
+	for _, name := range []fyne.ThemeSizeName{theme.SizeNameText, theme.SizeNameHeadingText, theme.SizeNameCaptionText} {
+		if got, want := large.Size(name), normal.Size(name)*largeTextScale; got != want {
+			t.Errorf("Expected %s to be %v, got %v", name, want, got)
+		}
+	}
+	if large.Size(theme.SizeNamePadding) != normal.Size(theme.SizeNamePadding) {
+		t.Error("Expected padding to be unchanged")
+	}
+}
@@ -0,0 +1,91 @@
+//go:build darwin
+
+package trash
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// macTrash moves items to ~/.Trash, or to /Volumes/<name>/.Trashes/<uid>
+// for other volumes, the way the Finder does. Put Back in the Finder is
+// not available for them, but Restore is. Move fails with ErrUnavailable
+// when a volume's .Trashes folder can't be created.
+type macTrash struct {
+	home string
+}
+
+func newPlatform() (Trash, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	return &macTrash{home: filepath.Join(home, ".Trash")}, nil
+}
+
+func (t *macTrash) Move(path string) (Item, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return Item{}, err
+	}
+	dir, err := t.trashDir(path)
+	if err != nil {
+		return Item{}, err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		if dir != t.home {
+			return Item{}, fmt.Errorf("%w: %v", ErrUnavailable, err)
+		}
+		return Item{}, err
+	}
+
+	base := filepath.Base(path)
+	ext := filepath.Ext(base)
+	trashed := filepath.Join(dir, base)
+	for i := 2; ; i++ {
+		if _, err := os.Lstat(trashed); errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		trashed = filepath.Join(dir, base[:len(base)-len(ext)]+" "+strconv.Itoa(i)+ext)
+	}
+	if err := os.Rename(path, trashed); err != nil {
+		return Item{}, err
+	}
+	return Item{OriginalPath: path, TrashedPath: trashed, DeletedAt: time.Now()}, nil
+}
+
+func (t *macTrash) trashDir(path string) (string, error) {
+	homeDev, err := device(filepath.Dir(t.home))
+	if err != nil {
+		return "", err
+	}
+	dev, err := device(path)
+	if err != nil {
+		return "", err
+	}
+	if dev == homeDev {
+		return t.home, nil
+	}
+	top, err := mountPoint(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(top, ".Trashes", strconv.Itoa(os.Getuid())), nil
+}
+
+func (t *macTrash) Restore(item Item) error {
+	if item.TrashedPath == "" {
+		return ErrUnsupported
+	}
+	if _, err := os.Lstat(item.OriginalPath); err == nil {
+		return fmt.Errorf("cannot restore %s: the path is taken", item.OriginalPath)
+	}
+	if err := os.MkdirAll(filepath.Dir(item.OriginalPath), 0o755); err != nil {
+		return err
+	}
+	return os.Rename(item.TrashedPath, item.OriginalPath)
+}
@@ -0,0 +1,198 @@
+//go:build linux || freebsd || netbsd || openbsd || dragonfly
+
+package trash
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// trashInfoTime is the DeletionDate format of .trashinfo files, in local
+// time.
+const trashInfoTime = "2006-01-02T15:04:05"
+
+// freedesktop implements the freedesktop.org Trash specification: the home
+// trash under $XDG_DATA_HOME for files on the same file system, and
+// $topdir/.Trash-$uid for files on other volumes. Move fails with
+// ErrUnavailable when the latter can't be created.
+type freedesktop struct {
+	home string
+}
+
+func newPlatform() (Trash, error) {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return NewFreedesktop(filepath.Join(dataHome, "Trash")), nil
+}
+
+// NewFreedesktop returns a freedesktop.org trash whose home trash is the
+// given directory.
+func NewFreedesktop(home string) Trash {
+	return &freedesktop{home: home}
+}
+
+func (t *freedesktop) Move(path string) (Item, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return Item{}, err
+	}
+	if _, err := os.Lstat(path); err != nil {
+		return Item{}, err
+	}
+
+	dir, infoPath, err := t.trashDir(path)
+	if err != nil {
+		return Item{}, err
+	}
+	for _, sub := range []string{"files", "info"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o700); err != nil {
+			if dir != t.home {
+				return Item{}, fmt.Errorf("%w: %v", ErrUnavailable, err)
+			}
+			return Item{}, err
+		}
+	}
+
+	now := time.Now()
+	info, name, err := createInfo(dir, filepath.Base(path))
+	if err != nil {
+		return Item{}, err
+	}
+	_, err = fmt.Fprintf(info, "[Trash Info]\nPath=%s\nDeletionDate=%s\n",
+		(&url.URL{Path: infoPath}).EscapedPath(), now.Format(trashInfoTime))
+	if closeErr := info.Close(); err == nil {
+		err = closeErr
+	}
+	infoFile := filepath.Join(dir, "info", name+".trashinfo")
+	if err != nil {
+		os.Remove(infoFile)
+		return Item{}, err
+	}
+
+	trashed := filepath.Join(dir, "files", name)
+	if err := os.Rename(path, trashed); err != nil {
+		os.Remove(infoFile)
+		return Item{}, err
+	}
+	return Item{OriginalPath: path, TrashedPath: trashed, DeletedAt: now}, nil
+}
+
+// trashDir picks the trash for path and the Path its .trashinfo records:
+// absolute in the home trash, relative to the volume's top directory
+// elsewhere.
+func (t *freedesktop) trashDir(path string) (string, string, error) {
+	if err := os.MkdirAll(t.home, 0o700); err != nil {
+		return "", "", err
+	}
+	homeDev, err := device(t.home)
+	if err != nil {
+		return "", "", err
+	}
+	dev, err := device(path)
+	if err != nil {
+		return "", "", err
+	}
+	if dev == homeDev {
+		return t.home, path, nil
+	}
+
+	top, err := mountPoint(path)
+	if err != nil {
+		return "", "", err
+	}
+	rel, err := filepath.Rel(top, path)
+	if err != nil {
+		return "", "", err
+	}
+	return filepath.Join(top, ".Trash-"+strconv.Itoa(os.Getuid())), rel, nil
+}
+
+// createInfo creates the .trashinfo file under a name no other trashed
+// item uses, which also reserves that name in files/.
+func createInfo(dir, base string) (*os.File, string, error) {
+	name := base
+	for i := 2; ; i++ {
+		f, err := os.OpenFile(filepath.Join(dir, "info", name+".trashinfo"), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err == nil {
+			if _, err := os.Lstat(filepath.Join(dir, "files", name)); errors.Is(err, os.ErrNotExist) {
+				return f, name, nil
+			}
+			// files/ holds an orphan under this name; leave it alone.
+			f.Close()
+			os.Remove(f.Name())
+		} else if !errors.Is(err, os.ErrExist) {
+			return nil, "", err
+		}
+		name = base + "." + strconv.Itoa(i)
+	}
+}
+
+func (t *freedesktop) Restore(item Item) error {
+	if item.TrashedPath == "" {
+		return ErrUnsupported
+	}
+	infoFile := filepath.Join(filepath.Dir(filepath.Dir(item.TrashedPath)), "info", filepath.Base(item.TrashedPath)+".trashinfo")
+	original := item.OriginalPath
+	if original == "" {
+		var err error
+		if original, err = readInfoPath(infoFile); err != nil {
+			return err
+		}
+	}
+
+	if _, err := os.Lstat(original); err == nil {
+		return fmt.Errorf("cannot restore %s: the path is taken", original)
+	}
+	if err := os.MkdirAll(filepath.Dir(original), 0o755); err != nil {
+		return err
+	}
+	if err := os.Rename(item.TrashedPath, original); err != nil {
+		return err
+	}
+	os.Remove(infoFile)
+	return nil
+}
+
+// readInfoPath reads the original path from a .trashinfo file. Relative
+// paths are relative to the volume holding the trash.
+func readInfoPath(infoFile string) (string, error) {
+	f, err := os.Open(infoFile)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		value, ok := strings.CutPrefix(scanner.Text(), "Path=")
+		if !ok {
+			continue
+		}
+		path, err := url.PathUnescape(value)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", infoFile, err)
+		}
+		if !filepath.IsAbs(path) {
+			trashDir := filepath.Dir(filepath.Dir(infoFile))
+			path = filepath.Join(filepath.Dir(trashDir), path)
+		}
+		return path, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("%s: no Path entry", infoFile)
+}
@@ -0,0 +1,104 @@
+//go:build linux || freebsd || netbsd || openbsd || dragonfly
+
+package trash
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestFreedesktopMove verifies files land in files/ with a matching
+// .trashinfo, and that names are made unique
+func TestFreedesktopMove(t *testing.T) {
+	root := t.TempDir()
+	home := filepath.Join(root, "Trash")
+	tr := NewFreedesktop(home)
+
+	path := filepath.Join(root, "my photo.jpg")
+	writeFile(t, path, "one")
+	item, err := tr.Move(path)
+	if err != nil {
+		t.Fatalf("Move failed: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Expected the file to be gone")
+	}
+	if item.TrashedPath != filepath.Join(home, "files", "my photo.jpg") {
+		t.Errorf("Unexpected trashed path %s", item.TrashedPath)
+	}
+	info, err := os.ReadFile(filepath.Join(home, "info", "my photo.jpg.trashinfo"))
+	if err != nil {
+		t.Fatalf("Expected a .trashinfo file: %v", err)
+	}
+	if !strings.HasPrefix(string(info), "[Trash Info]\nPath="+strings.ReplaceAll(path, " ", "%20")+"\nDeletionDate=") {
+		t.Errorf("Unexpected .trashinfo content:\n%s", info)
+	}
+
+	writeFile(t, path, "two")
+	second, err := tr.Move(path)
+	if err != nil {
+		t.Fatalf("Second Move failed: %v", err)
+	}
+	if filepath.Base(second.TrashedPath) != "my photo.jpg.2" {
+		t.Errorf("Expected a unique name, got %s", second.TrashedPath)
+	}
+}
+
+// TestFreedesktopRestore verifies restoring moves the item back and
+// removes its .trashinfo, and refuses to overwrite
+func TestFreedesktopRestore(t *testing.T) {
+	root := t.TempDir()
+	home := filepath.Join(root, "Trash")
+	tr := NewFreedesktop(home)
+
+	dir := filepath.Join(root, "folder")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "a.txt")
+	writeFile(t, path, "a")
+	item, err := tr.Move(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeFile(t, path, "taken")
+	if err := tr.Restore(item); err == nil {
+		t.Error("Expected restoring over an existing file to fail")
+	}
+	os.Remove(path)
+
+	// Restoring from the .trashinfo alone finds the original path too.
+	if err := tr.Restore(Item{TrashedPath: item.TrashedPath}); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "a" {
+		t.Errorf("Expected the original content back, got %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(home, "info", "a.txt.trashinfo")); !os.IsNotExist(err) {
+		t.Error("Expected the .trashinfo file to be removed")
+	}
+}
+
+// TestFreedesktopMoveMissing verifies a missing file is an error and
+// leaves no .trashinfo behind
+func TestFreedesktopMoveMissing(t *testing.T) {
+	root := t.TempDir()
+	tr := NewFreedesktop(filepath.Join(root, "Trash"))
+	if _, err := tr.Move(filepath.Join(root, "missing.txt")); err == nil {
+		t.Fatal("Expected an error")
+	}
+	entries, _ := os.ReadDir(filepath.Join(root, "Trash", "info"))
+	if len(entries) != 0 {
+		t.Errorf("Expected no .trashinfo files, got %d", len(entries))
+	}
+}
@@ -0,0 +1,36 @@
+//go:build unix
+
+package trash
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+func device(path string) (uint64, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(info.Sys().(*syscall.Stat_t).Dev), nil
+}
+
+// mountPoint returns the top directory of the file system holding path.
+func mountPoint(path string) (string, error) {
+	dev, err := device(path)
+	if err != nil {
+		return "", err
+	}
+	dir := path
+	for {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir, nil
+		}
+		if d, err := device(parent); err != nil || d != dev {
+			return dir, nil
+		}
+		dir = parent
+	}
+}
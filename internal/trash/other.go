@@ -0,0 +1,7 @@
+//go:build !(linux || freebsd || netbsd || openbsd || dragonfly || darwin || (windows && !386))
+
+package trash
+
+func newPlatform() (Trash, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,42 @@
+package trash
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrUnsupported is returned where the platform has no trash, or can't
+// restore from it.
+var ErrUnsupported = errors.New("the trash is not supported on this platform")
+
+// ErrUnavailable is returned by Move when the volume holding a path has no
+// trash that can be used, such as a network drive or a volume whose trash
+// folder can't be created. Callers can delete the path instead.
+var ErrUnavailable = errors.New("no trash is available on this volume")
+
+// Item is a file or folder that was moved to the trash. Its fields are
+// exported so callers can keep it, e.g. in undo data, and restore it later.
+type Item struct {
+	// OriginalPath is the absolute path the item was trashed from.
+	OriginalPath string `json:"originalPath"`
+	// TrashedPath is where the item now lives inside the trash. It is
+	// empty when the platform doesn't say.
+	TrashedPath string    `json:"trashedPath,omitempty"`
+	DeletedAt   time.Time `json:"deletedAt"`
+}
+
+// Trash moves files and folders out of the way so that they can be
+// restored, instead of deleting them.
+type Trash interface {
+	// Move sends path to the trash.
+	Move(path string) (Item, error)
+	// Restore moves an item back to its original path. It fails if
+	// something else has taken that path since.
+	Restore(item Item) error
+}
+
+// New returns the current user's trash for this platform, or
+// ErrUnsupported.
+func New() (Trash, error) {
+	return newPlatform()
+}
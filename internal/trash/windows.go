@@ -0,0 +1,107 @@
+//go:build windows && !386
+
+package trash
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+var (
+	procSHFileOperationW   = syscall.NewLazyDLL("shell32.dll").NewProc("SHFileOperationW")
+	kernel32               = syscall.NewLazyDLL("kernel32.dll")
+	procGetVolumePathNameW = kernel32.NewProc("GetVolumePathNameW")
+	procGetDriveTypeW      = kernel32.NewProc("GetDriveTypeW")
+)
+
+const (
+	foDelete          = 0x0003
+	fofSilent         = 0x0004
+	fofNoConfirmation = 0x0010
+	fofAllowUndo      = 0x0040
+	fofNoErrorUI      = 0x0400
+	fofNoConfirmMkdir = 0x0200
+	recycleFlags      = fofSilent | fofNoConfirmation | fofAllowUndo | fofNoErrorUI | fofNoConfirmMkdir
+
+	driveRemovable = 2
+	driveRemote    = 4
+)
+
+// shFileOpStruct is SHFILEOPSTRUCTW. It is byte-packed on 32-bit Windows,
+// so that build falls back to ErrUnsupported.
+type shFileOpStruct struct {
+	hwnd                  uintptr
+	wFunc                 uint32
+	pFrom                 *uint16
+	pTo                   *uint16
+	fFlags                uint16
+	fAnyOperationsAborted int32
+	hNameMappings         uintptr
+	lpszProgressTitle     *uint16
+}
+
+// recycleBin sends items to the Recycle Bin with SHFileOperation. Windows
+// doesn't say where an item went, so items are restored from the Recycle
+// Bin in Explorer rather than by Restore. Network and removable drives
+// have no Recycle Bin, and SHFileOperation would delete from them for good
+// without saying so, so Move fails with ErrUnavailable there.
+type recycleBin struct{}
+
+func newPlatform() (Trash, error) {
+	if err := procSHFileOperationW.Find(); err != nil {
+		return nil, ErrUnsupported
+	}
+	return recycleBin{}, nil
+}
+
+func (recycleBin) Move(path string) (Item, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return Item{}, err
+	}
+	if noRecycleBin(path) {
+		return Item{}, fmt.Errorf("%w: %s is on a network or removable drive", ErrUnavailable, filepath.VolumeName(path))
+	}
+	from, err := syscall.UTF16FromString(path)
+	if err != nil {
+		return Item{}, err
+	}
+	// pFrom is a list of paths ending in an empty string.
+	from = append(from, 0)
+
+	op := shFileOpStruct{wFunc: foDelete, pFrom: &from[0], fFlags: recycleFlags}
+	if r, _, _ := procSHFileOperationW.Call(uintptr(unsafe.Pointer(&op))); r != 0 {
+		return Item{}, fmt.Errorf("moving %s to the Recycle Bin failed with code %#x", path, r)
+	}
+	if op.fAnyOperationsAborted != 0 {
+		return Item{}, fmt.Errorf("moving %s to the Recycle Bin was aborted", path)
+	}
+	return Item{OriginalPath: path, DeletedAt: time.Now()}, nil
+}
+
+// noRecycleBin reports whether path is on a network or removable drive.
+func noRecycleBin(path string) bool {
+	// A UNC path, \\server\share, is on the network.
+	unc := strings.HasPrefix(path, `\\`) && !strings.HasPrefix(path, `\\?\`)
+	p, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return unc
+	}
+	root := make([]uint16, syscall.MAX_PATH+1)
+	if r, _, _ := procGetVolumePathNameW.Call(uintptr(unsafe.Pointer(p)), uintptr(unsafe.Pointer(&root[0])), uintptr(len(root))); r == 0 {
+		return unc
+	}
+	switch r, _, _ := procGetDriveTypeW.Call(uintptr(unsafe.Pointer(&root[0]))); r {
+	case driveRemovable, driveRemote:
+		return true
+	}
+	return false
+}
+
+func (recycleBin) Restore(Item) error {
+	return ErrUnsupported
+}
@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dale-tomson/declutter/internal/i18n"
+	"github.com/dale-tomson/declutter/internal/organizer"
+)
+
+// defaultArchiveYears is how old a month must be, in years, before the
+// archive dialog offers to pack it.
+const defaultArchiveYears = 2
+
+// onArchive asks how old months must be, then packs those of the selected
+// folder into archives after a confirmation.
+func (a *App) onArchive() {
+	if a.running {
+		return
+	}
+	if a.selectedFolder == "" {
+		dialog.ShowInformation(i18n.T("dialog.info"), i18n.T("dialog.selectFirst"), a.window)
+		return
+	}
+	yearsEntry := widget.NewEntry()
+	yearsEntry.SetText(strconv.Itoa(defaultArchiveYears))
+	yearsEntry.Validator = func(s string) error {
+		if years, err := strconv.Atoi(s); err != nil || years < 0 {
+			return errors.New(i18n.T("archive.invalidYears"))
+		}
+		return nil
+	}
+
+	items := []*widget.FormItem{{Text: i18n.T("archive.years"), Widget: yearsEntry, HintText: i18n.T("archive.years.hint")}}
+	dir := a.selectedFolder
+	dialog.ShowForm(i18n.T("archive.title"), i18n.T("archive.next"), i18n.T("dialog.cancel"), items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		years, _ := strconv.Atoi(yearsEntry.Text)
+		org := organizer.NewWithOptions(dir, organizer.Options{Trash: a.trash}, a.log)
+		months, err := org.ArchiveCandidates(organizer.ArchiveCutoff(time.Now(), years))
+		switch {
+		case err != nil:
+			dialog.ShowError(err, a.window)
+		case len(months) == 0:
+			dialog.ShowInformation(i18n.T("archive.title"), i18n.T("archive.none", years), a.window)
+		default:
+			dialog.ShowConfirm(i18n.T("archive.title"), i18n.T("archive.confirm", len(months)), func(confirmed bool) {
+				if !confirmed {
+					return
+				}
+				a.clearLog()
+				a.setRunning(true)
+				a.current = org
+				a.tabs.SelectIndex(1)
+				a.statusLabel.SetText(i18n.T("status.archiving"))
+				go a.archiveMonths(org, months)
+			}, a.window)
+		}
+	}, a.window)
+}
+
+// archiveMonths packs the months. It runs off the UI goroutine.
+func (a *App) archiveMonths(org *organizer.Organizer, months []organizer.MonthFolder) {
+	archived, err := org.Archive(months)
+	status := i18n.T("archive.done", archived, len(months))
+	a.log(status)
+	fyne.Do(func() {
+		a.setRunning(false)
+		if errors.Is(err, organizer.ErrCancelled) {
+			status = i18n.T("status.cancelled")
+		}
+		a.statusLabel.SetText(status)
+	})
+}
+
+// onRestoreArchive lets the user pick a month archive and unpacks it.
+func (a *App) onRestoreArchive() {
+	if a.running {
+		return
+	}
+	d := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, a.window)
+			return
+		}
+		if reader == nil {
+			return
+		}
+		path := reader.URI().Path()
+		reader.Close()
+
+		a.clearLog()
+		a.setRunning(true)
+		a.tabs.SelectIndex(1)
+		a.statusLabel.SetText(i18n.T("status.restoring"))
+		go a.restoreArchive(path)
+	}, a.window)
+	d.SetFilter(storage.NewExtensionFileFilter([]string{organizer.ArchiveExt}))
+	d.Show()
+}
+
+// restoreArchive unpacks one archive. It runs off the UI goroutine.
+func (a *App) restoreArchive(path string) {
+	org := organizer.NewWithOptions(".", organizer.Options{Trash: a.trash}, a.log)
+	restored, skipped, err := org.RestoreArchive(path)
+	status := i18n.T("archive.restored", restored, skipped)
+	if err != nil {
+		a.log(i18n.T("log.error", err))
+		status = i18n.T("status.error")
+	}
+	a.log(status)
+	fyne.Do(func() {
+		a.setRunning(false)
+		a.statusLabel.SetText(status)
+	})
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+
+	"github.com/dale-tomson/declutter/internal/organizer"
+)
+
+func TestArchiveAndRestore(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	w := app.NewWindow("Test")
+	ui := New(w)
+	ui.trash = nil
+	w.SetContent(ui.GetContent())
+
+	dir := t.TempDir()
+	month := filepath.Join(dir, "2019", "03-March")
+	if err := os.MkdirAll(month, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(month, "a.jpg"), []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	org := organizer.New(dir, ui.log)
+	ui.setRunning(true)
+	ui.archiveMonths(org, []organizer.MonthFolder{{Path: month, Rel: "2019/03-March"}})
+	if ui.running || ui.statusLabel.Text != "Archived 1 of 1 months" {
+		t.Errorf("unexpected status %q", ui.statusLabel.Text)
+	}
+	if _, err := os.Stat(month + organizer.ArchiveExt); err != nil {
+		t.Fatalf("expected the archive: %v", err)
+	}
+
+	ui.setRunning(true)
+	ui.restoreArchive(month + organizer.ArchiveExt)
+	if ui.running || ui.statusLabel.Text != "Restored 1 files, kept 0 already there" {
+		t.Errorf("unexpected status %q", ui.statusLabel.Text)
+	}
+	if _, err := os.Stat(filepath.Join(month, "a.jpg")); err != nil {
+		t.Errorf("expected a.jpg back: %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package ui
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dale-tomson/declutter/internal/i18n"
+	"github.com/dale-tomson/declutter/internal/organizer"
+	"github.com/dale-tomson/declutter/internal/trash"
+)
+
+var (
+	shortcutSelect   = &desktop.CustomShortcut{KeyName: fyne.KeyO, Modifier: fyne.KeyModifierShortcutDefault}
+	shortcutOrganize = &desktop.CustomShortcut{KeyName: fyne.KeyReturn, Modifier: fyne.KeyModifierShortcutDefault}
+	shortcutClearLog = &desktop.CustomShortcut{KeyName: fyne.KeyL, Modifier: fyne.KeyModifierShortcutDefault}
+	shortcutFind     = &desktop.CustomShortcut{KeyName: fyne.KeyF, Modifier: fyne.KeyModifierShortcutDefault}
+)
+
+// tabKeys switch tabs with Ctrl+1 to Ctrl+4, since the tab bar itself
+// can't take keyboard focus.
+var tabKeys = []fyne.KeyName{fyne.Key1, fyne.Key2, fyne.Key3, fyne.Key4}
+
+var tabTitles = []string{"tab.files", "tab.log", "tab.queue", "tab.folders"}
+
+// lastRun is what Undo needs to reverse the most recent run.
+type lastRun struct {
+	dir     string
+	results []organizer.Result
+	// trashed is what the cleanup moved to the trash.
+	trashed []trash.Item
+}
+
+func (r *lastRun) moved() int {
+	moved := 0
+	for _, result := range r.results {
+		if result.Status == organizer.StatusMoved {
+			moved++
+		}
+	}
+	return moved
+}
+
+// undoable counts the files Undo would put back.
+func (r *lastRun) undoable() int {
+	return r.moved() + len(r.trashed)
+}
+
+// setupKeyboard registers the shortcuts on the window canvas and adds the
+// main menu. Ctrl+Z is registered as Fyne's undo shortcut, so a focused
+// text field still gets it first.
+func (a *App) setupKeyboard() {
+	c := a.window.Canvas()
+	c.AddShortcut(shortcutSelect, func(fyne.Shortcut) { a.press(a.selectFolderBtn) })
+	c.AddShortcut(shortcutOrganize, func(fyne.Shortcut) { a.press(a.organizeBtn) })
+	c.AddShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyEnter, Modifier: fyne.KeyModifierShortcutDefault},
+		func(fyne.Shortcut) { a.press(a.organizeBtn) })
+	c.AddShortcut(&fyne.ShortcutUndo{}, func(fyne.Shortcut) { a.onUndo() })
+	c.AddShortcut(shortcutClearLog, func(fyne.Shortcut) { a.clearLog() })
+	c.AddShortcut(shortcutFind, func(fyne.Shortcut) { a.findInLog() })
+	for i, key := range tabKeys {
+		c.AddShortcut(&desktop.CustomShortcut{KeyName: key, Modifier: fyne.KeyModifierShortcutDefault},
+			func(fyne.Shortcut) { a.tabs.SelectIndex(i) })
+	}
+	c.SetOnTypedKey(func(ev *fyne.KeyEvent) {
+		if ev.Name == fyne.KeyEscape {
+			a.cancel()
+		}
+	})
+
+	a.window.SetMainMenu(a.mainMenu())
+}
+
+// mainMenu offers the same actions as the buttons and shortcuts. Undo is
+// left without a menu accelerator: menu shortcuts take precedence over the
+// focused widget and would steal Ctrl+Z from text fields.
+func (a *App) mainMenu() *fyne.MainMenu {
+	item := func(key string, shortcut fyne.Shortcut, action func()) *fyne.MenuItem {
+		m := fyne.NewMenuItem(i18n.T(key), action)
+		m.Shortcut = shortcut
+		return m
+	}
+
+	file := fyne.NewMenu(i18n.T("menu.file"),
+		item("button.select", shortcutSelect, func() { a.press(a.selectFolderBtn) }),
+		item("button.organize", shortcutOrganize, func() { a.press(a.organizeBtn) }),
+		item("button.addToQueue", nil, func() { a.press(a.addToQueueBtn) }),
+		item("button.runQueue", nil, func() { a.press(a.runQueueBtn) }),
+		item("button.saveReport", nil, func() { a.press(a.saveReportBtn) }),
+		fyne.NewMenuItemSeparator(),
+		item("menu.archive", nil, a.onArchive),
+		item("menu.restoreArchive", nil, a.onRestoreArchive),
+		item("menu.verify", nil, a.onVerify),
+		fyne.NewMenuItemSeparator(),
+		item("button.settings", nil, a.onSettings),
+	)
+	edit := fyne.NewMenu(i18n.T("menu.edit"),
+		item("menu.undo", nil, a.onUndo),
+		item("menu.cancel", nil, a.cancel),
+		fyne.NewMenuItemSeparator(),
+		item("menu.find", shortcutFind, a.findInLog),
+		item("menu.clearLog", shortcutClearLog, a.clearLog),
+	)
+	view := fyne.NewMenu(i18n.T("menu.view"))
+	for i, key := range tabKeys {
+		view.Items = append(view.Items, item(tabTitles[i],
+			&desktop.CustomShortcut{KeyName: key, Modifier: fyne.KeyModifierShortcutDefault},
+			func() { a.tabs.SelectIndex(i) }))
+	}
+	return fyne.NewMainMenu(file, edit, view)
+}
+
+// press runs a button's action from the keyboard or menu, unless the
+// button is disabled.
+func (a *App) press(btn *widget.Button) {
+	if !btn.Disabled() && btn.OnTapped != nil {
+		btn.OnTapped()
+	}
+}
+
+func (a *App) focus(obj fyne.Focusable) {
+	if c := a.window.Canvas(); c != nil {
+		c.Focus(obj)
+	}
+}
+
+// findInLog shows the activity log and puts the cursor in its find field.
+func (a *App) findInLog() {
+	a.tabs.SelectIndex(1)
+	a.focus(a.logSearch)
+}
+
+// showLog fills the log view, keeping only the lines that match the find
+// field.
+func (a *App) showLog() {
+	lines := a.logLines
+	if query := strings.ToLower(strings.TrimSpace(a.logSearch.Text)); query != "" {
+		lines = nil
+		for _, line := range a.logLines {
+			if strings.Contains(strings.ToLower(line), query) {
+				lines = append(lines, line)
+			}
+		}
+	}
+	a.logOutput.SetText(strings.Join(lines, "\n"))
+	a.logOutput.CursorRow = len(lines) - 1
+}
+
+// cancel stops the scan, run or queue in progress after the current file.
+func (a *App) cancel() {
+	if !a.running {
+		return
+	}
+	a.cancelRequested = true
+	if a.current != nil {
+		a.current.Cancel()
+	}
+	a.statusLabel.SetText(i18n.T("status.cancelling"))
+}
+
+func (a *App) onUndo() {
+	if a.running {
+		return
+	}
+	if a.lastRun == nil || a.lastRun.undoable() == 0 {
+		dialog.ShowInformation(i18n.T("dialog.info"), i18n.T("undo.nothing"), a.window)
+		return
+	}
+	run := a.lastRun
+	dialog.ShowConfirm(i18n.T("undo.title"), i18n.T("undo.confirm", run.undoable(), run.dir), func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		a.setRunning(true)
+		a.tabs.SelectIndex(1)
+		a.statusLabel.SetText(i18n.T("status.undoing"))
+		go a.undoRun(run)
+	}, a.window)
+}
+
+// undoRun takes what the cleanup removed back out of the trash, then moves
+// the files of run back. It runs off the UI goroutine.
+func (a *App) undoRun(run *lastRun) {
+	org := organizer.NewWithOptions(run.dir, organizer.Options{Trash: a.trash}, a.log)
+	restored, failed := org.RestoreTrashed(run.trashed)
+	moved, notMoved := org.Undo(run.results)
+	restored += moved
+	failed += notMoved
+	a.log(i18n.T("undo.done", restored, failed))
+	fyne.Do(func() {
+		if a.lastRun == run {
+			a.lastRun = nil
+		}
+		a.setRunning(false)
+		a.statusLabel.SetText(i18n.T("undo.done", restored, failed))
+	})
+}
@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+
+	"github.com/dale-tomson/declutter/internal/organizer"
+	"github.com/dale-tomson/declutter/internal/trash"
+)
+
+func TestLogShortcuts(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	w := app.NewWindow("Test")
+	ui := New(w)
+	w.SetContent(ui.GetContent())
+	ui.log("Moved: a.jpg")
+	ui.log("Skipped (already exists): b.jpg")
+
+	w.Canvas().(fyne.Shortcutable).TypedShortcut(shortcutFind)
+	if ui.tabs.SelectedIndex() != 1 || w.Canvas().Focused() != ui.logSearch {
+		t.Error("expected find to show the log and focus its find field")
+	}
+	test.Type(ui.logSearch, "skipped")
+	if ui.logOutput.Text != "Skipped (already exists): b.jpg" {
+		t.Errorf("expected only the matching line, got %q", ui.logOutput.Text)
+	}
+
+	w.Canvas().(fyne.Shortcutable).TypedShortcut(shortcutClearLog)
+	if len(ui.logLines) != 0 || ui.logOutput.Text != "" {
+		t.Errorf("expected the log to be cleared, got %q", ui.logOutput.Text)
+	}
+}
+
+func TestShortcutsFollowButtons(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	w := app.NewWindow("Test")
+	ui := New(w)
+	w.SetContent(ui.GetContent())
+
+	organized := false
+	ui.organizeBtn.OnTapped = func() { organized = true }
+	w.Canvas().(fyne.Shortcutable).TypedShortcut(shortcutOrganize)
+	if organized {
+		t.Error("expected the shortcut to do nothing while the button is disabled")
+	}
+	ui.organizeBtn.Enable()
+	w.Canvas().(fyne.Shortcutable).TypedShortcut(shortcutOrganize)
+	if !organized {
+		t.Error("expected the shortcut to organize")
+	}
+
+	if menu := w.MainMenu(); menu == nil || len(menu.Items) != 3 {
+		t.Errorf("expected File, Edit and View menus, got %+v", menu)
+	}
+}
+
+func TestCancelAndUndo(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	ui := New(app.NewWindow("Test"))
+	dir := t.TempDir()
+	path := filepath.Join(dir, "photo.jpg")
+	if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Date(2024, 3, 15, 12, 0, 0, 0, time.Local)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+
+	ui.enqueue(dir)
+	ui.setRunning(true)
+	ui.cancel()
+	ui.processQueue(ui.organizerOptions())
+	if ui.queue.items[0].state != queuePending || ui.lastRun != nil {
+		t.Fatal("expected a cancelled queue to leave the folder waiting")
+	}
+
+	ui.setRunning(true)
+	ui.processQueue(ui.organizerOptions())
+	if ui.lastRun == nil || ui.lastRun.moved() != 1 {
+		t.Fatalf("expected the run to be recorded for undo, got %+v", ui.lastRun)
+	}
+
+	ui.setRunning(true)
+	ui.undoRun(ui.lastRun)
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expected photo.jpg to be back: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "2024")); !os.IsNotExist(err) {
+		t.Error("expected the year folder to be removed")
+	}
+	if ui.lastRun != nil || ui.running {
+		t.Error("expected the undone run to be forgotten")
+	}
+}
+
+func TestCancelStopsExecute(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	ui := New(app.NewWindow("Test"))
+	org := organizer.New(t.TempDir(), nil)
+	ui.setRunning(true)
+	ui.current = org
+	ui.cancel()
+
+	plan := []organizer.PlannedMove{{File: organizer.FileInfo{Name: "a.txt"}}}
+	if _, _, err := org.Execute(plan); err != organizer.ErrCancelled {
+		t.Errorf("expected the running organizer to be cancelled, got %v", err)
+	}
+}
+
+type dirTrash struct {
+	dir string
+}
+
+func (d dirTrash) Move(path string) (trash.Item, error) {
+	trashed := filepath.Join(d.dir, filepath.Base(path))
+	if err := os.Rename(path, trashed); err != nil {
+		return trash.Item{}, err
+	}
+	return trash.Item{OriginalPath: path, TrashedPath: trashed}, nil
+}
+
+func (d dirTrash) Restore(item trash.Item) error {
+	return os.Rename(item.TrashedPath, item.OriginalPath)
+}
+
+func TestUndoRestoresTrashed(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	ui := New(app.NewWindow("Test"))
+	ui.trash = dirTrash{t.TempDir()}
+	ui.junkCheck.SetChecked(true)
+	dir := t.TempDir()
+	junk := filepath.Join(dir, "Thumbs.db")
+	if err := os.WriteFile(junk, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ui.enqueue(dir)
+	ui.setRunning(true)
+	ui.processQueue(ui.organizerOptions())
+	if _, err := os.Stat(junk); !os.IsNotExist(err) {
+		t.Fatalf("expected Thumbs.db in the trash, got %v", err)
+	}
+	if ui.lastRun == nil || ui.lastRun.undoable() != 1 {
+		t.Fatalf("expected the trashed file to be recorded for undo, got %+v", ui.lastRun)
+	}
+
+	ui.setRunning(true)
+	ui.undoRun(ui.lastRun)
+	if _, err := os.Stat(junk); err != nil {
+		t.Errorf("expected Thumbs.db to be back: %v", err)
+	}
+	if ui.statusLabel.Text != "Restored 1 files, 0 could not be restored" {
+		t.Errorf("unexpected status %q", ui.statusLabel.Text)
+	}
+}
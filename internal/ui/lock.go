@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2/dialog"
+
+	"github.com/dale-tomson/declutter/internal/i18n"
+	"github.com/dale-tomson/declutter/internal/organizer"
+)
+
+// offerUnlock explains that another run holds dir and offers to force the
+// lock open, for when that run is known to be gone. Organizing starts once
+// it is unlocked.
+func (a *App) offerUnlock(dir string, owner organizer.LockInfo) {
+	message := i18n.T("lock.message", owner.PID, owner.Hostname, owner.Started.Local().Format(time.DateTime))
+	d := dialog.NewConfirm(i18n.T("lock.title"), message, func(force bool) {
+		if !force {
+			return
+		}
+		if err := organizer.ForceUnlock(dir); err != nil {
+			dialog.ShowError(err, a.window)
+			return
+		}
+		a.log(i18n.T("lock.unlocked", dir))
+		a.onOrganize()
+	}, a.window)
+	d.SetConfirmText(i18n.T("lock.force"))
+	d.SetDismissText(i18n.T("dialog.cancel"))
+	d.Show()
+}
@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+)
+
+func TestOrganizeLockedFolder(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	w := app.NewWindow("Test")
+	ui := New(w)
+	w.SetContent(ui.GetContent())
+
+	dir := t.TempDir()
+	now := time.Now().UTC().Format(time.RFC3339)
+	lock := `{"pid":42,"hostname":"elsewhere.invalid","started":"` + now + `","updated":"` + now + `"}`
+	if err := os.WriteFile(filepath.Join(dir, ".declutter-lock"), []byte(lock), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ui.selectFolder(dir)
+	ui.background.Wait()
+	ui.onOrganize()
+	if ui.running {
+		t.Error("expected organizing not to start while the folder is locked")
+	}
+	if w.Canvas().Overlays().Top() == nil {
+		t.Error("expected a dialog offering to unlock the folder")
+	}
+}
@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/dale-tomson/declutter/internal/i18n"
+	"github.com/dale-tomson/declutter/internal/organizer"
+)
+
+// notifyMode decides when finished runs raise a desktop notification.
+type notifyMode string
+
+const (
+	notifyUnfocused notifyMode = "unfocused"
+	notifyAlways    notifyMode = "always"
+	notifyOff       notifyMode = "off"
+)
+
+var notifyModes = []notifyMode{notifyUnfocused, notifyAlways, notifyOff}
+
+// folderNotifyLabel names a folder's notification mode, where "" follows
+// Settings.
+func folderNotifyLabel(mode notifyMode) string {
+	if mode == "" {
+		return i18n.T("notify.default")
+	}
+	return notifyModeLabel(mode)
+}
+
+func notifyModeLabel(mode notifyMode) string {
+	switch mode {
+	case notifyAlways:
+		return i18n.T("notify.always")
+	case notifyOff:
+		return i18n.T("notify.off")
+	}
+	return i18n.T("notify.unfocused")
+}
+
+// notifyDelay is how long the notifier waits for more runs to finish
+// before sending, so that a burst of runs gives a single notification.
+const notifyDelay = 3 * time.Second
+
+// maxFailedNames caps the failed files listed in a notification.
+const maxFailedNames = 3
+
+// runSummary is the outcome of one folder's run. err is set when the run
+// couldn't complete at all.
+type runSummary struct {
+	folder  string
+	moved   int
+	skipped int
+	failed  []string
+	err     error
+}
+
+func summarize(folder string, results []organizer.Result, err error) runSummary {
+	s := runSummary{folder: folder, err: err}
+	for _, r := range results {
+		switch r.Status {
+		case organizer.StatusMoved:
+			s.moved++
+		case organizer.StatusSkipped:
+			s.skipped++
+		case organizer.StatusFailed:
+			s.failed = append(s.failed, r.Move.File.Name)
+		}
+	}
+	return s
+}
+
+// notifier batches run summaries and sends them as one notification once
+// no more have arrived for delay. A zero delay sends straight away.
+type notifier struct {
+	delay time.Duration
+	send  func([]runSummary)
+
+	mu      sync.Mutex
+	pending []runSummary
+	timer   *time.Timer
+}
+
+func (n *notifier) add(summaries ...runSummary) {
+	n.mu.Lock()
+	n.pending = append(n.pending, summaries...)
+	if n.delay == 0 {
+		n.mu.Unlock()
+		n.flush()
+		return
+	}
+	if n.timer != nil {
+		n.timer.Stop()
+	}
+	n.timer = time.AfterFunc(n.delay, n.flush)
+	n.mu.Unlock()
+}
+
+func (n *notifier) flush() {
+	n.mu.Lock()
+	batch := n.pending
+	n.pending = nil
+	n.mu.Unlock()
+	if len(batch) > 0 {
+		n.send(batch)
+	}
+}
+
+// notification words a batch of summaries: one folder gets its counts and
+// failed files, several get totals.
+func notification(batch []runSummary) *fyne.Notification {
+	if len(batch) == 1 {
+		s := batch[0]
+		name := filepath.Base(s.folder)
+		if s.err != nil {
+			return fyne.NewNotification(i18n.T("notify.failedTitle", name), s.err.Error())
+		}
+		content := i18n.T("notify.counts", s.moved, s.skipped, len(s.failed))
+		if len(s.failed) > 0 {
+			content += "\n" + failedNames(s.failed)
+		}
+		return fyne.NewNotification(i18n.T("notify.doneTitle", name), content)
+	}
+
+	var moved, skipped, failed, errored int
+	var names []string
+	for _, s := range batch {
+		moved += s.moved
+		skipped += s.skipped
+		failed += len(s.failed)
+		names = append(names, s.failed...)
+		if s.err != nil {
+			errored++
+		}
+	}
+	content := i18n.T("notify.counts", moved, skipped, failed)
+	if errored > 0 {
+		content += "\n" + i18n.T("notify.foldersFailed", errored)
+	}
+	if len(names) > 0 {
+		content += "\n" + failedNames(names)
+	}
+	return fyne.NewNotification(i18n.T("notify.batchTitle", len(batch)), content)
+}
+
+func failedNames(names []string) string {
+	if len(names) <= maxFailedNames {
+		return i18n.T("notify.failedFiles", strings.Join(names, ", "))
+	}
+	return i18n.T("notify.failedFilesMore", strings.Join(names[:maxFailedNames], ", "), len(names)-maxFailedNames)
+}
+
+// setupNotifications tracks whether the window has focus, which desktop
+// drivers report as the app entering and leaving the foreground.
+func (a *App) setupNotifications() {
+	a.focused = true
+	a.notifier = &notifier{delay: notifyDelay, send: func(batch []runSummary) {
+		fyne.Do(func() { a.sendNotification(batch) })
+	}}
+	lifecycle := fyne.CurrentApp().Lifecycle()
+	lifecycle.SetOnEnteredForeground(func() { a.focused = true })
+	lifecycle.SetOnExitedForeground(func() { a.focused = false })
+}
+
+// notify queues finished runs for a desktop notification.
+func (a *App) notify(summaries ...runSummary) {
+	for _, s := range summaries {
+		if a.notifyModeFor(s.folder) != notifyOff {
+			a.notifier.add(s)
+		}
+	}
+}
+
+// notifyModeFor returns the notification mode for runs of folder: its own
+// choice in the Folders tab, or the one in Settings.
+func (a *App) notifyModeFor(folder string) notifyMode {
+	if mode := a.history.notifyMode(folder); mode != "" {
+		return mode
+	}
+	return a.settings.notify
+}
+
+func (a *App) sendNotification(batch []runSummary) {
+	var send []runSummary
+	for _, s := range batch {
+		switch a.notifyModeFor(s.folder) {
+		case notifyOff:
+			continue
+		case notifyUnfocused:
+			if a.focused {
+				continue
+			}
+		}
+		send = append(send, s)
+	}
+	if len(send) > 0 {
+		fyne.CurrentApp().SendNotification(notification(send))
+	}
+}
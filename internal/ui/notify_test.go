@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+
+	"github.com/dale-tomson/declutter/internal/organizer"
+)
+
+func TestNotificationText(t *testing.T) {
+	results := []organizer.Result{
+		{Status: organizer.StatusMoved},
+		{Status: organizer.StatusSkipped},
+	}
+	for _, name := range []string{"a.jpg", "b.jpg", "c.jpg", "d.jpg"} {
+		results = append(results, organizer.Result{Move: organizer.PlannedMove{File: organizer.FileInfo{Name: name}}, Status: organizer.StatusFailed})
+	}
+
+	n := notification([]runSummary{summarize("/home/me/Pictures", results, nil)})
+	if n.Title != "Organized Pictures" {
+		t.Errorf("unexpected title %q", n.Title)
+	}
+	if want := "1 moved, 1 skipped, 4 failed\nFailed: a.jpg, b.jpg, c.jpg and 1 more"; n.Content != want {
+		t.Errorf("expected %q, got %q", want, n.Content)
+	}
+
+	n = notification([]runSummary{{folder: "/tmp/Inbox", err: errors.New("permission denied")}})
+	if n.Title != "Organizing Inbox failed" || n.Content != "permission denied" {
+		t.Errorf("unexpected failure notification %+v", n)
+	}
+
+	n = notification([]runSummary{{folder: "/a", moved: 2}, {folder: "/b", moved: 3, failed: []string{"x.png"}}, {folder: "/c", err: errors.New("gone")}})
+	if n.Title != "Organized 3 folders" {
+		t.Errorf("unexpected batch title %q", n.Title)
+	}
+	if want := "5 moved, 0 skipped, 1 failed\n1 folders could not be organized\nFailed: x.png"; n.Content != want {
+		t.Errorf("expected %q, got %q", want, n.Content)
+	}
+}
+
+func TestNotifierBatches(t *testing.T) {
+	sent := make(chan []runSummary, 2)
+	n := &notifier{delay: 20 * time.Millisecond, send: func(batch []runSummary) { sent <- batch }}
+	n.add(runSummary{folder: "/a"})
+	n.add(runSummary{folder: "/b"}, runSummary{folder: "/c"})
+
+	select {
+	case batch := <-sent:
+		if len(batch) != 3 {
+			t.Errorf("expected one batch of 3, got %d", len(batch))
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the batch to be sent")
+	}
+	select {
+	case batch := <-sent:
+		t.Errorf("expected a single notification, got another with %d", len(batch))
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestNotifySkipsFocusedWindow(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	ui := New(app.NewWindow("Test"))
+	ui.notifier.delay = 0
+	summary := runSummary{folder: "/tmp/Inbox", moved: 1}
+
+	ui.settings.notify = notifyUnfocused
+	test.AssertNotificationSent(t, nil, func() { ui.notify(summary) })
+
+	ui.focused = false
+	test.AssertNotificationSent(t, notification([]runSummary{summary}), func() { ui.notify(summary) })
+
+	ui.settings.notify = notifyOff
+	test.AssertNotificationSent(t, nil, func() { ui.notify(summary) })
+
+	ui.focused = true
+	ui.settings.notify = notifyAlways
+	test.AssertNotificationSent(t, notification([]runSummary{summary}), func() { ui.notify(summary) })
+}
+
+func TestNotifyPerFolder(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	ui := New(app.NewWindow("Test"))
+	ui.notifier.delay = 0
+	ui.focused = false
+	inbox := runSummary{folder: "/tmp/Inbox", moved: 1}
+	other := runSummary{folder: "/tmp/Other", moved: 2}
+
+	ui.settings.notify = notifyOff
+	ui.history.touch(inbox.folder)
+	ui.history.setNotify(inbox.folder, notifyAlways)
+	ui.saveHistory()
+	test.AssertNotificationSent(t, notification([]runSummary{inbox}), func() { ui.notify(inbox, other) })
+
+	ui.settings.notify = notifyAlways
+	ui.history.setNotify(inbox.folder, notifyOff)
+	test.AssertNotificationSent(t, notification([]runSummary{other}), func() { ui.notify(inbox, other) })
+
+	saved := loadFolderHistory(ui.prefs)
+	if mode := saved.notifyMode(inbox.folder); mode != notifyAlways {
+		t.Errorf("expected the folder's mode to be saved, got %q", mode)
+	}
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dale-tomson/declutter/internal/i18n"
+	"github.com/dale-tomson/declutter/internal/organizer"
+)
+
+// issueText describes a preflight issue in the interface language.
+func issueText(issue organizer.PreflightIssue) string {
+	if issue.Kind == organizer.PreflightSpace {
+		return i18n.T("preflight.space", organizer.FormatSize(issue.Need), organizer.FormatSize(issue.Free))
+	}
+	rel := issue.Rel
+	if rel == "" {
+		rel = i18n.T("preflight.sourceFolder")
+	}
+	return i18n.T("preflight."+string(issue.Kind), rel)
+}
+
+// showPreflight lists the problems found before a run. Blocking ones end
+// it there; otherwise the user may carry on to the preview.
+func (a *App) showPreflight(org *organizer.Organizer, plan []organizer.PlannedMove, cleanup []organizer.CleanupItem, issues []organizer.PreflightIssue) {
+	lines := make([]string, len(issues))
+	for i, issue := range issues {
+		lines[i] = issueText(issue)
+		a.log(lines[i])
+	}
+	list := widget.NewLabel(strings.Join(lines, "\n"))
+	list.Wrapping = fyne.TextWrapWord
+
+	blocking := organizer.HasBlocking(issues)
+	intro := i18n.T("preflight.warnings")
+	if blocking {
+		intro = i18n.T("preflight.blocking")
+	}
+	content := container.NewBorder(widget.NewLabel(intro), nil, nil, nil, container.NewVScroll(list))
+
+	var d dialog.Dialog
+	if blocking {
+		d = dialog.NewCustom(i18n.T("preflight.title"), i18n.T("dialog.cancel"), content, a.window)
+		d.SetOnClosed(func() { a.idle(i18n.T("status.preflightFailed")) })
+	} else {
+		d = dialog.NewCustomConfirm(i18n.T("preflight.title"), i18n.T("preflight.continue"), i18n.T("dialog.cancel"), content, func(confirmed bool) {
+			if !confirmed {
+				a.idle("")
+				return
+			}
+			a.showPreview(org, plan, cleanup)
+		}, a.window)
+	}
+	d.Resize(fyne.NewSize(560, 360))
+	d.Show()
+}
@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+
+	"github.com/dale-tomson/declutter/internal/organizer"
+)
+
+func TestIssueText(t *testing.T) {
+	tests := []struct {
+		issue organizer.PreflightIssue
+		want  string
+	}{
+		{organizer.PreflightIssue{Kind: organizer.PreflightSpace, Need: 2_500_000_000, Free: 1_000_000}, "Not enough free space: 2.5 GB to copy, 1.0 MB available"},
+		{organizer.PreflightIssue{Kind: organizer.PreflightUnwritable}, "The selected folder: folder is not writable"},
+		{organizer.PreflightIssue{Kind: organizer.PreflightInvalidName, Rel: "2024/03-March/a?.jpg"}, "2024/03-March/a?.jpg: name not allowed on the destination drive"},
+	}
+	for _, tt := range tests {
+		if got := issueText(tt.issue); got != tt.want {
+			t.Errorf("issueText(%+v) = %q, want %q", tt.issue, got, tt.want)
+		}
+	}
+}
+
+func TestShowPreflight(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	w := app.NewWindow("Test")
+	ui := New(w)
+	w.SetContent(ui.GetContent())
+
+	org := organizer.New(t.TempDir(), nil)
+	ui.setRunning(true)
+	ui.showPreflight(org, nil, nil, []organizer.PreflightIssue{{Kind: organizer.PreflightSpace, Blocking: true, Need: 2000, Free: 1000}})
+	if w.Canvas().Overlays().Top() == nil {
+		t.Fatal("expected a dialog listing the problems")
+	}
+	if !ui.running {
+		t.Error("expected the run to wait for the dialog")
+	}
+}
@@ -0,0 +1,299 @@
+package ui
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dale-tomson/declutter/internal/i18n"
+	"github.com/dale-tomson/declutter/internal/organizer"
+)
+
+// preview groups a plan into a year → month → file tree. Node IDs are the
+// year ("2024"), the month folder ("2024/03-March") and, for files, the
+// month folder plus the file's index in the plan ("2024/03-March/7").
+// Unticking a node sets Excluded on the planned moves beneath it. Items
+// of the cleanup pass sit under a separate "cleanup" node, as
+// "cleanup/<index>".
+type preview struct {
+	plan    []organizer.PlannedMove
+	cleanup []organizer.CleanupItem
+	years   []string
+	folders map[string][]string
+	files   map[string][]int
+}
+
+const cleanupNode = "cleanup"
+
+func newPreview(plan []organizer.PlannedMove, cleanup []organizer.CleanupItem) *preview {
+	p := &preview{
+		plan:    plan,
+		cleanup: cleanup,
+		folders: make(map[string][]string),
+		files:   make(map[string][]int),
+	}
+	for i, move := range plan {
+		year, _, _ := strings.Cut(move.Folder, "/")
+		if _, ok := p.folders[year]; !ok {
+			p.years = append(p.years, year)
+		}
+		if _, ok := p.files[move.Folder]; !ok {
+			p.folders[year] = append(p.folders[year], move.Folder)
+		}
+		p.files[move.Folder] = append(p.files[move.Folder], i)
+	}
+	sort.Strings(p.years)
+	for _, folders := range p.folders {
+		sort.Strings(folders)
+	}
+	return p
+}
+
+// cleanupIndices returns the cleanup items under a node, and whether the
+// node belongs to the cleanup branch at all.
+func (p *preview) cleanupIndices(id widget.TreeNodeID) ([]int, bool) {
+	if id == cleanupNode {
+		indices := make([]int, len(p.cleanup))
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices, true
+	}
+	rest, ok := strings.CutPrefix(id, cleanupNode+"/")
+	if !ok {
+		return nil, false
+	}
+	index, err := strconv.Atoi(rest)
+	if err != nil {
+		return nil, true
+	}
+	return []int{index}, true
+}
+
+func (p *preview) childUIDs(id widget.TreeNodeID) []widget.TreeNodeID {
+	switch {
+	case id == "" && len(p.cleanup) > 0:
+		return append(append([]string(nil), p.years...), cleanupNode)
+	case id == cleanupNode:
+		ids := make([]widget.TreeNodeID, len(p.cleanup))
+		for i := range p.cleanup {
+			ids[i] = fmt.Sprintf("%s/%d", cleanupNode, i)
+		}
+		return ids
+	}
+	switch strings.Count(id, "/") {
+	case 0:
+		if id == "" {
+			return p.years
+		}
+		return p.folders[id]
+	case 1:
+		ids := make([]widget.TreeNodeID, len(p.files[id]))
+		for i, index := range p.files[id] {
+			ids[i] = fmt.Sprintf("%s/%d", id, index)
+		}
+		return ids
+	}
+	return nil
+}
+
+func (p *preview) isBranch(id widget.TreeNodeID) bool {
+	if _, ok := p.cleanupIndices(id); ok {
+		return id == cleanupNode
+	}
+	return strings.Count(id, "/") < 2
+}
+
+// indices returns the plan entries under a node.
+func (p *preview) indices(id widget.TreeNodeID) []int {
+	switch strings.Count(id, "/") {
+	case 0:
+		var indices []int
+		for _, folder := range p.folders[id] {
+			indices = append(indices, p.files[folder]...)
+		}
+		return indices
+	case 1:
+		return p.files[id]
+	}
+	index, err := strconv.Atoi(path.Base(id))
+	if err != nil {
+		return nil
+	}
+	return []int{index}
+}
+
+func (p *preview) setIncluded(id widget.TreeNodeID, included bool) {
+	if indices, ok := p.cleanupIndices(id); ok {
+		for _, i := range indices {
+			p.cleanup[i].Excluded = !included
+		}
+		return
+	}
+	for _, i := range p.indices(id) {
+		p.plan[i].Excluded = !included
+	}
+}
+
+// state reports whether a node is ticked, and whether only some of the
+// files beneath it are.
+func (p *preview) state(id widget.TreeNodeID) (checked, partial bool) {
+	included := 0
+	indices, ok := p.cleanupIndices(id)
+	if ok {
+		for _, i := range indices {
+			if !p.cleanup[i].Excluded {
+				included++
+			}
+		}
+	} else {
+		indices = p.indices(id)
+		for _, i := range indices {
+			if !p.plan[i].Excluded {
+				included++
+			}
+		}
+	}
+	return included > 0, included > 0 && included < len(indices)
+}
+
+func (p *preview) label(id widget.TreeNodeID) (string, widget.Importance) {
+	if indices, ok := p.cleanupIndices(id); ok {
+		return p.cleanupLabel(id, indices)
+	}
+	indices := p.indices(id)
+	if !p.isBranch(id) {
+		move := p.plan[indices[0]]
+		text := move.File.Name
+		if move.Name != move.File.Name {
+			text += " → " + move.Name
+		}
+		switch {
+		case move.Excluded:
+			return text, widget.LowImportance
+		case move.Conflict:
+			return i18n.T("preview.conflict", text), widget.DangerImportance
+		}
+		return text, widget.MediumImportance
+	}
+
+	conflicts := 0
+	for _, i := range indices {
+		if p.plan[i].Conflict && !p.plan[i].Excluded {
+			conflicts++
+		}
+	}
+	text := i18n.T("preview.folder", path.Base(id), len(indices))
+	if checked, _ := p.state(id); !checked {
+		return text, widget.LowImportance
+	}
+	if conflicts > 0 {
+		return text + "  " + i18n.T("preview.conflicts", conflicts), widget.WarningImportance
+	}
+	return text, widget.MediumImportance
+}
+
+func (p *preview) summary() string {
+	files, conflicts := 0, 0
+	folders := make(map[string]bool)
+	for _, move := range p.plan {
+		if move.Excluded {
+			continue
+		}
+		if move.Conflict {
+			conflicts++
+			continue
+		}
+		files++
+		folders[move.Folder] = true
+	}
+	summary := i18n.T("preview.summary", files, len(folders), conflicts)
+
+	removed := 0
+	for _, item := range p.cleanup {
+		if !item.Excluded {
+			removed++
+		}
+	}
+	if removed > 0 {
+		summary += "\n" + i18n.T("preview.cleanupSummary", removed)
+	}
+	return summary
+}
+
+func (p *preview) cleanupLabel(id widget.TreeNodeID, indices []int) (string, widget.Importance) {
+	if id == cleanupNode {
+		text := i18n.T("preview.cleanup", len(indices))
+		if checked, _ := p.state(id); !checked {
+			return text, widget.LowImportance
+		}
+		return text, widget.MediumImportance
+	}
+	if len(indices) == 0 || indices[0] >= len(p.cleanup) {
+		return "", widget.MediumImportance
+	}
+	item := p.cleanup[indices[0]]
+	text := i18n.T("preview.cleanupItem", item.Rel, i18n.T("cleanup."+string(item.Kind)))
+	if item.Excluded {
+		return text, widget.LowImportance
+	}
+	return text, widget.MediumImportance
+}
+
+func (p *preview) content(sourceDir string) fyne.CanvasObject {
+	intro := widget.NewLabel(i18n.T("preview.intro", sourceDir))
+	intro.Wrapping = fyne.TextWrapWord
+	summary := widget.NewLabel(p.summary())
+
+	var tree *widget.Tree
+	tree = widget.NewTree(p.childUIDs, p.isBranch,
+		func(bool) fyne.CanvasObject {
+			return container.NewHBox(widget.NewCheck("", nil), widget.NewLabel(""))
+		},
+		func(id widget.TreeNodeID, _ bool, obj fyne.CanvasObject) {
+			row := obj.(*fyne.Container)
+			check := row.Objects[0].(*widget.Check)
+			label := row.Objects[1].(*widget.Label)
+
+			check.OnChanged = nil
+			check.Checked, check.Partial = p.state(id)
+			check.Refresh()
+			check.OnChanged = func(included bool) {
+				p.setIncluded(id, included)
+				summary.SetText(p.summary())
+				tree.Refresh()
+			}
+
+			label.Text, label.Importance = p.label(id)
+			label.Refresh()
+		})
+	for _, year := range p.years {
+		tree.OpenBranch(year)
+	}
+
+	return container.NewBorder(container.NewVBox(intro, summary), nil, nil, nil, tree)
+}
+
+// showPreview lets the user review and trim the plan before it runs.
+func (a *App) showPreview(org *organizer.Organizer, plan []organizer.PlannedMove, cleanup []organizer.CleanupItem) {
+	p := newPreview(plan, cleanup)
+	d := dialog.NewCustomConfirm(i18n.T("dialog.confirm.title"),
+		i18n.T("button.organize"), i18n.T("dialog.cancel"),
+		p.content(org.SourceDir()),
+		func(confirmed bool) {
+			if !confirmed {
+				a.idle("")
+				return
+			}
+			a.performOrganization(org, plan, cleanup)
+		}, a.window)
+	d.Resize(fyne.NewSize(620, 460))
+	d.Show()
+}
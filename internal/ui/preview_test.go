@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dale-tomson/declutter/internal/organizer"
+)
+
+func testPlan() []organizer.PlannedMove {
+	move := func(name, folder string, conflict bool) organizer.PlannedMove {
+		return organizer.PlannedMove{
+			File:     organizer.FileInfo{Name: name},
+			Folder:   folder,
+			Name:     name,
+			Conflict: conflict,
+		}
+	}
+	return []organizer.PlannedMove{
+		move("a.jpg", "2023/12-December", false),
+		move("b.jpg", "2024/01-January", false),
+		move("c.jpg", "2024/01-January", true),
+		move("d.jpg", "2024/03-March", false),
+	}
+}
+
+func TestPreviewTree(t *testing.T) {
+	p := newPreview(testPlan(), nil)
+
+	if got := p.childUIDs(""); len(got) != 2 || got[0] != "2023" || got[1] != "2024" {
+		t.Errorf("unexpected years %v", got)
+	}
+	if got := p.childUIDs("2024"); len(got) != 2 || got[0] != "2024/01-January" {
+		t.Errorf("unexpected months %v", got)
+	}
+	files := p.childUIDs("2024/01-January")
+	if len(files) != 2 || p.isBranch(files[0]) {
+		t.Fatalf("unexpected files %v", files)
+	}
+
+	text, importance := p.label("2024/01-January")
+	if !strings.HasPrefix(text, "01-January (2 files)") || importance != widget.WarningImportance {
+		t.Errorf("unexpected month label %q (%v)", text, importance)
+	}
+	if _, importance := p.label(files[1]); importance != widget.DangerImportance {
+		t.Errorf("expected conflicting file to be highlighted, got %v", importance)
+	}
+	if got := p.summary(); got != "3 files into 3 folders, 1 conflicts will be skipped" {
+		t.Errorf("unexpected summary %q", got)
+	}
+}
+
+func TestPreviewUntick(t *testing.T) {
+	plan := testPlan()
+	p := newPreview(plan, nil)
+
+	p.setIncluded("2024/01-January", false)
+	if !plan[1].Excluded || !plan[2].Excluded || plan[3].Excluded {
+		t.Error("expected only the January files to be excluded")
+	}
+	if checked, partial := p.state("2024"); !checked || !partial {
+		t.Errorf("expected 2024 to be partially ticked, got %v %v", checked, partial)
+	}
+	if _, importance := p.label("2024/01-January"); importance != widget.LowImportance {
+		t.Errorf("expected excluded month to be dimmed, got %v", importance)
+	}
+
+	p.setIncluded("2024/01-January/1", true)
+	if plan[1].Excluded {
+		t.Error("expected b.jpg to be ticked again")
+	}
+	if got := p.summary(); got != "3 files into 3 folders, 0 conflicts will be skipped" {
+		t.Errorf("unexpected summary %q", got)
+	}
+}
+
+func TestPreviewCleanup(t *testing.T) {
+	cleanup := []organizer.CleanupItem{
+		{Rel: "old/Thumbs.db", Kind: organizer.CleanupJunk},
+		{Rel: "old", Kind: organizer.CleanupEmptyDir},
+	}
+	p := newPreview(testPlan(), cleanup)
+
+	if got := p.childUIDs(""); len(got) != 3 || got[2] != cleanupNode {
+		t.Fatalf("expected the cleanup node after the years, got %v", got)
+	}
+	items := p.childUIDs(cleanupNode)
+	if len(items) != 2 || !p.isBranch(cleanupNode) || p.isBranch(items[0]) {
+		t.Fatalf("unexpected cleanup items %v", items)
+	}
+	if text, _ := p.label(items[0]); text != "old/Thumbs.db (junk)" {
+		t.Errorf("unexpected item label %q", text)
+	}
+
+	p.setIncluded(items[1], false)
+	if cleanup[0].Excluded || !cleanup[1].Excluded {
+		t.Error("expected only the folder to be excluded")
+	}
+	if checked, partial := p.state(cleanupNode); !checked || !partial {
+		t.Errorf("expected cleanup to be partially ticked, got %v %v", checked, partial)
+	}
+	if !strings.HasSuffix(p.summary(), "\n1 empty or junk items will be removed") {
+		t.Errorf("unexpected summary %q", p.summary())
+	}
+}
+
+func TestPreviewContent(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	if newPreview(testPlan(), nil).content("/tmp/photos") == nil {
+		t.Fatal("content() returned nil")
+	}
+}
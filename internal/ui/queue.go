@@ -0,0 +1,328 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dale-tomson/declutter/internal/i18n"
+	"github.com/dale-tomson/declutter/internal/organizer"
+	"github.com/dale-tomson/declutter/internal/report"
+)
+
+type queueState int
+
+const (
+	queuePending queueState = iota
+	queueRunning
+	queueDone
+	queueFailed
+)
+
+// queueItem is a folder waiting in, or processed by, the queue. Its fields
+// are only touched on the UI goroutine.
+type queueItem struct {
+	path    string
+	state   queueState
+	moved   int
+	skipped int
+	failed  int
+	err     error
+	results []organizer.Result
+	report  *report.Report
+}
+
+func (item *queueItem) statusText() string {
+	switch item.state {
+	case queueRunning:
+		return i18n.T("queue.running")
+	case queueDone:
+		return i18n.T("queue.done", item.moved, item.skipped, item.failed)
+	case queueFailed:
+		return i18n.T("queue.failed", item.err)
+	}
+	return i18n.T("queue.pending")
+}
+
+type folderQueue struct {
+	items []*queueItem
+}
+
+// add queues folders that aren't already waiting, and returns how many
+// were added.
+func (q *folderQueue) add(paths ...string) int {
+	added := 0
+	for _, path := range paths {
+		if q.pending(path) {
+			continue
+		}
+		q.items = append(q.items, &queueItem{path: path})
+		added++
+	}
+	return added
+}
+
+func (q *folderQueue) pending(path string) bool {
+	for _, item := range q.items {
+		if item.path == path && item.state == queuePending {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *folderQueue) next() *queueItem {
+	for _, item := range q.items {
+		if item.state == queuePending {
+			return item
+		}
+	}
+	return nil
+}
+
+func (q *folderQueue) remove(i int) {
+	if i >= 0 && i < len(q.items) && q.items[i].state != queueRunning {
+		q.items = append(q.items[:i], q.items[i+1:]...)
+	}
+}
+
+func (q *folderQueue) clearFinished() {
+	kept := q.items[:0]
+	for _, item := range q.items {
+		if item.state == queuePending || item.state == queueRunning {
+			kept = append(kept, item)
+		}
+	}
+	q.items = kept
+}
+
+func (a *App) setupQueue() {
+	a.queueList = widget.NewList(
+		func() int { return len(a.queue.items) },
+		func() fyne.CanvasObject {
+			remove := widget.NewButtonWithIcon("", theme.DeleteIcon(), nil)
+			remove.Importance = widget.LowImportance
+			return container.NewBorder(nil, nil, nil, remove, widget.NewLabel(""))
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			row := obj.(*fyne.Container)
+			label := row.Objects[0].(*widget.Label)
+			remove := row.Objects[1].(*widget.Button)
+			item := a.queue.items[id]
+
+			label.SetText("📂 " + item.path + " — " + item.statusText())
+			remove.OnTapped = func() {
+				a.queue.remove(id)
+				a.queueList.UnselectAll()
+				a.queueList.Refresh()
+			}
+			if item.state == queueRunning {
+				remove.Disable()
+			} else {
+				remove.Enable()
+			}
+		})
+	a.queueList.OnSelected = a.showQueueItem
+
+	a.addToQueueBtn = widget.NewButton(i18n.T("button.addToQueue"), func() {
+		if a.selectedFolder != "" {
+			a.enqueue(a.selectedFolder)
+		}
+	})
+	a.addToQueueBtn.Disable()
+	a.runQueueBtn = widget.NewButton(i18n.T("button.runQueue"), a.runQueue)
+	a.runQueueBtn.Importance = widget.HighImportance
+	a.clearQueueBtn = widget.NewButton(i18n.T("button.clearQueue"), func() {
+		a.queue.clearFinished()
+		a.queueList.UnselectAll()
+		a.queueList.Refresh()
+	})
+}
+
+func (a *App) queueContent() fyne.CanvasObject {
+	return container.NewBorder(
+		container.NewHBox(a.runQueueBtn, a.clearQueueBtn),
+		nil, nil, nil,
+		a.queueList,
+	)
+}
+
+func (a *App) enqueue(paths ...string) {
+	if added := a.queue.add(paths...); added > 0 {
+		a.log(i18n.T("queue.added", added))
+	}
+	a.queueList.Refresh()
+	a.tabs.SelectIndex(2)
+}
+
+// onDropped selects a single dropped folder like the Select Folder dialog
+// would, and queues several. Anything dropped while a run is in progress
+// is queued.
+func (a *App) onDropped(_ fyne.Position, uris []fyne.URI) {
+	var folders []string
+	for _, uri := range uris {
+		if info, err := os.Stat(uri.Path()); err == nil && info.IsDir() {
+			folders = append(folders, uri.Path())
+		}
+	}
+	switch {
+	case len(folders) == 0:
+		return
+	case len(folders) == 1 && !a.running:
+		a.selectFolder(folders[0])
+	default:
+		a.enqueue(folders...)
+	}
+}
+
+// showQueueItem loads a finished folder's results into the Files tab so
+// they can be reviewed and saved as a report.
+func (a *App) showQueueItem(id widget.ListItemID) {
+	if id >= len(a.queue.items) || a.running {
+		return
+	}
+	item := a.queue.items[id]
+	if item.report == nil {
+		return
+	}
+	a.files.setResults(item.results)
+	a.lastReport = item.report
+	a.saveReportBtn.Enable()
+}
+
+// runQueue organizes each pending folder in turn with the current options,
+// without a preview.
+func (a *App) runQueue() {
+	if a.running || a.queue.next() == nil {
+		return
+	}
+	a.setRunning(true)
+	a.clearLog()
+	a.tabs.SelectIndex(2)
+	options := a.organizerOptions()
+
+	go a.processQueue(options)
+}
+
+// processQueue works through the pending folders. It runs off the UI
+// goroutine.
+func (a *App) processQueue(options organizer.Options) {
+	var summaries []runSummary
+	for {
+		var item *queueItem
+		fyne.DoAndWait(func() {
+			if a.cancelRequested {
+				return
+			}
+			item = a.queue.next()
+			if item != nil {
+				item.state = queueRunning
+				a.queueList.Refresh()
+			}
+		})
+		if item == nil {
+			break
+		}
+		if s := a.runQueueItem(item, options); !errors.Is(s.err, organizer.ErrCancelled) {
+			summaries = append(summaries, s)
+		}
+	}
+
+	fyne.Do(func() {
+		status := i18n.T("queue.finished")
+		if a.cancelRequested {
+			status = i18n.T("status.cancelled")
+		}
+		a.setRunning(false)
+		a.statusLabel.SetText(status)
+		if len(summaries) > 0 {
+			a.notify(summaries...)
+		}
+	})
+}
+
+func (a *App) runQueueItem(item *queueItem, options organizer.Options) runSummary {
+	a.log(i18n.T("queue.starting", item.path))
+	org := organizer.NewWithOptions(item.path, options, a.log)
+	var results []organizer.Result
+	org.SetResultCallback(func(r organizer.Result) {
+		results = append(results, r)
+		fyne.Do(func() { a.files.setResult(r) })
+	})
+
+	if run, _ := organizer.FindInterruptedRun(item.path); run != nil {
+		err := errors.New(i18n.T("resume.pending"))
+		a.log(i18n.T("log.error", err))
+		return a.failQueueItem(item, err)
+	}
+
+	started := time.Now()
+	files, err := org.GetFiles()
+	if err != nil {
+		a.log(i18n.T("log.error", err))
+		return a.failQueueItem(item, err)
+	}
+	plan := org.Plan(files)
+	cleanup, err := org.PlanCleanup()
+	if err != nil {
+		a.log(i18n.T("log.error", err))
+	}
+	issues := org.Preflight(plan)
+	for _, issue := range issues {
+		a.log(issueText(issue))
+	}
+	if organizer.HasBlocking(issues) {
+		return a.failQueueItem(item, errors.New(i18n.T("status.preflightFailed")))
+	}
+	fyne.DoAndWait(func() {
+		a.files.setPlan(plan)
+		a.current = org
+		if a.cancelRequested {
+			org.Cancel()
+		}
+	})
+
+	moved, skipped, err := org.Execute(plan)
+	if err == nil && len(cleanup) > 0 {
+		removed, cleanupErr := org.ExecuteCleanup(cleanup)
+		a.log(i18n.T("cleanup.done", removed))
+		err = cleanupErr
+	}
+	trashed := org.Trashed()
+	rep := report.FromResults(item.path, started, time.Now(), results)
+	overall, _ := rep.Summary()
+	a.log(i18n.T("log.complete", moved, skipped))
+
+	fyne.Do(func() {
+		item.state = queueDone
+		if err != nil {
+			item.state = queueFailed
+			item.err = err
+		}
+		item.moved, item.skipped, item.failed = moved, skipped, overall.Failed
+		item.results = results
+		item.report = rep
+		a.files.apply()
+		a.lastReport = rep
+		a.lastRun = &lastRun{dir: item.path, results: results, trashed: trashed}
+		a.saveReportBtn.Enable()
+		a.queueList.Refresh()
+		a.recordRun(item.path, moved, skipped, overall.Failed)
+	})
+	return summarize(item.path, results, err)
+}
+
+// failQueueItem marks a folder that couldn't be organized at all.
+func (a *App) failQueueItem(item *queueItem, err error) runSummary {
+	fyne.Do(func() {
+		item.state = queueFailed
+		item.err = err
+		a.queueList.Refresh()
+	})
+	return runSummary{folder: item.path, err: err}
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestFolderQueue(t *testing.T) {
+	var q folderQueue
+	if added := q.add("/a", "/b", "/a"); added != 2 {
+		t.Errorf("expected 2 folders added, got %d", added)
+	}
+
+	first := q.next()
+	if first == nil || first.path != "/a" {
+		t.Fatalf("expected /a first, got %+v", first)
+	}
+	first.state = queueDone
+	if q.add("/a") != 1 {
+		t.Error("expected a finished folder to be queued again")
+	}
+
+	q.items[1].state = queueRunning
+	q.remove(1)
+	if len(q.items) != 3 {
+		t.Error("expected a running folder not to be removed")
+	}
+
+	q.clearFinished()
+	if len(q.items) != 2 || q.items[0].path != "/b" || q.items[1].path != "/a" {
+		t.Errorf("unexpected queue after clearing %+v", q.items)
+	}
+}
+
+func TestDropFolders(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	ui := New(app.NewWindow("Test"))
+	dir1, dir2 := t.TempDir(), t.TempDir()
+	file := filepath.Join(dir1, "note.txt")
+	if err := os.WriteFile(file, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ui.onDropped(fyne.Position{}, []fyne.URI{storage.NewFileURI(file)})
+	if ui.selectedFolder != "" || len(ui.queue.items) != 0 {
+		t.Error("expected dropped files to be ignored")
+	}
+
+	ui.onDropped(fyne.Position{}, []fyne.URI{storage.NewFileURI(dir1)})
+	ui.background.Wait()
+	if ui.selectedFolder != dir1 {
+		t.Errorf("expected a single dropped folder to be selected, got %q", ui.selectedFolder)
+	}
+
+	ui.onDropped(fyne.Position{}, []fyne.URI{storage.NewFileURI(dir1), storage.NewFileURI(dir2)})
+	if len(ui.queue.items) != 2 {
+		t.Errorf("expected two queued folders, got %d", len(ui.queue.items))
+	}
+}
+
+func TestRunQueue(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	ui := New(app.NewWindow("Test"))
+	dirs := []string{t.TempDir(), t.TempDir()}
+	modTime := time.Date(2024, 3, 15, 12, 0, 0, 0, time.Local)
+	for _, dir := range dirs {
+		path := filepath.Join(dir, "photo.jpg")
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ui.enqueue(dirs...)
+	ui.setRunning(true)
+	ui.processQueue(ui.organizerOptions())
+	if ui.running {
+		t.Error("expected the queue to finish")
+	}
+
+	for _, item := range ui.queue.items {
+		if item.state != queueDone || item.moved != 1 || item.report == nil {
+			t.Errorf("unexpected result for %s: %+v", item.path, item)
+		}
+		if _, err := os.Stat(filepath.Join(item.path, "2024", "03-March", "photo.jpg")); err != nil {
+			t.Errorf("expected photo.jpg to be moved: %v", err)
+		}
+	}
+}
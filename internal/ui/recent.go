@@ -0,0 +1,247 @@
+package ui
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dale-tomson/declutter/internal/i18n"
+)
+
+const (
+	prefFolderHistory = "folderHistory"
+	prefLastLocation  = "lastLocation"
+)
+
+// maxRecentFolders caps the unpinned entries; pinned favourites are kept
+// regardless.
+const maxRecentFolders = 10
+
+// folderEntry is a recent or pinned folder. Notify overrides the
+// notification setting for runs of the folder; empty follows Settings.
+type folderEntry struct {
+	Path    string     `json:"path"`
+	Pinned  bool       `json:"pinned,omitempty"`
+	LastRun time.Time  `json:"lastRun,omitempty"`
+	Moved   int        `json:"moved,omitempty"`
+	Skipped int        `json:"skipped,omitempty"`
+	Failed  int        `json:"failed,omitempty"`
+	Notify  notifyMode `json:"notify,omitempty"`
+}
+
+// folderHistory holds recently used and pinned folders, most recent first.
+type folderHistory struct {
+	entries []folderEntry
+}
+
+func loadFolderHistory(p fyne.Preferences) folderHistory {
+	var h folderHistory
+	if data := p.String(prefFolderHistory); data != "" {
+		json.Unmarshal([]byte(data), &h.entries)
+	}
+	return h
+}
+
+func (h *folderHistory) save(p fyne.Preferences) {
+	data, err := json.Marshal(h.entries)
+	if err != nil {
+		return
+	}
+	p.SetString(prefFolderHistory, string(data))
+}
+
+func (h *folderHistory) find(path string) int {
+	for i, e := range h.entries {
+		if e.Path == path {
+			return i
+		}
+	}
+	return -1
+}
+
+// touch moves path to the front, adding it if needed, and drops the
+// oldest unpinned folders beyond maxRecentFolders.
+func (h *folderHistory) touch(path string) {
+	entry := folderEntry{Path: path}
+	if i := h.find(path); i >= 0 {
+		entry = h.entries[i]
+		h.entries = append(h.entries[:i], h.entries[i+1:]...)
+	}
+	h.entries = append([]folderEntry{entry}, h.entries...)
+
+	unpinned := 0
+	kept := h.entries[:0]
+	for _, e := range h.entries {
+		if !e.Pinned {
+			unpinned++
+			if unpinned > maxRecentFolders {
+				continue
+			}
+		}
+		kept = append(kept, e)
+	}
+	h.entries = kept
+}
+
+func (h *folderHistory) recordRun(path string, at time.Time, moved, skipped, failed int) {
+	h.touch(path)
+	e := &h.entries[0]
+	e.LastRun, e.Moved, e.Skipped, e.Failed = at, moved, skipped, failed
+}
+
+func (h *folderHistory) togglePin(path string) {
+	if i := h.find(path); i >= 0 {
+		h.entries[i].Pinned = !h.entries[i].Pinned
+	}
+}
+
+func (h *folderHistory) setNotify(path string, mode notifyMode) {
+	if i := h.find(path); i >= 0 {
+		h.entries[i].Notify = mode
+	}
+}
+
+// notifyMode returns the notification mode chosen for path, or "" when it
+// follows Settings.
+func (h *folderHistory) notifyMode(path string) notifyMode {
+	if i := h.find(path); i >= 0 {
+		return h.entries[i].Notify
+	}
+	return ""
+}
+
+func (h *folderHistory) remove(path string) {
+	if i := h.find(path); i >= 0 {
+		h.entries = append(h.entries[:i], h.entries[i+1:]...)
+	}
+}
+
+// list returns the pinned favourites followed by the recent folders.
+func (h *folderHistory) list() []folderEntry {
+	list := make([]folderEntry, 0, len(h.entries))
+	for _, e := range h.entries {
+		if e.Pinned {
+			list = append(list, e)
+		}
+	}
+	for _, e := range h.entries {
+		if !e.Pinned {
+			list = append(list, e)
+		}
+	}
+	return list
+}
+
+func (e folderEntry) description() string {
+	if e.LastRun.IsZero() {
+		return i18n.T("recent.never")
+	}
+	return i18n.T("recent.lastRun", e.LastRun.Format("2006-01-02 15:04"), e.Moved, e.Skipped, e.Failed)
+}
+
+func (a *App) setupRecent() {
+	a.history = loadFolderHistory(a.prefs)
+	a.recentList = widget.NewList(
+		func() int { return len(a.history.entries) },
+		func() fyne.CanvasObject {
+			pin := widget.NewButton("", nil)
+			remove := widget.NewButtonWithIcon("", theme.DeleteIcon(), nil)
+			remove.Importance = widget.LowImportance
+			notify := widget.NewSelect(nil, nil)
+			name := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+			name.Truncation = fyne.TextTruncateEllipsis
+			return container.NewBorder(nil, nil, nil, container.NewHBox(notify, pin, remove),
+				container.NewVBox(name, widget.NewLabel("")))
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			list := a.history.list()
+			if id >= len(list) {
+				return
+			}
+			entry := list[id]
+			row := obj.(*fyne.Container)
+			labels := row.Objects[0].(*fyne.Container)
+			buttons := row.Objects[1].(*fyne.Container)
+
+			name := "📂 " + entry.Path
+			if entry.Pinned {
+				name = "📌 " + entry.Path
+			}
+			labels.Objects[0].(*widget.Label).SetText(name)
+			labels.Objects[1].(*widget.Label).SetText(entry.description())
+
+			notify := buttons.Objects[0].(*widget.Select)
+			modes := append([]notifyMode{""}, notifyModes...)
+			options := make([]string, len(modes))
+			for i, mode := range modes {
+				options[i] = folderNotifyLabel(mode)
+			}
+			// The row is reused, so the old handler must not see the
+			// selection change.
+			notify.OnChanged = nil
+			notify.Options = options
+			notify.SetSelected(folderNotifyLabel(entry.Notify))
+			notify.OnChanged = func(string) {
+				a.history.setNotify(entry.Path, modes[notify.SelectedIndex()])
+				a.saveHistory()
+			}
+
+			pin := buttons.Objects[1].(*widget.Button)
+			if entry.Pinned {
+				pin.SetText(i18n.T("recent.unpin"))
+			} else {
+				pin.SetText(i18n.T("recent.pin"))
+			}
+			pin.OnTapped = func() {
+				a.history.togglePin(entry.Path)
+				a.saveHistory()
+			}
+			buttons.Objects[2].(*widget.Button).OnTapped = func() {
+				a.history.remove(entry.Path)
+				a.saveHistory()
+			}
+		})
+	a.recentList.OnSelected = func(id widget.ListItemID) {
+		a.recentList.UnselectAll()
+		list := a.history.list()
+		if id < len(list) && !a.running {
+			a.selectFolder(list[id].Path)
+		}
+	}
+}
+
+func (a *App) saveHistory() {
+	a.history.save(a.prefs)
+	a.recentList.Refresh()
+}
+
+// recordRun notes a finished run of path for the recent folders list.
+func (a *App) recordRun(path string, moved, skipped, failed int) {
+	a.history.recordRun(path, time.Now(), moved, skipped, failed)
+	a.saveHistory()
+}
+
+// folderDialog opens the folder picker where the last selected folder
+// lives.
+func (a *App) folderDialog(callback func(fyne.ListableURI, error)) *dialog.FileDialog {
+	d := dialog.NewFolderOpen(callback, a.window)
+	if location := a.prefs.String(prefLastLocation); location != "" {
+		if lister, err := storage.ListerForURI(storage.NewFileURI(location)); err == nil {
+			d.SetLocation(lister)
+		}
+	}
+	return d
+}
+
+func (a *App) rememberFolder(path string) {
+	a.prefs.SetString(prefLastLocation, filepath.Dir(path))
+	a.history.touch(path)
+	a.saveHistory()
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+)
+
+func TestFolderHistoryOrder(t *testing.T) {
+	var h folderHistory
+	h.touch("/a")
+	h.touch("/b")
+	h.touch("/c")
+	h.togglePin("/a")
+	h.touch("/b")
+
+	var paths []string
+	for _, e := range h.list() {
+		paths = append(paths, e.Path)
+	}
+	if fmt.Sprint(paths) != "[/a /b /c]" {
+		t.Errorf("expected pinned first then most recent, got %v", paths)
+	}
+}
+
+func TestFolderHistoryCap(t *testing.T) {
+	var h folderHistory
+	h.touch("/pinned")
+	h.togglePin("/pinned")
+	for i := 0; i < maxRecentFolders+5; i++ {
+		h.touch(fmt.Sprintf("/folder%d", i))
+	}
+	if len(h.entries) != maxRecentFolders+1 {
+		t.Errorf("expected %d entries, got %d", maxRecentFolders+1, len(h.entries))
+	}
+	if h.find("/pinned") < 0 {
+		t.Error("expected the pinned folder to be kept")
+	}
+	if h.find("/folder0") >= 0 {
+		t.Error("expected the oldest folder to be dropped")
+	}
+}
+
+func TestFolderHistoryPersists(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	var h folderHistory
+	at := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	h.recordRun("/photos", at, 12, 1, 2)
+	h.togglePin("/photos")
+	h.save(app.Preferences())
+
+	loaded := loadFolderHistory(app.Preferences())
+	if len(loaded.entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(loaded.entries))
+	}
+	e := loaded.entries[0]
+	if !e.Pinned || !e.LastRun.Equal(at) || e.Moved != 12 || e.Skipped != 1 || e.Failed != 2 {
+		t.Errorf("unexpected entry %+v", e)
+	}
+	if got := e.description(); got != "Last run 2024-03-01 09:30: 12 moved, 1 skipped, 2 failed" {
+		t.Errorf("unexpected description %q", got)
+	}
+}
+
+func TestSelectFolderIsRemembered(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	dir := t.TempDir()
+	first := New(app.NewWindow("Test"))
+	first.selectFolder(dir)
+	first.background.Wait()
+
+	ui := New(app.NewWindow("Test"))
+	if len(ui.history.entries) != 1 || ui.history.entries[0].Path != dir {
+		t.Errorf("expected %s in the recent folders, got %+v", dir, ui.history.entries)
+	}
+	if ui.prefs.String(prefLastLocation) == "" {
+		t.Error("expected the dialog location to be remembered")
+	}
+}
@@ -0,0 +1,267 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dale-tomson/declutter/internal/i18n"
+	"github.com/dale-tomson/declutter/internal/organizer"
+)
+
+const (
+	columnName = iota
+	columnDate
+	columnSource
+	columnDestination
+	columnStatus
+	columnError
+	columnCount
+)
+
+var columnTitles = [columnCount]string{
+	"table.name", "table.date", "table.source", "table.destination", "table.status", "table.error",
+}
+
+var columnWidths = [columnCount]float32{200, 130, 130, 200, 100, 200}
+
+// tableRefreshInterval is the least time between redraws of the table
+// while a run reports its files.
+const tableRefreshInterval = 100 * time.Millisecond
+
+// fileRow is one file in the results table. status is empty until Execute
+// reports on the file.
+type fileRow struct {
+	path   string
+	name   string
+	date   time.Time
+	source organizer.DateSource
+	dest   string
+	status organizer.Status
+	err    string
+}
+
+func (r fileRow) cell(column int) string {
+	switch column {
+	case columnName:
+		return r.name
+	case columnDate:
+		return r.date.Format("2006-01-02 15:04")
+	case columnSource:
+		return i18n.T("source." + string(r.source))
+	case columnDestination:
+		return r.dest
+	case columnStatus:
+		if r.status == "" {
+			return i18n.T("result.pending")
+		}
+		return i18n.T("result." + string(r.status))
+	case columnError:
+		return r.err
+	}
+	return ""
+}
+
+// fileTable lists every file of a run with its outcome. Rows are kept in
+// plan order; visible holds the indices left after searching and
+// filtering, in display order.
+type fileTable struct {
+	rows    []fileRow
+	byPath  map[string]int
+	visible []int
+
+	// sortColumn is -1 for plan order.
+	sortColumn int
+	descending bool
+	query      string
+	errorsOnly bool
+	// refreshed is when setResult last redrew the table.
+	refreshed time.Time
+
+	table       *widget.Table
+	search      *widget.Entry
+	errorsCheck *widget.Check
+}
+
+func newFileTable() *fileTable {
+	t := &fileTable{byPath: make(map[string]int), sortColumn: -1}
+
+	t.table = widget.NewTableWithHeaders(
+		func() (int, int) { return len(t.visible), columnCount },
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return label
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			if id.Row >= len(t.visible) {
+				label.SetText("")
+				return
+			}
+			row := t.rows[t.visible[id.Row]]
+			label.Importance = widget.MediumImportance
+			if row.status == organizer.StatusFailed {
+				label.Importance = widget.DangerImportance
+			}
+			label.Text = row.cell(id.Col)
+			label.Refresh()
+		})
+	t.table.ShowHeaderColumn = false
+	t.table.CreateHeader = func() fyne.CanvasObject {
+		return widget.NewButton("", nil)
+	}
+	t.table.UpdateHeader = func(id widget.TableCellID, obj fyne.CanvasObject) {
+		button := obj.(*widget.Button)
+		if id.Col < 0 {
+			return
+		}
+		button.SetText(t.header(id.Col))
+		button.OnTapped = func() { t.sortBy(id.Col) }
+	}
+	for column, width := range columnWidths {
+		t.table.SetColumnWidth(column, width)
+	}
+
+	t.search = widget.NewEntry()
+	t.search.SetPlaceHolder(i18n.T("table.search"))
+	t.search.OnChanged = t.setQuery
+
+	t.errorsCheck = widget.NewCheck(i18n.T("table.errorsOnly"), t.setErrorsOnly)
+
+	return t
+}
+
+func (t *fileTable) content() fyne.CanvasObject {
+	return container.NewBorder(
+		container.NewBorder(nil, nil, nil, t.errorsCheck, t.search),
+		nil, nil, nil,
+		t.table,
+	)
+}
+
+func (t *fileTable) refreshTexts() {
+	t.search.SetPlaceHolder(i18n.T("table.search"))
+	t.errorsCheck.Text = i18n.T("table.errorsOnly")
+	t.errorsCheck.Refresh()
+	t.table.Refresh()
+}
+
+func (t *fileTable) header(column int) string {
+	title := i18n.T(columnTitles[column])
+	if column != t.sortColumn {
+		return title
+	}
+	if t.descending {
+		return title + " ▼"
+	}
+	return title + " ▲"
+}
+
+// setPlan replaces the rows with the files of a new run.
+func (t *fileTable) setPlan(plan []organizer.PlannedMove) {
+	t.rows = make([]fileRow, len(plan))
+	t.byPath = make(map[string]int, len(plan))
+	for i, move := range plan {
+		t.rows[i] = fileRow{
+			path:   move.File.Path,
+			name:   move.File.Name,
+			date:   move.File.EffectiveDate(),
+			source: move.File.DateSource,
+			dest:   move.Folder + "/" + move.Name,
+		}
+		t.byPath[move.File.Path] = i
+	}
+	t.apply()
+}
+
+// setResults shows the outcome of a finished run.
+func (t *fileTable) setResults(results []organizer.Result) {
+	plan := make([]organizer.PlannedMove, len(results))
+	for i, r := range results {
+		plan[i] = r.Move
+	}
+	t.setPlan(plan)
+	for _, r := range results {
+		t.setResult(r)
+	}
+	t.apply()
+}
+
+// setResult records the outcome of one file. The order and filter are not
+// re-applied, so rows don't jump around during a run, and the table is
+// redrawn at most every tableRefreshInterval; call apply once the run is
+// over.
+func (t *fileTable) setResult(r organizer.Result) {
+	i, ok := t.byPath[r.Move.File.Path]
+	if !ok {
+		return
+	}
+	t.rows[i].status = r.Status
+	switch {
+	case r.Err != nil:
+		t.rows[i].err = r.Err.Error()
+	case r.Status == organizer.StatusSkipped:
+		t.rows[i].err = i18n.T("result.exists")
+	}
+	if now := time.Now(); now.Sub(t.refreshed) >= tableRefreshInterval {
+		t.refreshed = now
+		t.table.Refresh()
+	}
+}
+
+// sortBy sorts on a column, toggling the direction when it is already the
+// sort column.
+func (t *fileTable) sortBy(column int) {
+	if column == t.sortColumn {
+		t.descending = !t.descending
+	} else {
+		t.sortColumn = column
+		t.descending = false
+	}
+	t.apply()
+}
+
+func (t *fileTable) setQuery(query string) {
+	t.query = strings.ToLower(strings.TrimSpace(query))
+	t.apply()
+}
+
+func (t *fileTable) setErrorsOnly(errorsOnly bool) {
+	t.errorsOnly = errorsOnly
+	t.apply()
+}
+
+// apply rebuilds the visible rows from the search, filter and sort order.
+func (t *fileTable) apply() {
+	t.visible = t.visible[:0]
+	for i, row := range t.rows {
+		if t.errorsOnly && row.status != organizer.StatusFailed {
+			continue
+		}
+		if t.query != "" &&
+			!strings.Contains(strings.ToLower(row.name), t.query) &&
+			!strings.Contains(strings.ToLower(row.dest), t.query) {
+			continue
+		}
+		t.visible = append(t.visible, i)
+	}
+
+	if t.sortColumn >= 0 {
+		sort.SliceStable(t.visible, func(i, j int) bool {
+			a, b := t.rows[t.visible[i]], t.rows[t.visible[j]]
+			if t.descending {
+				a, b = b, a
+			}
+			if t.sortColumn == columnDate {
+				return a.date.Before(b.date)
+			}
+			return strings.ToLower(a.cell(t.sortColumn)) < strings.ToLower(b.cell(t.sortColumn))
+		})
+	}
+	t.table.Refresh()
+}
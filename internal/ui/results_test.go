@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+
+	"github.com/dale-tomson/declutter/internal/organizer"
+)
+
+func resultsPlan() []organizer.PlannedMove {
+	move := func(name string, day int) organizer.PlannedMove {
+		return organizer.PlannedMove{
+			File: organizer.FileInfo{
+				Path:       "/src/" + name,
+				Name:       name,
+				ModTime:    time.Date(2024, 3, day, 12, 0, 0, 0, time.UTC),
+				DateSource: organizer.DateSourceModTime,
+			},
+			Folder: "2024/03-March",
+			Name:   name,
+		}
+	}
+	return []organizer.PlannedMove{move("beach.jpg", 2), move("Alpha.txt", 9), move("notes.md", 5)}
+}
+
+func visibleNames(t *fileTable) []string {
+	names := make([]string, len(t.visible))
+	for i, row := range t.visible {
+		names[i] = t.rows[row].name
+	}
+	return names
+}
+
+func TestFileTableSortAndSearch(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	table := newFileTable()
+	table.setPlan(resultsPlan())
+	if got := fmt.Sprint(visibleNames(table)); got != "[beach.jpg Alpha.txt notes.md]" {
+		t.Errorf("expected plan order, got %s", got)
+	}
+
+	table.sortBy(columnName)
+	if got := fmt.Sprint(visibleNames(table)); got != "[Alpha.txt beach.jpg notes.md]" {
+		t.Errorf("expected name order, got %s", got)
+	}
+	table.sortBy(columnDate)
+	table.sortBy(columnDate)
+	if got := fmt.Sprint(visibleNames(table)); got != "[Alpha.txt notes.md beach.jpg]" {
+		t.Errorf("expected newest first, got %s", got)
+	}
+	if table.header(columnDate) != "Date ▼" {
+		t.Errorf("unexpected header %q", table.header(columnDate))
+	}
+
+	table.setQuery(" NOTES ")
+	if got := fmt.Sprint(visibleNames(table)); got != "[notes.md]" {
+		t.Errorf("expected search to match notes.md, got %s", got)
+	}
+}
+
+func TestFileTableErrorsOnly(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	plan := resultsPlan()
+	table := newFileTable()
+	table.setPlan(plan)
+	table.setResult(organizer.Result{Move: plan[0], Status: organizer.StatusMoved})
+	table.setResult(organizer.Result{Move: plan[1], Status: organizer.StatusFailed, Err: errors.New("permission denied")})
+	table.setResult(organizer.Result{Move: plan[2], Status: organizer.StatusSkipped})
+
+	table.setErrorsOnly(true)
+	if got := fmt.Sprint(visibleNames(table)); got != "[Alpha.txt]" {
+		t.Errorf("expected only the failed file, got %s", got)
+	}
+	row := table.rows[table.visible[0]]
+	if row.cell(columnStatus) != "Failed" || row.cell(columnError) != "permission denied" {
+		t.Errorf("unexpected row %q / %q", row.cell(columnStatus), row.cell(columnError))
+	}
+	if got := table.rows[2].cell(columnError); got != "Already exists" {
+		t.Errorf("unexpected error for skipped file %q", got)
+	}
+}
+
+func TestFileTableThrottlesRefresh(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	plan := resultsPlan()
+	table := newFileTable()
+	table.setPlan(plan)
+	table.setResult(organizer.Result{Move: plan[0], Status: organizer.StatusMoved})
+	refreshed := table.refreshed
+	if refreshed.IsZero() {
+		t.Fatal("expected the first result to redraw the table")
+	}
+	table.setResult(organizer.Result{Move: plan[1], Status: organizer.StatusMoved})
+	if !table.refreshed.Equal(refreshed) {
+		t.Error("expected a result right after another not to redraw the table")
+	}
+	if table.rows[1].status != organizer.StatusMoved {
+		t.Errorf("expected the result recorded, got %q", table.rows[1].status)
+	}
+
+	table.refreshed = refreshed.Add(-tableRefreshInterval)
+	table.setResult(organizer.Result{Move: plan[2], Status: organizer.StatusMoved})
+	if !table.refreshed.After(refreshed) {
+		t.Error("expected a result after the interval to redraw the table")
+	}
+}
+
+func TestLogIsBounded(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	ui := New(app.NewWindow("Test"))
+	for i := 0; i < maxLogLines+10; i++ {
+		ui.log(fmt.Sprintf("line %d", i))
+	}
+	if len(ui.logLines) != maxLogLines {
+		t.Errorf("expected %d log lines, got %d", maxLogLines, len(ui.logLines))
+	}
+	if ui.logLines[0] != "line 10" {
+		t.Errorf("expected oldest lines to be dropped, got %q", ui.logLines[0])
+	}
+}
+
+func TestSaveReportNeedsARun(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	ui := New(app.NewWindow("Test"))
+	if !ui.saveReportBtn.Disabled() {
+		t.Error("expected Save report to be disabled before a run")
+	}
+	ui.onSaveReport()
+}
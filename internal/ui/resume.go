@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dale-tomson/declutter/internal/i18n"
+	"github.com/dale-tomson/declutter/internal/organizer"
+)
+
+// findInterruptedRun selects the first recent folder with a run that was
+// interrupted, such as by a crash, so that it can be resumed on launch.
+func (a *App) findInterruptedRun() {
+	for _, e := range a.history.entries {
+		if run, _ := organizer.FindInterruptedRun(e.Path); run != nil {
+			a.selectFolder(e.Path)
+			return
+		}
+	}
+}
+
+// offerResume asks whether to resume or roll back a run in dir that was
+// interrupted.
+func (a *App) offerResume(dir string) {
+	run, err := organizer.FindInterruptedRun(dir)
+	if err != nil {
+		a.log(i18n.T("log.error", err))
+		return
+	}
+	if run == nil || a.running {
+		return
+	}
+
+	var d dialog.Dialog
+	resume := widget.NewButton(i18n.T("resume.resume"), func() {
+		d.Hide()
+		a.resumeRun(dir, run)
+	})
+	resume.Importance = widget.HighImportance
+	rollback := widget.NewButton(i18n.T("resume.rollback"), func() {
+		d.Hide()
+		a.setRunning(true)
+		a.tabs.SelectIndex(1)
+		a.statusLabel.SetText(i18n.T("status.rollingBack"))
+		go a.rollbackRun(dir, run)
+	})
+	message := widget.NewLabel(i18n.T("resume.message",
+		run.Started.Local().Format(time.DateTime), run.Moved(), len(run.Plan)))
+	message.Wrapping = fyne.TextWrapWord
+	content := container.NewVBox(message, container.NewHBox(layout.NewSpacer(), rollback, resume))
+	d = dialog.NewCustom(i18n.T("resume.title"), i18n.T("resume.later"), content, a.window)
+	d.Resize(fyne.NewSize(480, 0))
+	d.Show()
+}
+
+// resumeRun finishes an interrupted run like a new one, with progress in
+// the Files tab.
+func (a *App) resumeRun(dir string, run *organizer.InterruptedRun) {
+	org := organizer.NewWithOptions(dir, a.organizerOptions(), a.log)
+	a.clearLog()
+	a.runPlan(org, run.Plan, nil, func() (int, int, error) { return org.Resume(run) })
+}
+
+// rollbackRun moves the files of an interrupted run back. It runs off the
+// UI goroutine.
+func (a *App) rollbackRun(dir string, run *organizer.InterruptedRun) {
+	org := organizer.NewWithOptions(dir, organizer.Options{Trash: a.trash}, a.log)
+	restored, failed := org.Rollback(run)
+	a.log(i18n.T("undo.done", restored, failed))
+	fyne.Do(func() {
+		a.setRunning(false)
+		a.statusLabel.SetText(i18n.T("undo.done", restored, failed))
+	})
+}
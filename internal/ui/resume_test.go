@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+
+	"github.com/dale-tomson/declutter/internal/organizer"
+)
+
+func writeInterruptedRun(t *testing.T) (dir, source string) {
+	t.Helper()
+	// A run that moved a.jpg and stopped before recording it.
+	dir = t.TempDir()
+	source = filepath.Join(dir, "a.jpg")
+	dest := filepath.Join(dir, "2024", "03-March", "a.jpg")
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dest, []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	journal, _ := json.Marshal(map[string]any{
+		"started": time.Now(),
+		"plan":    []map[string]any{{"File": map[string]any{"Path": source, "Name": "a.jpg"}, "Folder": "2024/03-March", "Name": "a.jpg", "Dest": dest}},
+	})
+	if err := os.WriteFile(filepath.Join(dir, ".declutter-run.json"), journal, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir, source
+}
+
+func TestResumeOffered(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	w := app.NewWindow("Test")
+	ui := New(w)
+	w.SetContent(ui.GetContent())
+
+	dir, source := writeInterruptedRun(t)
+
+	ui.selectFolder(dir)
+	ui.background.Wait()
+	if w.Canvas().Overlays().Top() == nil {
+		t.Fatal("expected a dialog offering to resume")
+	}
+
+	run, err := organizer.FindInterruptedRun(dir)
+	if err != nil || run == nil {
+		t.Fatalf("expected an interrupted run, got %v", err)
+	}
+	ui.setRunning(true)
+	ui.rollbackRun(dir, run)
+	if ui.running || ui.statusLabel.Text != "Restored 1 files, 0 could not be restored" {
+		t.Errorf("unexpected status %q", ui.statusLabel.Text)
+	}
+	if _, err := os.Stat(source); err != nil {
+		t.Errorf("expected a.jpg back: %v", err)
+	}
+}
+
+func TestOrganizeWithInterruptedRun(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	w := app.NewWindow("Test")
+	ui := New(w)
+	w.SetContent(ui.GetContent())
+	dir, _ := writeInterruptedRun(t)
+	if err := os.WriteFile(filepath.Join(dir, "b.jpg"), []byte("b"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ui.selectedFolder = dir
+	ui.onOrganize()
+	if ui.running || w.Canvas().Overlays().Top() == nil {
+		t.Error("expected the resume dialog instead of a new run")
+	}
+
+	ui.enqueue(dir)
+	ui.setRunning(true)
+	ui.processQueue(ui.organizerOptions())
+	if item := ui.queue.items[0]; item.state != queueFailed {
+		t.Errorf("expected the queued folder to fail, got %+v", item)
+	}
+	if run, _ := organizer.FindInterruptedRun(dir); run == nil {
+		t.Error("expected the interrupted run to be kept")
+	}
+}
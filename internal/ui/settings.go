@@ -0,0 +1,344 @@
+package ui
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/dale-tomson/declutter/internal/i18n"
+	"github.com/dale-tomson/declutter/internal/metadata"
+	"github.com/dale-tomson/declutter/internal/organizer"
+	apptheme "github.com/dale-tomson/declutter/internal/theme"
+)
+
+const (
+	prefLanguage   = "language"
+	prefMonthStyle = "monthStyle"
+	prefTimeZone   = "timeZone"
+	prefThemeMode  = "themeMode"
+	prefLargeText  = "largeText"
+	prefThemeFile  = "themeFile"
+	prefNotify     = "notifications"
+	prefJunk       = "junkPatterns"
+	prefRename     = "renameTemplate"
+	prefMediaLocal = "mediaTimesLocal"
+	prefPatterns   = "filenamePatterns"
+	prefDateRules  = "dateRules"
+)
+
+var monthStyles = []organizer.MonthStyle{
+	organizer.MonthStyleEnglish,
+	organizer.MonthStyleLocalized,
+	organizer.MonthStyleAbbreviated,
+	organizer.MonthStyleNumeric,
+}
+
+var themeModes = []apptheme.Mode{apptheme.ModeSystem, apptheme.ModeLight, apptheme.ModeDark, apptheme.ModeHighContrast}
+
+type settings struct {
+	language   i18n.Language
+	monthStyle organizer.MonthStyle
+	timeZone   string
+	themeMode  apptheme.Mode
+	largeText  bool
+	themeFile  string
+	notify     notifyMode
+	// junk is the comma-separated list of junk patterns for the cleanup
+	// pass; empty means the defaults.
+	junk string
+	// rename is the template files are renamed by as they are moved; empty
+	// keeps their names.
+	rename string
+	// mediaLocal reads the UTC times in MP4 and Matroska files as local
+	// time.
+	mediaLocal bool
+	// patterns holds extra regular expressions for dates in file names,
+	// one per line.
+	patterns string
+	// dateRules chooses the date sources for some file types, one rule per
+	// line; see organizer.ParseDateRules.
+	dateRules string
+}
+
+func loadSettings(p fyne.Preferences) settings {
+	s := settings{
+		language:   i18n.Language(p.String(prefLanguage)),
+		monthStyle: organizer.MonthStyle(p.StringWithFallback(prefMonthStyle, string(organizer.MonthStyleEnglish))),
+		timeZone:   p.String(prefTimeZone),
+		themeMode:  apptheme.Mode(p.StringWithFallback(prefThemeMode, string(apptheme.ModeSystem))),
+		largeText:  p.Bool(prefLargeText),
+		themeFile:  p.String(prefThemeFile),
+		notify:     notifyMode(p.StringWithFallback(prefNotify, string(notifyUnfocused))),
+		junk:       p.String(prefJunk),
+		rename:     p.String(prefRename),
+		mediaLocal: p.Bool(prefMediaLocal),
+		patterns:   p.String(prefPatterns),
+		dateRules:  p.String(prefDateRules),
+	}
+	if s.language == "" {
+		s.language = i18n.Match(lang.SystemLocale().LanguageString())
+	}
+	return s
+}
+
+func (s settings) save(p fyne.Preferences) {
+	p.SetString(prefLanguage, string(s.language))
+	p.SetString(prefMonthStyle, string(s.monthStyle))
+	p.SetString(prefTimeZone, s.timeZone)
+	p.SetString(prefThemeMode, string(s.themeMode))
+	p.SetBool(prefLargeText, s.largeText)
+	p.SetString(prefThemeFile, s.themeFile)
+	p.SetString(prefNotify, string(s.notify))
+	p.SetString(prefJunk, s.junk)
+	p.SetString(prefRename, s.rename)
+	p.SetBool(prefMediaLocal, s.mediaLocal)
+	p.SetString(prefPatterns, s.patterns)
+	p.SetString(prefDateRules, s.dateRules)
+}
+
+func (s settings) monthFormat() organizer.MonthFormat {
+	return organizer.MonthFormat{Style: s.monthStyle, Language: s.language}
+}
+
+// timeZonePolicy falls back to local time for a value that no longer
+// parses, e.g. a zone name removed from the tz database.
+func (s settings) timeZonePolicy() organizer.TimeZonePolicy {
+	policy, err := organizer.ParseTimeZonePolicy(s.timeZone)
+	if err != nil {
+		return organizer.TimeZonePolicy{Mode: organizer.TimeZoneLocal}
+	}
+	return policy
+}
+
+// junkPatterns falls back to the defaults for a list that no longer parses.
+func (s settings) junkPatterns() []string {
+	patterns, err := organizer.ParseJunkPatterns(s.junk)
+	if err != nil {
+		return nil
+	}
+	return patterns
+}
+
+// renameTemplate keeps names as they are for a template that no longer
+// parses.
+func (s settings) renameTemplate() organizer.RenameTemplate {
+	template, err := organizer.ParseRenameTemplate(s.rename)
+	if err != nil {
+		return ""
+	}
+	return template
+}
+
+// lines splits a list entered one item per line.
+func lines(s string) []string {
+	var items []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			items = append(items, line)
+		}
+	}
+	return items
+}
+
+// filenameMatcher falls back to the built-in patterns for a list that no
+// longer compiles.
+func (s settings) filenameMatcher() *metadata.FilenameMatcher {
+	matcher, err := metadata.NewFilenameMatcher(lines(s.patterns)...)
+	if err != nil {
+		return nil
+	}
+	return matcher
+}
+
+// dateSourcesByExt ignores rules that no longer parse.
+func (s settings) dateSourcesByExt() map[string][]organizer.DateSource {
+	byExt, err := organizer.ParseDateRules(lines(s.dateRules))
+	if err != nil {
+		return nil
+	}
+	return byExt
+}
+
+func themeModeLabel(mode apptheme.Mode) string {
+	switch mode {
+	case apptheme.ModeLight:
+		return i18n.T("theme.light")
+	case apptheme.ModeDark:
+		return i18n.T("theme.dark")
+	case apptheme.ModeHighContrast:
+		return i18n.T("theme.highContrast")
+	}
+	return i18n.T("theme.system")
+}
+
+func monthStyleLabel(style organizer.MonthStyle, l i18n.Language) string {
+	example := organizer.MonthFormat{Style: style, Language: l}.FolderName(3)
+	switch style {
+	case organizer.MonthStyleLocalized:
+		return i18n.T("month.localized", example)
+	case organizer.MonthStyleAbbreviated:
+		return i18n.T("month.abbreviated", example)
+	case organizer.MonthStyleNumeric:
+		return i18n.T("month.numeric")
+	}
+	return i18n.T("month.english")
+}
+
+func (a *App) onSettings() {
+	languages := i18n.Languages()
+	languageNames := make([]string, len(languages))
+	for i, l := range languages {
+		languageNames[i] = l.Name()
+	}
+	languageSelect := widget.NewSelect(languageNames, nil)
+	languageSelect.SetSelected(a.settings.language.Name())
+
+	styleLabels := make([]string, len(monthStyles))
+	for i, style := range monthStyles {
+		styleLabels[i] = monthStyleLabel(style, a.settings.language)
+	}
+	styleSelect := widget.NewSelect(styleLabels, nil)
+	styleSelect.SetSelected(monthStyleLabel(a.settings.monthStyle, a.settings.language))
+
+	themeLabels := make([]string, len(themeModes))
+	for i, mode := range themeModes {
+		themeLabels[i] = themeModeLabel(mode)
+	}
+	themeSelect := widget.NewSelect(themeLabels, nil)
+	themeSelect.SetSelected(themeModeLabel(a.settings.themeMode))
+	largeTextCheck := widget.NewCheck(i18n.T("settings.largeText"), nil)
+	largeTextCheck.SetChecked(a.settings.largeText)
+	themeFileEntry := widget.NewEntry()
+	themeFileEntry.SetText(a.settings.themeFile)
+
+	notifyLabels := make([]string, len(notifyModes))
+	for i, mode := range notifyModes {
+		notifyLabels[i] = notifyModeLabel(mode)
+	}
+	notifySelect := widget.NewSelect(notifyLabels, nil)
+	notifySelect.SetSelected(notifyModeLabel(a.settings.notify))
+
+	junkEntry := widget.NewEntry()
+	junkEntry.SetPlaceHolder(strings.Join(organizer.DefaultJunkPatterns, ", "))
+	junkEntry.SetText(a.settings.junk)
+
+	renameEntry := widget.NewEntry()
+	renameEntry.SetPlaceHolder(string(organizer.DefaultRenameTemplate))
+	renameEntry.SetText(a.settings.rename)
+
+	mediaLocalCheck := widget.NewCheck(i18n.T("settings.mediaLocal"), nil)
+	mediaLocalCheck.SetChecked(a.settings.mediaLocal)
+
+	patternsEntry := widget.NewMultiLineEntry()
+	patternsEntry.SetPlaceHolder(`(?P<day>\d{2})\.(?P<month>\d{2})\.(?P<year>\d{4})`)
+	patternsEntry.SetText(a.settings.patterns)
+
+	dateRulesEntry := widget.NewMultiLineEntry()
+	dateRulesEntry.SetPlaceHolder(".pdf,.docx=document,filename")
+	dateRulesEntry.SetText(a.settings.dateRules)
+
+	timeZoneEntry := widget.NewEntry()
+	timeZoneEntry.SetPlaceHolder(string(organizer.TimeZoneLocal))
+	timeZoneEntry.SetText(a.settings.timeZone)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem(i18n.T("settings.language"), languageSelect),
+		widget.NewFormItem(i18n.T("settings.theme"), themeSelect),
+		widget.NewFormItem("", largeTextCheck),
+		{Text: i18n.T("settings.themeFile"), Widget: themeFileEntry, HintText: i18n.T("settings.themeFile.hint")},
+		widget.NewFormItem(i18n.T("settings.monthNames"), styleSelect),
+		{Text: i18n.T("settings.timeZone"), Widget: timeZoneEntry, HintText: i18n.T("settings.timeZone.hint")},
+		{Text: "", Widget: mediaLocalCheck, HintText: i18n.T("settings.mediaLocal.hint")},
+		{Text: i18n.T("settings.namePatterns"), Widget: patternsEntry, HintText: i18n.T("settings.namePatterns.hint")},
+		{Text: i18n.T("settings.dateRules"), Widget: dateRulesEntry, HintText: i18n.T("settings.dateRules.hint")},
+		{Text: i18n.T("settings.junkPatterns"), Widget: junkEntry, HintText: i18n.T("settings.junkPatterns.hint")},
+		{Text: i18n.T("settings.rename"), Widget: renameEntry, HintText: i18n.T("settings.rename.hint")},
+		widget.NewFormItem(i18n.T("settings.notify"), notifySelect),
+	}
+
+	dialog.ShowForm(i18n.T("settings.title"), i18n.T("settings.save"), i18n.T("settings.cancel"), items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		if _, err := organizer.ParseTimeZonePolicy(timeZoneEntry.Text); err != nil {
+			dialog.ShowError(err, a.window)
+			return
+		}
+		if _, err := organizer.ParseJunkPatterns(junkEntry.Text); err != nil {
+			dialog.ShowError(err, a.window)
+			return
+		}
+		if _, err := organizer.ParseRenameTemplate(renameEntry.Text); err != nil {
+			dialog.ShowError(err, a.window)
+			return
+		}
+		if _, err := metadata.NewFilenameMatcher(lines(patternsEntry.Text)...); err != nil {
+			dialog.ShowError(err, a.window)
+			return
+		}
+		if _, err := organizer.ParseDateRules(lines(dateRulesEntry.Text)); err != nil {
+			dialog.ShowError(err, a.window)
+			return
+		}
+		if themeFileEntry.Text != "" {
+			if _, err := apptheme.LoadDefinition(themeFileEntry.Text); err != nil {
+				dialog.ShowError(err, a.window)
+				return
+			}
+		}
+
+		updated := a.settings
+		updated.timeZone = timeZoneEntry.Text
+		updated.largeText = largeTextCheck.Checked
+		updated.mediaLocal = mediaLocalCheck.Checked
+		updated.themeFile = themeFileEntry.Text
+		updated.junk = junkEntry.Text
+		updated.rename = renameEntry.Text
+		updated.patterns = patternsEntry.Text
+		updated.dateRules = dateRulesEntry.Text
+		if i := languageSelect.SelectedIndex(); i >= 0 {
+			updated.language = languages[i]
+		}
+		if i := styleSelect.SelectedIndex(); i >= 0 {
+			updated.monthStyle = monthStyles[i]
+		}
+		if i := themeSelect.SelectedIndex(); i >= 0 {
+			updated.themeMode = themeModes[i]
+		}
+		if i := notifySelect.SelectedIndex(); i >= 0 {
+			updated.notify = notifyModes[i]
+		}
+		a.applySettings(updated)
+	}, a.window)
+}
+
+func (a *App) applySettings(s settings) {
+	languageChanged := s.language != a.settings.language
+	themeChanged := s.themeMode != a.settings.themeMode || s.largeText != a.settings.largeText || s.themeFile != a.settings.themeFile
+	a.settings = s
+	a.settings.save(a.prefs)
+	if themeChanged {
+		if err := a.applyTheme(); err != nil {
+			a.log(i18n.T("theme.fileError", err))
+		}
+	}
+	if languageChanged {
+		i18n.SetLanguage(s.language)
+		a.refreshTexts()
+	}
+}
+
+// applyTheme switches to the configured theme. A theme file that can't be
+// loaded is reported, and the built-in theme is used instead.
+func (a *App) applyTheme() error {
+	options := apptheme.Options{Mode: a.settings.themeMode, LargeText: a.settings.largeText}
+	var err error
+	if a.settings.themeFile != "" {
+		options.Definition, err = apptheme.LoadDefinition(a.settings.themeFile)
+	}
+	fyne.CurrentApp().Settings().SetTheme(apptheme.NewWithOptions(options))
+	return err
+}
@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+
+	"github.com/dale-tomson/declutter/internal/i18n"
+	"github.com/dale-tomson/declutter/internal/organizer"
+	apptheme "github.com/dale-tomson/declutter/internal/theme"
+)
+
+func TestSettingsRoundTrip(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	s := settings{language: i18n.French, monthStyle: organizer.MonthStyleNumeric, timeZone: "UTC", themeMode: apptheme.ModeLight, largeText: true, themeFile: "/etc/brand.toml", notify: notifyAlways, junk: "*.tmp, Thumbs.db", rename: "{date}_{counter:3}{ext}", mediaLocal: true, patterns: `(?P<day>\d{2})\.(?P<month>\d{2})\.(?P<year>\d{4})`, dateRules: ".pdf=document"}
+	s.save(app.Preferences())
+
+	loaded := loadSettings(app.Preferences())
+	if loaded != s {
+		t.Errorf("expected %+v, got %+v", s, loaded)
+	}
+	if loaded.timeZonePolicy().Mode != organizer.TimeZoneUTC {
+		t.Errorf("expected UTC policy, got %s", loaded.timeZonePolicy())
+	}
+}
+
+func TestSettingsInvalidTimeZoneFallsBack(t *testing.T) {
+	s := settings{timeZone: "Nowhere/Special"}
+	if s.timeZonePolicy().Mode != organizer.TimeZoneLocal {
+		t.Errorf("expected local policy, got %s", s.timeZonePolicy())
+	}
+}
+
+func TestSettingsInvalidRenameTemplateFallsBack(t *testing.T) {
+	s := settings{rename: "{when}"}
+	if s.renameTemplate() != "" {
+		t.Errorf("expected no renaming, got %q", s.renameTemplate())
+	}
+}
+
+func TestSettingsFilenamePatterns(t *testing.T) {
+	s := settings{patterns: "\n  (?P<day>\\d{2})\\.(?P<month>\\d{2})\\.(?P<year>\\d{4})  \n"}
+	d, ok := s.filenameMatcher().Match("scan 24.12.2023.pdf")
+	if !ok || d.Time.Month() != time.December || d.Time.Day() != 24 {
+		t.Errorf("expected 24 December from the custom pattern, got %v", d.Time)
+	}
+
+	s.patterns = "(?P<year>\\d{4})"
+	if s.filenameMatcher() != nil {
+		t.Error("expected no matcher for a pattern without month and day")
+	}
+}
+
+func TestOrganizerOptionsDateSources(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	ui := New(app.NewWindow("Test"))
+	ui.documentDatesCheck.SetChecked(true)
+	ui.settings.dateRules = ".mp4=mtime\n.mov = media, filename"
+	options := ui.organizerOptions()
+	if len(options.DateSources) != 1 || options.DateSources[0] != organizer.DateSourceDocument {
+		t.Errorf("expected only document dates, got %v", options.DateSources)
+	}
+	if len(options.DateSourcesByExt) != 2 || len(options.DateSourcesByExt[".mov"]) != 2 {
+		t.Errorf("expected rules for .mp4 and .mov, got %v", options.DateSourcesByExt)
+	}
+}
+
+func TestApplySettingsChangesLanguage(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+	defer i18n.SetLanguage(i18n.English)
+
+	w := app.NewWindow("Test")
+	ui := New(w)
+	w.SetContent(ui.GetContent())
+
+	updated := ui.settings
+	updated.language = i18n.German
+	ui.applySettings(updated)
+
+	if ui.selectFolderBtn.Text != "Ordner auswählen" {
+		t.Errorf("expected German button text, got '%s'", ui.selectFolderBtn.Text)
+	}
+	if ui.selectedFolderLabel.Text != "Kein Ordner ausgewählt" {
+		t.Errorf("expected German folder label, got '%s'", ui.selectedFolderLabel.Text)
+	}
+	if app.Preferences().String(prefLanguage) != string(i18n.German) {
+		t.Error("language preference not saved")
+	}
+}
+
+func TestApplySettingsChangesTheme(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	ui := New(app.NewWindow("Test"))
+	updated := ui.settings
+	updated.themeMode = apptheme.ModeHighContrast
+	updated.largeText = true
+	ui.applySettings(updated)
+
+	th, ok := app.Settings().Theme().(*apptheme.CustomTheme)
+	if !ok || th.Mode != apptheme.ModeHighContrast || !th.LargeText {
+		t.Errorf("expected the large high-contrast theme to be applied, got %#v", app.Settings().Theme())
+	}
+	if loadSettings(app.Preferences()).themeMode != apptheme.ModeHighContrast {
+		t.Error("expected the theme mode to be saved")
+	}
+}
+
+func TestApplySettingsThemeFile(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	path := filepath.Join(t.TempDir(), "brand.json")
+	if err := os.WriteFile(path, []byte(`{"colors": {"primary": "#ff6600"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := New(app.NewWindow("Test"))
+	updated := ui.settings
+	updated.themeFile = path
+	ui.applySettings(updated)
+
+	th, ok := app.Settings().Theme().(*apptheme.CustomTheme)
+	if !ok || th.Definition == nil {
+		t.Fatalf("expected the theme file to be applied, got %#v", app.Settings().Theme())
+	}
+
+	if err := os.WriteFile(path, []byte(`{"colors": {"primary": "orange"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ui = New(app.NewWindow("Test"))
+	th = app.Settings().Theme().(*apptheme.CustomTheme)
+	if th.Definition != nil {
+		t.Error("expected the built-in theme when the file is invalid")
+	}
+	if !strings.Contains(ui.logOutput.Text, "orange") {
+		t.Errorf("expected the theme error in the log, got %q", ui.logOutput.Text)
+	}
+}
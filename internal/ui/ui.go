@@ -1,18 +1,24 @@
 package ui
 
 import (
-	"fmt"
+	"errors"
 	"image/color"
-	"strings"
+	"sync"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/widget"
 
+	"github.com/dale-tomson/declutter/internal/i18n"
 	"github.com/dale-tomson/declutter/internal/icon"
 	"github.com/dale-tomson/declutter/internal/organizer"
+	"github.com/dale-tomson/declutter/internal/report"
+	"github.com/dale-tomson/declutter/internal/trash"
 	"github.com/dale-tomson/declutter/internal/version"
 )
 
@@ -21,15 +27,64 @@ type App struct {
 	selectedFolder      string
 	selectedFolderLabel *widget.Label
 	logOutput           *widget.Entry
+	logSearch           *widget.Entry
+	logLines            []string
+	files               *fileTable
+	tabs                *container.AppTabs
 	progress            *widget.ProgressBar
 	statusLabel         *widget.Label
 	selectFolderBtn     *widget.Button
 	organizeBtn         *widget.Button
+	saveReportBtn       *widget.Button
+	addToQueueBtn       *widget.Button
+	runQueueBtn         *widget.Button
+	clearQueueBtn       *widget.Button
+	queueList           *widget.List
+	queue               folderQueue
+	recentList          *widget.List
+	history             folderHistory
+	running             bool
+	cancelRequested     bool
+	current             *organizer.Organizer
+	fixExtensionsCheck  *widget.Check
+	mediaDatesCheck     *widget.Check
+	documentDatesCheck  *widget.Check
+	filenameDatesCheck  *widget.Check
+	emptyDirsCheck      *widget.Check
+	emptyFilesCheck     *widget.Check
+	junkCheck           *widget.Check
+	checksumsCheck      *widget.Check
+	normalizeCheck      *widget.Check
+	settingsBtn         *widget.Button
+	prefs               fyne.Preferences
+	settings            settings
+	results             []organizer.Result
+	lastReport          *report.Report
+	lastRun             *lastRun
+	notifier            *notifier
+	trash               trash.Trash
+	focused             bool
+	// background tracks the folder scans and runs started off the UI
+	// goroutine.
+	background sync.WaitGroup
 }
 
 func New(w fyne.Window) *App {
-	app := &App{window: w}
+	app := &App{window: w, prefs: fyne.CurrentApp().Preferences()}
+	app.settings = loadSettings(app.prefs)
+	if t, err := trash.New(); err == nil {
+		app.trash = t
+	}
+	i18n.SetLanguage(app.settings.language)
+	themeErr := app.applyTheme()
+	app.setupNotifications()
 	app.setupUI()
+	app.setupKeyboard()
+	if themeErr != nil {
+		app.log(i18n.T("theme.fileError", themeErr))
+	}
+	app.findInterruptedRun()
+	w.SetOnDropped(app.onDropped)
 	return app
 }
 
@@ -38,13 +93,31 @@ func (a *App) GetContent() fyne.CanvasObject {
 }
 
 func (a *App) setupUI() {
-	a.selectedFolderLabel = widget.NewLabel("No folder selected")
+	a.selectedFolderLabel = widget.NewLabel(i18n.T("folder.none"))
 	a.selectedFolderLabel.Wrapping = fyne.TextWrapWord
 
 	a.logOutput = widget.NewMultiLineEntry()
 	a.logOutput.Wrapping = fyne.TextWrapWord
 	a.logOutput.Disable()
 	a.logOutput.SetMinRowsVisible(12)
+	a.logSearch = widget.NewEntry()
+	a.logSearch.SetPlaceHolder(i18n.T("log.search"))
+	a.logSearch.OnChanged = func(string) { a.showLog() }
+
+	a.files = newFileTable()
+	a.setupQueue()
+	a.setupRecent()
+
+	a.tabs = container.NewAppTabs(
+		container.NewTabItem(i18n.T(tabTitles[0]), a.files.content()),
+		container.NewTabItem(i18n.T(tabTitles[1]), container.NewBorder(a.logSearch, nil, nil, nil, a.logOutput)),
+		container.NewTabItem(i18n.T(tabTitles[2]), a.queueContent()),
+		container.NewTabItem(i18n.T(tabTitles[3]), a.recentList),
+	)
+	a.tabs.SelectIndex(1)
+	if len(a.history.entries) > 0 {
+		a.tabs.SelectIndex(3)
+	}
 
 	a.progress = widget.NewProgressBar()
 	a.progress.Hide()
@@ -52,13 +125,70 @@ func (a *App) setupUI() {
 	a.statusLabel = widget.NewLabel("")
 	a.statusLabel.Alignment = fyne.TextAlignCenter
 
-	a.organizeBtn = widget.NewButton("Organize Files", nil)
+	a.organizeBtn = widget.NewButton(i18n.T("button.organize"), nil)
 	a.organizeBtn.Importance = widget.HighImportance
 	a.organizeBtn.Disable()
 	a.organizeBtn.OnTapped = a.onOrganize
 
-	a.selectFolderBtn = widget.NewButton("Select Folder", a.onSelectFolder)
+	a.saveReportBtn = widget.NewButton(i18n.T("button.saveReport"), a.onSaveReport)
+	a.saveReportBtn.Disable()
+
+	a.selectFolderBtn = widget.NewButton(i18n.T("button.select"), a.onSelectFolder)
 	a.selectFolderBtn.Importance = widget.MediumImportance
+
+	a.fixExtensionsCheck = widget.NewCheck(i18n.T("option.fixExtensions"), nil)
+	a.mediaDatesCheck = widget.NewCheck(i18n.T("option.mediaDates"), nil)
+	a.documentDatesCheck = widget.NewCheck(i18n.T("option.documentDates"), nil)
+	a.filenameDatesCheck = widget.NewCheck(i18n.T("option.filenameDates"), nil)
+	a.emptyDirsCheck = widget.NewCheck(i18n.T("option.emptyDirs"), nil)
+	a.emptyFilesCheck = widget.NewCheck(i18n.T("option.emptyFiles"), nil)
+	a.junkCheck = widget.NewCheck(i18n.T("option.junk"), nil)
+	a.checksumsCheck = widget.NewCheck(i18n.T("option.checksums"), nil)
+	a.normalizeCheck = widget.NewCheck(i18n.T("option.normalize"), nil)
+
+	a.settingsBtn = widget.NewButton(i18n.T("button.settings"), a.onSettings)
+}
+
+// refreshTexts re-applies translated labels after the language changes.
+func (a *App) refreshTexts() {
+	if a.selectedFolder == "" {
+		a.selectedFolderLabel.SetText(i18n.T("folder.none"))
+	}
+	a.organizeBtn.SetText(i18n.T("button.organize"))
+	a.selectFolderBtn.SetText(i18n.T("button.select"))
+	a.settingsBtn.SetText(i18n.T("button.settings"))
+	a.saveReportBtn.SetText(i18n.T("button.saveReport"))
+	a.addToQueueBtn.SetText(i18n.T("button.addToQueue"))
+	a.runQueueBtn.SetText(i18n.T("button.runQueue"))
+	a.clearQueueBtn.SetText(i18n.T("button.clearQueue"))
+	a.queueList.Refresh()
+	a.fixExtensionsCheck.Text = i18n.T("option.fixExtensions")
+	a.fixExtensionsCheck.Refresh()
+	a.mediaDatesCheck.Text = i18n.T("option.mediaDates")
+	a.mediaDatesCheck.Refresh()
+	a.documentDatesCheck.Text = i18n.T("option.documentDates")
+	a.documentDatesCheck.Refresh()
+	a.filenameDatesCheck.Text = i18n.T("option.filenameDates")
+	a.filenameDatesCheck.Refresh()
+	a.emptyDirsCheck.Text = i18n.T("option.emptyDirs")
+	a.emptyDirsCheck.Refresh()
+	a.emptyFilesCheck.Text = i18n.T("option.emptyFiles")
+	a.emptyFilesCheck.Refresh()
+	a.junkCheck.Text = i18n.T("option.junk")
+	a.junkCheck.Refresh()
+	a.checksumsCheck.Text = i18n.T("option.checksums")
+	a.checksumsCheck.Refresh()
+	a.normalizeCheck.Text = i18n.T("option.normalize")
+	a.normalizeCheck.Refresh()
+	a.files.refreshTexts()
+	a.recentList.Refresh()
+	a.logSearch.SetPlaceHolder(i18n.T("log.search"))
+	for i, key := range tabTitles {
+		a.tabs.Items[i].Text = i18n.T(key)
+	}
+	a.tabs.Refresh()
+	a.window.SetMainMenu(a.mainMenu())
+	a.window.SetContent(a.buildLayout())
 }
 
 func (a *App) buildLayout() fyne.CanvasObject {
@@ -78,7 +208,7 @@ func (a *App) buildLayout() fyne.CanvasObject {
 	titleLabel.TextStyle = fyne.TextStyle{Bold: true}
 
 	descLabel := widget.NewLabelWithStyle(
-		"Organize your files into Year/Month folders\nbased on their timestamps",
+		i18n.T("app.description"),
 		fyne.TextAlignLeading,
 		fyne.TextStyle{},
 	)
@@ -87,18 +217,31 @@ func (a *App) buildLayout() fyne.CanvasObject {
 	headerContent := container.NewHBox(logoWithBorder, container.NewCenter(titleSection))
 
 	folderSection := container.NewVBox(
-		widget.NewLabel("Selected Folder:"),
+		widget.NewLabel(i18n.T("folder.selected")),
 		container.NewBorder(nil, nil, nil, nil, a.selectedFolderLabel),
 	)
 
 	buttons := container.NewHBox(
 		a.selectFolderBtn,
 		a.organizeBtn,
+		a.addToQueueBtn,
+		a.saveReportBtn,
+		layout.NewSpacer(),
+		a.settingsBtn,
 	)
 
-	logSection := container.NewVBox(
-		widget.NewLabel("Activity Log:"),
-		container.NewMax(a.logOutput),
+	optionsRow := container.NewHBox(
+		a.fixExtensionsCheck,
+		a.mediaDatesCheck,
+		a.documentDatesCheck,
+		a.filenameDatesCheck,
+		a.checksumsCheck,
+		a.normalizeCheck,
+	)
+	cleanupRow := container.NewHBox(
+		a.emptyDirsCheck,
+		a.emptyFilesCheck,
+		a.junkCheck,
 	)
 
 	footerVersion := canvas.NewText("v"+version.Version, color.Gray{Y: 128})
@@ -112,31 +255,41 @@ func (a *App) buildLayout() fyne.CanvasObject {
 			widget.NewSeparator(),
 			folderSection,
 			buttons,
+			optionsRow,
+			cleanupRow,
 			a.progress,
 			a.statusLabel,
 		),
 		footer,
 		nil,
 		nil,
-		logSection,
+		a.tabs,
 	)
 
 	return container.NewPadded(content)
 }
 
+// maxLogLines bounds the activity log so that appending stays cheap on
+// large folders. The file table keeps the full per-file record.
+const maxLogLines = 500
+
 func (a *App) log(message string) {
 	fyne.Do(func() {
-		current := a.logOutput.Text
-		if current != "" {
-			current += "\n"
+		a.logLines = append(a.logLines, message)
+		if len(a.logLines) > maxLogLines {
+			a.logLines = a.logLines[len(a.logLines)-maxLogLines:]
 		}
-		a.logOutput.SetText(current + message)
-		a.logOutput.CursorRow = strings.Count(a.logOutput.Text, "\n")
+		a.showLog()
 	})
 }
 
+func (a *App) clearLog() {
+	a.logLines = nil
+	a.showLog()
+}
+
 func (a *App) onSelectFolder() {
-	dialog.ShowFolderOpen(func(uri fyne.ListableURI, err error) {
+	a.folderDialog(func(uri fyne.ListableURI, err error) {
 		if err != nil {
 			dialog.ShowError(err, a.window)
 			return
@@ -145,100 +298,279 @@ func (a *App) onSelectFolder() {
 			return
 		}
 
-		a.selectedFolder = uri.Path()
-		a.selectedFolderLabel.SetText("📂 " + a.selectedFolder)
-		a.organizeBtn.Enable()
-		a.logOutput.SetText("")
-		a.statusLabel.SetText("")
+		a.selectFolder(uri.Path())
+	}).Show()
+}
 
-		org := organizer.New(a.selectedFolder, nil)
-		files, err := org.GetFiles()
+// selectFolder makes path the folder to organize and reports what a scan
+// of it finds. The scan reads every file's header, so it runs off the UI
+// goroutine.
+func (a *App) selectFolder(path string) {
+	a.selectedFolder = path
+	a.selectedFolderLabel.SetText("📂 " + a.selectedFolder)
+	a.rememberFolder(path)
+	a.organizeBtn.Enable()
+	a.addToQueueBtn.Enable()
+	a.clearLog()
+	a.statusLabel.SetText("")
+
+	a.focus(a.organizeBtn)
+	a.background.Add(1)
+	go func() {
+		defer a.background.Done()
+		a.scanFolder(path)
+	}()
+}
+
+// scanFolder logs how many files path holds and how many carry the wrong
+// extension, then offers to resume an interrupted run there, unless
+// another folder was selected in the meantime.
+func (a *App) scanFolder(path string) {
+	var messages []string
+	org := organizer.NewWithOptions(path, organizer.Options{DetectContent: true}, func(msg string) {
+		messages = append(messages, msg)
+	})
+	files, err := org.GetFiles()
+	mismatched := 0
+	for _, f := range files {
+		if f.ExtensionMismatch() {
+			mismatched++
+		}
+	}
+	fyne.Do(func() {
+		if a.selectedFolder != path {
+			return
+		}
+		for _, msg := range messages {
+			a.log(msg)
+		}
 		if err != nil {
-			a.log(fmt.Sprintf("Error reading folder: %v", err))
+			a.log(i18n.T("folder.error", err))
 			return
 		}
-		a.log(fmt.Sprintf("Found %d files to organize", len(files)))
-	}, a.window)
+		a.log(i18n.T("folder.found", len(files)))
+		if mismatched > 0 {
+			a.log(i18n.T("folder.mismatched", mismatched))
+		}
+		a.offerResume(path)
+	})
 }
 
 func (a *App) onOrganize() {
 	if a.selectedFolder == "" {
-		dialog.ShowInformation("Info", "Please select a folder first", a.window)
+		dialog.ShowInformation(i18n.T("dialog.info"), i18n.T("dialog.selectFirst"), a.window)
+		return
+	}
+	if owner := organizer.LockOwner(a.selectedFolder); owner != nil {
+		a.offerUnlock(a.selectedFolder, *owner)
+		return
+	}
+	if run, _ := organizer.FindInterruptedRun(a.selectedFolder); run != nil {
+		a.offerResume(a.selectedFolder)
 		return
 	}
 
-	dialog.ShowConfirm("Confirm Organization",
-		fmt.Sprintf("This will organize all files in:\n%s\n\nFiles will be moved to Year/Month folders based on their modification dates.\n\nContinue?", a.selectedFolder),
-		func(confirmed bool) {
-			if !confirmed {
-				return
-			}
-			a.performOrganization()
-		}, a.window)
-}
+	a.clearLog()
+	a.setRunning(true)
+	a.statusLabel.SetText(i18n.T("status.scanning"))
 
-func (a *App) performOrganization() {
-	a.logOutput.SetText("")
-	a.progress.Show()
-	a.progress.SetValue(0)
-	a.selectFolderBtn.Disable()
-	a.organizeBtn.Disable()
-	a.statusLabel.SetText("Organizing...")
+	org := organizer.NewWithOptions(a.selectedFolder, a.organizerOptions(), a.log)
+	a.current = org
 
 	go func() {
-		org := organizer.New(a.selectedFolder, func(msg string) {
-			a.log(msg)
-		})
-
 		files, err := org.GetFiles()
 		if err != nil {
-			a.log(fmt.Sprintf("Error: %v", err))
+			a.log(i18n.T("log.error", err))
 			fyne.Do(func() {
-				a.progress.Hide()
-				a.selectFolderBtn.Enable()
-				a.organizeBtn.Enable()
-				a.statusLabel.SetText("Error occurred")
+				a.idle(i18n.T("status.error"))
+				a.notify(runSummary{folder: org.SourceDir(), err: err})
 			})
 			return
 		}
 
-		if len(files) == 0 {
-			a.log("No files found to organize")
-			fyne.Do(func() {
-				a.progress.Hide()
-				a.selectFolderBtn.Enable()
-				a.organizeBtn.Enable()
-				a.statusLabel.SetText("No files to organize")
-			})
+		plan := org.Plan(files)
+		cleanup, err := org.PlanCleanup()
+		if err != nil {
+			a.log(i18n.T("log.error", err))
+		}
+
+		if len(plan) == 0 && len(cleanup) == 0 {
+			a.log(i18n.T("log.noFiles"))
+			fyne.Do(func() { a.idle(i18n.T("status.noFiles")) })
 			return
 		}
+		issues := org.Preflight(plan)
 
 		fyne.Do(func() {
-			a.progress.SetValue(0.2)
+			if a.cancelRequested {
+				a.idle(i18n.T("status.cancelled"))
+				return
+			}
+			if len(issues) > 0 {
+				a.showPreflight(org, plan, cleanup, issues)
+				return
+			}
+			a.showPreview(org, plan, cleanup)
+		})
+	}()
+}
+
+// idle re-enables the buttons after a scan or run that didn't complete.
+func (a *App) idle(status string) {
+	a.progress.Hide()
+	a.setRunning(false)
+	a.statusLabel.SetText(status)
+}
+
+// setRunning disables the actions that would start another run while one
+// is in progress.
+func (a *App) setRunning(running bool) {
+	a.running = running
+	if running {
+		a.cancelRequested = false
+	} else {
+		a.current = nil
+	}
+	for _, btn := range []*widget.Button{a.selectFolderBtn, a.organizeBtn, a.addToQueueBtn, a.runQueueBtn} {
+		if running {
+			btn.Disable()
+		} else {
+			btn.Enable()
+		}
+	}
+	if !running && a.selectedFolder == "" {
+		a.organizeBtn.Disable()
+		a.addToQueueBtn.Disable()
+	}
+}
+
+func (a *App) organizerOptions() organizer.Options {
+	options := organizer.Options{
+		FixExtensions: a.fixExtensionsCheck.Checked,
+		MonthFormat:   a.settings.monthFormat(),
+		TimeZone:      a.settings.timeZonePolicy(),
+		Trash:         a.trash,
+		Checksums:     a.checksumsCheck.Checked,
+	}
+	options.MediaTimesAreLocal = a.settings.mediaLocal
+	options.FilenameMatcher = a.settings.filenameMatcher()
+	options.DateSourcesByExt = a.settings.dateSourcesByExt()
+	if a.mediaDatesCheck.Checked {
+		options.DateSources = append(options.DateSources, organizer.DateSourceMedia)
+	}
+	if a.documentDatesCheck.Checked {
+		options.DateSources = append(options.DateSources, organizer.DateSourceDocument)
+	}
+	if a.filenameDatesCheck.Checked {
+		options.DateSources = append(options.DateSources, organizer.DateSourceFilename)
+	}
+	if a.normalizeCheck.Checked {
+		options.Normalize = organizer.NormalizeOptions{NFC: true, ForbiddenChars: true, Whitespace: true, CaseInsensitive: true}
+	}
+	options.RenameTemplate = a.settings.renameTemplate()
+	options.Cleanup = organizer.CleanupOptions{
+		EmptyDirs:    a.emptyDirsCheck.Checked,
+		EmptyFiles:   a.emptyFilesCheck.Checked,
+		Junk:         a.junkCheck.Checked,
+		JunkPatterns: a.settings.junkPatterns(),
+	}
+	return options
+}
+
+func (a *App) performOrganization(org *organizer.Organizer, plan []organizer.PlannedMove, cleanup []organizer.CleanupItem) {
+	a.runPlan(org, plan, cleanup, func() (int, int, error) { return org.Execute(plan) })
+}
+
+// runPlan shows the progress of execute, which carries out plan, and
+// then runs the cleanup.
+func (a *App) runPlan(org *organizer.Organizer, plan []organizer.PlannedMove, cleanup []organizer.CleanupItem, execute func() (int, int, error)) {
+	a.progress.Show()
+	a.progress.SetValue(0)
+	a.setRunning(true)
+	a.current = org
+	a.statusLabel.SetText(i18n.T("status.organizing"))
+
+	a.files.setPlan(plan)
+	a.tabs.SelectIndex(0)
+	a.results = nil
+	a.saveReportBtn.Disable()
+	org.SetResultCallback(func(r organizer.Result) {
+		fyne.Do(func() {
+			a.results = append(a.results, r)
+			a.files.setResult(r)
 		})
-		a.log("Starting organization...")
+	})
+	started := time.Now()
 
-		moved, skipped, err := org.OrganizeFiles(files)
+	a.background.Add(1)
+	go func() {
+		defer a.background.Done()
+		a.log(i18n.T("log.starting"))
+
+		moved, skipped, err := execute()
+		if err == nil && len(cleanup) > 0 {
+			var removed int
+			removed, err = org.ExecuteCleanup(cleanup)
+			a.log(i18n.T("cleanup.done", removed))
+		}
+		trashed := org.Trashed()
 
 		fyne.Do(func() {
 			a.progress.SetValue(1.0)
 		})
 
 		if err != nil {
-			a.log(fmt.Sprintf("Error during organization: %v", err))
+			a.log(i18n.T("log.organizeError", err))
 		}
 
 		a.log("─────────────────────────────")
-		a.log(fmt.Sprintf("✅ Complete! Moved: %d, Skipped: %d", moved, skipped))
+		a.log(i18n.T("log.complete", moved, skipped))
 
 		fyne.Do(func() {
+			a.files.apply()
+			a.lastReport = report.FromResults(org.SourceDir(), started, time.Now(), a.results)
+			a.lastRun = &lastRun{dir: org.SourceDir(), results: a.results, trashed: trashed}
+			overall, _ := a.lastReport.Summary()
+			a.recordRun(org.SourceDir(), moved, skipped, overall.Failed)
+			a.saveReportBtn.Enable()
 			a.progress.Hide()
-			a.selectFolderBtn.Enable()
-			// Reset folder selection to encourage selecting a new folder
-			a.selectedFolder = ""
-			a.selectedFolderLabel.SetText("No folder selected - Select a folder to organize more files")
-			a.organizeBtn.Disable()
-			a.statusLabel.SetText(fmt.Sprintf("Done! %d files moved, %d skipped", moved, skipped))
+			a.setRunning(false)
+			if errors.Is(err, organizer.ErrCancelled) {
+				a.statusLabel.SetText(i18n.T("status.cancelled"))
+			} else {
+				a.statusLabel.SetText(i18n.T("status.done", moved, skipped))
+				a.notify(summarize(org.SourceDir(), a.results, err))
+			}
+			a.focus(a.selectFolderBtn)
 		})
 	}()
 }
+
+func (a *App) onSaveReport() {
+	if a.lastReport == nil {
+		return
+	}
+	d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, a.window)
+			return
+		}
+		if writer == nil {
+			return
+		}
+		defer writer.Close()
+
+		format, err := report.FormatFor(writer.URI().Name())
+		if err == nil {
+			err = a.lastReport.Write(writer, format)
+		}
+		if err != nil {
+			dialog.ShowError(errors.New(i18n.T("report.error", err)), a.window)
+			return
+		}
+		a.log(i18n.T("report.saved", writer.URI().Path()))
+	}, a.window)
+	d.SetFileName("declutter-report-" + a.lastReport.Started.Format("2006-01-02") + ".html")
+	d.SetFilter(storage.NewExtensionFileFilter([]string{".html", ".csv", ".json"}))
+	d.Show()
+}
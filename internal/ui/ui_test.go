@@ -1,9 +1,14 @@
 package ui
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"fyne.io/fyne/v2/test"
+
+	"github.com/dale-tomson/declutter/internal/organizer"
 )
 
 func TestNew(t *testing.T) {
@@ -114,3 +119,76 @@ func TestProgressHiddenByDefault(t *testing.T) {
 		t.Error("progress bar should be hidden by default")
 	}
 }
+
+func TestSelectFolderScansInBackground(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	dir := t.TempDir()
+	png := []byte{0x89, 'P', 'N', 'G', 0x0D, 0x0A, 0x1A, 0x0A}
+	if err := os.WriteFile(filepath.Join(dir, "photo.jpg"), png, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := New(app.NewWindow("Test"))
+	ui.selectFolder(dir)
+	if ui.organizeBtn.Disabled() {
+		t.Error("expected Organize to be enabled straight away")
+	}
+	ui.background.Wait()
+	log := strings.Join(ui.logLines, "\n")
+	if !strings.Contains(log, "Found 2 files to organize") || !strings.Contains(log, "1 files have a missing or mismatched extension") {
+		t.Errorf("unexpected log %q", log)
+	}
+
+	// A scan finishing after another folder was picked logs nothing.
+	ui.selectedFolder = t.TempDir()
+	ui.logLines = nil
+	ui.scanFolder(dir)
+	if len(ui.logLines) != 0 {
+		t.Errorf("expected no log for a folder no longer selected, got %q", ui.logLines)
+	}
+}
+
+func TestRunKeepsSelection(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "photo.jpg"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ui := New(app.NewWindow("Test"))
+	ui.selectFolder(dir)
+	ui.background.Wait()
+
+	org := organizer.NewWithOptions(dir, ui.organizerOptions(), nil)
+	files, err := org.GetFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ui.performOrganization(org, org.Plan(files), nil)
+	ui.background.Wait()
+
+	if ui.running || ui.selectedFolder != dir || ui.selectedFolderLabel.Text != "📂 "+dir {
+		t.Errorf("expected %s to stay selected, got %q", dir, ui.selectedFolderLabel.Text)
+	}
+	if ui.organizeBtn.Disabled() || ui.addToQueueBtn.Disabled() {
+		t.Error("expected Organize and Add to queue to stay enabled")
+	}
+}
+
+func TestOrganizerOptionsCleanupKinds(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	ui := New(app.NewWindow("Test"))
+	ui.emptyFilesCheck.SetChecked(true)
+	c := ui.organizerOptions().Cleanup
+	if c.EmptyDirs || !c.EmptyFiles || c.Junk {
+		t.Errorf("expected only empty files to be cleaned up, got %+v", c)
+	}
+}
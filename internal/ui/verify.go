@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"errors"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+
+	"github.com/dale-tomson/declutter/internal/i18n"
+	"github.com/dale-tomson/declutter/internal/organizer"
+)
+
+// onVerify re-hashes the selected folder's files against the SHA256SUMS
+// files written when they were organized.
+func (a *App) onVerify() {
+	if a.running {
+		return
+	}
+	if a.selectedFolder == "" {
+		dialog.ShowInformation(i18n.T("dialog.info"), i18n.T("dialog.selectFirst"), a.window)
+		return
+	}
+	org := organizer.New(a.selectedFolder, a.log)
+	a.clearLog()
+	a.setRunning(true)
+	a.current = org
+	a.tabs.SelectIndex(1)
+	a.statusLabel.SetText(i18n.T("status.verifying"))
+	go a.verify(org)
+}
+
+// verify runs off the UI goroutine. Problems are listed in the log and
+// summed up in a dialog.
+func (a *App) verify(org *organizer.Organizer) {
+	results, err := org.VerifyChecksums()
+	counts := make(map[organizer.ChecksumStatus]int)
+	for _, r := range results {
+		counts[r.Status]++
+	}
+	summary := i18n.T("verify.done", counts[organizer.ChecksumOK], counts[organizer.ChecksumChanged],
+		counts[organizer.ChecksumMissing], counts[organizer.ChecksumUnexpected])
+	switch {
+	case errors.Is(err, organizer.ErrCancelled):
+		summary = i18n.T("status.cancelled")
+	case err != nil:
+		a.log(i18n.T("log.error", err))
+		summary = i18n.T("status.error")
+	case len(results) == 0:
+		summary = i18n.T("verify.none")
+	}
+	a.log(summary)
+
+	fyne.Do(func() {
+		a.setRunning(false)
+		a.statusLabel.SetText(summary)
+		if err == nil && counts[organizer.ChecksumOK] < len(results) {
+			dialog.ShowInformation(i18n.T("verify.title"), summary, a.window)
+		}
+	})
+}
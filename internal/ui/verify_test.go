@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+
+	"github.com/dale-tomson/declutter/internal/organizer"
+)
+
+func TestVerify(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	w := app.NewWindow("Test")
+	ui := New(w)
+	w.SetContent(ui.GetContent())
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.jpg")
+	if err := os.WriteFile(path, []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	march := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(path, march, march); err != nil {
+		t.Fatal(err)
+	}
+
+	ui.checksumsCheck.SetChecked(true)
+	org := organizer.NewWithOptions(dir, ui.organizerOptions(), nil)
+	files, _ := org.GetFiles()
+	if _, _, err := org.Execute(org.Plan(files)); err != nil {
+		t.Fatal(err)
+	}
+
+	ui.setRunning(true)
+	ui.verify(organizer.New(dir, ui.log))
+	if ui.running || ui.statusLabel.Text != "1 ok, 0 changed, 0 missing, 0 unexpected" {
+		t.Errorf("unexpected status %q", ui.statusLabel.Text)
+	}
+
+	if err := os.Remove(filepath.Join(dir, "2024", "03-March", "a.jpg")); err != nil {
+		t.Fatal(err)
+	}
+	ui.verify(organizer.New(dir, ui.log))
+	if ui.statusLabel.Text != "0 ok, 0 changed, 1 missing, 0 unexpected" {
+		t.Errorf("unexpected status %q", ui.statusLabel.Text)
+	}
+}